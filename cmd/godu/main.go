@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -12,10 +15,14 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sadopc/godu/internal/config"
+	"github.com/sadopc/godu/internal/model"
 	"github.com/sadopc/godu/internal/ops"
 	"github.com/sadopc/godu/internal/remote"
 	"github.com/sadopc/godu/internal/scanner"
 	"github.com/sadopc/godu/internal/ui"
+	"github.com/sadopc/godu/internal/ui/style"
+	"github.com/sadopc/godu/internal/util"
 )
 
 var (
@@ -27,25 +34,86 @@ const defaultSSHPort = 22
 type scanTarget struct {
 	Remote         bool
 	LocalPath      string
+	LocalPaths     []string
 	SSHDestination string
 	RemotePath     string
 }
 
 func main() {
+	stopSignalCleanup := ops.InstallSignalCleanup()
+	defer stopSignalCleanup()
+
+	if iconsPath, err := config.DefaultDirIconsPath(); err == nil {
+		if err := config.LoadDirIcons(iconsPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	if categoriesPath, err := config.DefaultFileCategoriesPath(); err == nil {
+		warnings, err := config.LoadFileCategories(categoriesPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+	}
+
 	// Flags
 	exportPath := flag.String("export", "", "Export scan results to JSON file (headless mode, use '-' for stdout)")
-	importPath := flag.String("import", "", "Import and view scan results from JSON file")
+	exportFormat := flag.String("export-format", "ncdu", "Format for -export: ncdu (JSON), csv (flat, one row per file/dir), or html (self-contained report with a treemap overview and collapsible tree); csv and html are incompatible with -export-pretty/-low-memory-export/-stream-export")
+	exportPretty := flag.Bool("export-pretty", false, "Indent -export output for readability (still valid ncdu JSON)")
+	lowMemoryExport := flag.Bool("low-memory-export", false, "Free each subtree from memory as soon as it's written during -export (incompatible with -export-pretty)")
+	streamExport := flag.Bool("stream-export", false, "Scan and write -export one top-level directory at a time, instead of building the whole tree before exporting any of it (incompatible with -export-pretty and -min-dir-size)")
+	showProgress := flag.Bool("progress", false, "With -export, print an updating files/dirs/speed line to stderr while scanning; ignored when exporting to stdout with -export -")
+	importPath := flag.String("import", "", "Import and view scan results from JSON file(s); comma-separate multiple files to merge them under one synthetic root, use '-' to read from stdin")
+	anonymize := flag.Bool("anonymize", false, "With --import and --export, replace real file/directory names with sequential placeholders before re-exporting")
 	showHidden := flag.Bool("hidden", true, "Show hidden files")
 	noHidden := flag.Bool("no-hidden", false, "Hide hidden files")
+	noHiddenTop := flag.Bool("no-hidden-top", false, "Hide dotfiles only at the scan root; keep showing hidden entries nested deeper")
 	showVersion := flag.Bool("version", false, "Show version")
 	disableGC := flag.Bool("no-gc", false, "Disable GC during scan (faster but uses more memory)")
 	exclude := flag.String("exclude", "", "Comma-separated list of directory names to exclude")
+	gitignoreFlag := flag.Bool("gitignore", false, "Skip files and directories matched by .gitignore files encountered during the scan (local scans only; ignored for remote/SFTP scans)")
 	followSymlinks := flag.Bool("follow-symlinks", false, "Follow symbolic links during scan")
+	countSymlinkSizes := flag.Bool("count-symlink-sizes", false, "With -follow-symlinks, count each symlink's full target size even if seen before (backup-style sizing)")
 	concurrency := flag.Int("j", 0, "Max concurrent directory scans (0 = auto: 3x CPU cores)")
+	maxDirEntries := flag.Int("max-dir-entries", 0, "Collapse directories with more than this many immediate entries into a single summed node (0 = unlimited)")
+	jsonErrors := flag.String("json-errors", "", "Write scan errors (path + message) as a JSON array to this file after a headless -export scan ('-' for stderr)")
 	sshPort := flag.Int("ssh-port", defaultSSHPort, "SSH port for remote scans")
 	sshBatch := flag.Bool("ssh-batch", false, "Disable SSH password prompts (key/agent auth only)")
 	sshTimeout := flag.Int("ssh-timeout", 15, "SSH connection timeout in seconds (default 15)")
 	sshScanTimeout := flag.Int("ssh-scan-timeout", 0, "SSH scan timeout in seconds (0 = no limit)")
+	sshKeepAlive := flag.Int("ssh-keepalive", 0, "Send an SSH keepalive every N seconds during remote scans (0 = disabled)")
+	sshJump := flag.String("ssh-jump", "", "Jump host (user@bastion) to tunnel the remote scan's SSH connection through")
+	sshConcurrency := flag.Int("ssh-concurrency", 8, "Max concurrent SFTP operations during remote scans, independent of -j")
+	sshRetries := flag.Int("ssh-retries", 0, "Retry a directory read or stat this many times on transient SFTP errors before flagging the path (permission-denied/not-exist are never retried)")
+	sshPrintFingerprint := flag.Bool("ssh-print-fingerprint", false, "Connect to the remote target, print its SSH host key fingerprint, and exit (no scan, no known_hosts changes)")
+	scanTimeout := flag.Int("timeout", 0, "Local scan timeout in seconds; on expiry the partial tree is used (0 = no limit)")
+	report := flag.String("report", "", "Run a headless report instead of the TUI (supported: perms, extensions)")
+	reportFormat := flag.String("report-format", "table", "Output format for -report (table, json)")
+	oneFileSystem := flag.Bool("one-file-system", false, "Don't descend into directories on a different filesystem than the scan root, like du -x or find -xdev")
+	flag.BoolVar(oneFileSystem, "x", false, "Shorthand for -one-file-system")
+	cross := flag.String("cross", "", "Comma-separated mount paths to still traverse despite -one-file-system")
+	dangerThreshold := flag.Float64("danger-threshold", 0, "Highlight an item's size bar in red once it exceeds this fraction of its parent's size, e.g. 0.5 (0 = disabled)")
+	minDirSize := flag.String("min-dir-size", "", "Hide directories smaller than this size, e.g. 1G (applies to TUI and -export; empty = disabled)")
+	minSize := flag.String("min-size", "", "Exclude files smaller than this size from the scan entirely, e.g. 10M (applies to local and remote scans; empty = disabled)")
+	maxDepth := flag.Int("max-depth", 0, "Stop descending into directories more than this many levels below the scan root, for a quick top-level overview (0 = unlimited)")
+	olderThan := flag.String("older-than", "", "Mark files whose mtime is older than this age as stale, e.g. 30d or 6mo (empty = disabled)")
+	oneline := flag.Bool("oneline", false, "Scan then print '<size> <path>' and exit, like du -sh (headless, no TUI/export)")
+	apparent := flag.Bool("apparent", false, "With -oneline/-root-only/-top/-summary/-report extensions, report apparent size instead of disk usage")
+	rootOnly := flag.Bool("root-only", false, "Scan and print only the root's total size, like du -s (headless, no TUI/export/per-item output)")
+	flag.BoolVar(rootOnly, "s", false, "Shorthand for -root-only")
+	siUnits := flag.Bool("si", false, "Use SI (decimal, powers of 1000) units instead of binary everywhere sizes are shown: the TUI, -oneline, -root-only, -top, and -summary")
+	precision := flag.Int("precision", 1, "Number of decimal places for formatted sizes and counts, 0-2")
+	topN := flag.Int("top", 0, "Scan and print the N largest files and exit (headless, no TUI/export); 0 disables")
+	summaryN := flag.Int("summary", 0, "Scan and print the N largest directories by disk usage, one per line, and exit (headless, no TUI/export); 0 disables")
+	asciiFlag := flag.Bool("ascii", false, "Force ASCII borders/bars, overriding auto-detection from TERM/LANG")
+	unicodeFlag := flag.Bool("unicode", false, "Force Unicode box-drawing borders/bars, overriding auto-detection from TERM/LANG")
+	debugLog := flag.String("debug", "", "Write structured scan-decision debug logs (exclusions, symlinks followed, skipped special files) to this file; disabled by default since the TUI owns the terminal")
+	useTrash := flag.Bool("trash", false, "Move deleted items to the platform trash/recycle bin instead of deleting them permanently")
+	readOnly := flag.Bool("read-only", false, "Disable all deletion, for demos and shared sessions; stronger than import mode since it also applies to live scans")
+	themeName := flag.String("theme", "dark", "Color theme: dark or light")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "godu - Interactive disk usage analyzer\n\n")
@@ -56,31 +124,84 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  godu .                          Scan current directory\n")
 		fmt.Fprintf(os.Stderr, "  godu /home                      Scan /home\n")
 		fmt.Fprintf(os.Stderr, "  godu --export scan.json .       Export scan to JSON\n")
+		fmt.Fprintf(os.Stderr, "  godu --export scan.json --export-pretty .  Export scan to indented JSON\n")
+		fmt.Fprintf(os.Stderr, "  godu --export scan.json --low-memory-export .  Free subtrees as they're written\n")
+		fmt.Fprintf(os.Stderr, "  godu --export scan.json --stream-export /mnt/huge  Write top-level subtrees as they finish scanning\n")
+		fmt.Fprintf(os.Stderr, "  godu --export scan.csv --export-format csv .  Export scan as a flat CSV for spreadsheets\n")
+		fmt.Fprintf(os.Stderr, "  godu --export report.html --export-format html .  Export scan as a self-contained HTML report\n")
+		fmt.Fprintf(os.Stderr, "  godu --export scan.json.gz .    Export scan as gzip-compressed JSON\n")
+		fmt.Fprintf(os.Stderr, "  godu --export scan.json --progress /mnt/huge  Show a live files/dirs/speed line while scanning\n")
 		fmt.Fprintf(os.Stderr, "  godu --import scan.json         View exported scan\n")
+		fmt.Fprintf(os.Stderr, "  godu --import scan.json.gz      View a gzip-compressed export\n")
+		fmt.Fprintf(os.Stderr, "  ssh host godu --export - / | godu --import -  Pipe a remote scan straight into a local view\n")
+		fmt.Fprintf(os.Stderr, "  godu --import host-a.json,host-b.json   Merge and compare multiple exports\n")
+		fmt.Fprintf(os.Stderr, "  godu --import scan.json --anonymize --export shared.json  Strip real names before sharing\n")
 		fmt.Fprintf(os.Stderr, "  godu user@192.168.1.10          Scan remote home directory over SSH\n")
 		fmt.Fprintf(os.Stderr, "  godu --ssh-port 2222 user@host /var/log\n")
 		fmt.Fprintf(os.Stderr, "  godu --ssh-batch user@host      Key-based/agent auth only (no password prompt)\n")
+		fmt.Fprintf(os.Stderr, "  godu --ssh-print-fingerprint user@host  Print host key fingerprint and exit\n")
 		fmt.Fprintf(os.Stderr, "  godu --follow-symlinks .        Follow symlinks during scan\n")
 		fmt.Fprintf(os.Stderr, "  godu -j 8 /home                 Scan with 8 concurrent workers\n")
+		fmt.Fprintf(os.Stderr, "  godu -timeout 30 /mnt/huge      Bound scan time, show partial results\n")
+		fmt.Fprintf(os.Stderr, "  godu --oneline /var/log         Print total size and exit, like du -sh\n")
+		fmt.Fprintf(os.Stderr, "  godu -s /var/log                Print only the total size and exit, like du -s\n")
+		fmt.Fprintf(os.Stderr, "  godu -s --si /var/log           Print the total size in SI (decimal) units\n")
+		fmt.Fprintf(os.Stderr, "  godu --precision 0 /var/log     Show sizes with no decimal places, e.g. \"1 GiB\"\n")
+		fmt.Fprintf(os.Stderr, "  godu --top 20 /var/log          Print the 20 largest files and exit\n")
+		fmt.Fprintf(os.Stderr, "  godu --summary 10 /var/log      Print the 10 largest directories by disk usage and exit\n")
+		fmt.Fprintf(os.Stderr, "  godu --no-hidden-top ~          Hide top-level dotfiles, keep nested ones\n")
+		fmt.Fprintf(os.Stderr, "  godu --danger-threshold 0.5 .   Highlight bars for items over half their parent\n")
+		fmt.Fprintf(os.Stderr, "  godu --min-dir-size 1G /data    Hide directories smaller than 1 GiB\n")
+		fmt.Fprintf(os.Stderr, "  godu --min-size 10M /data       Exclude files smaller than 10 MiB from the scan\n")
+		fmt.Fprintf(os.Stderr, "  godu --max-depth 1 /data        Quick top-level overview; deeper dirs are left unscanned\n")
+		fmt.Fprintf(os.Stderr, "  godu --ascii .                  Force ASCII borders/bars on a minimal terminal\n")
+		fmt.Fprintf(os.Stderr, "  godu --debug scan.log .         Log scan decisions (exclusions, symlinks, skips) to a file\n")
+		fmt.Fprintf(os.Stderr, "  godu --trash .                  Move deletions to the trash instead of removing them permanently\n")
+		fmt.Fprintf(os.Stderr, "  godu --read-only /data          Browse without risk of deleting anything, for demos and shared sessions\n")
+		fmt.Fprintf(os.Stderr, "  godu --theme light .            Use a light color theme for light terminal backgrounds\n")
+		fmt.Fprintf(os.Stderr, "  godu --gitignore .              Skip files and directories matched by .gitignore\n")
+		fmt.Fprintf(os.Stderr, "  godu --older-than 6mo /data     Flag files not modified in 6 months as stale\n")
+		fmt.Fprintf(os.Stderr, "  godu -x /                       Scan / without descending into other mounted filesystems\n")
+		fmt.Fprintf(os.Stderr, "  godu /var /home /opt            Scan multiple roots and browse them as one combined tree\n")
 	}
 
 	flag.Parse()
 
-	// Detect conflicting --hidden / --no-hidden flags
-	hiddenSet, noHiddenSet := false, false
+	util.SetSIUnits(*siUnits)
+	util.SetSizePrecision(*precision)
+
+	// Detect conflicting --hidden / --no-hidden flags, and whether --ssh-port
+	// was passed explicitly (it should override a port from ~/.ssh/config).
+	// explicitFlags additionally records every flag the user actually typed,
+	// so a persistent config file default never overrides one of them.
+	hiddenSet, noHiddenSet, sshPortSet := false, false, false
+	explicitFlags := map[string]bool{}
 	flag.Visit(func(f *flag.Flag) {
+		explicitFlags[f.Name] = true
 		if f.Name == "hidden" {
 			hiddenSet = true
 		}
 		if f.Name == "no-hidden" {
 			noHiddenSet = true
 		}
+		if f.Name == "ssh-port" {
+			sshPortSet = true
+		}
 	})
 	if hiddenSet && noHiddenSet {
 		fmt.Fprintf(os.Stderr, "Error: --hidden and --no-hidden cannot be used together\n")
 		os.Exit(1)
 	}
 
+	if settingsPath, err := config.DefaultSettingsPath(); err == nil {
+		settings, err := config.LoadSettings(settingsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else {
+			applyPersistentSettings(settings, explicitFlags, showHidden, followSymlinks, concurrency, exclude, themeName)
+		}
+	}
+
 	if *showVersion {
 		fmt.Printf("godu %s\n", version)
 		os.Exit(0)
@@ -91,6 +212,83 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *asciiFlag && *unicodeFlag {
+		fmt.Fprintf(os.Stderr, "Error: --ascii and --unicode cannot be used together\n")
+		os.Exit(1)
+	}
+
+	if *streamExport && *exportPretty {
+		fmt.Fprintf(os.Stderr, "Error: --stream-export does not support --export-pretty\n")
+		os.Exit(1)
+	}
+	if *streamExport && *lowMemoryExport {
+		fmt.Fprintf(os.Stderr, "Error: --stream-export and --low-memory-export cannot be used together (--stream-export already frees each subtree as it is written)\n")
+		os.Exit(1)
+	}
+	if *exportFormat != "ncdu" && *exportFormat != "csv" && *exportFormat != "html" {
+		fmt.Fprintf(os.Stderr, "Error: --export-format must be \"ncdu\", \"csv\", or \"html\"\n")
+		os.Exit(1)
+	}
+	if *exportFormat == "csv" || *exportFormat == "html" {
+		if *exportPretty {
+			fmt.Fprintf(os.Stderr, "Error: --export-format %s does not support --export-pretty\n", *exportFormat)
+			os.Exit(1)
+		}
+		if *lowMemoryExport {
+			fmt.Fprintf(os.Stderr, "Error: --export-format %s does not support --low-memory-export\n", *exportFormat)
+			os.Exit(1)
+		}
+		if *streamExport {
+			fmt.Fprintf(os.Stderr, "Error: --export-format %s does not support --stream-export\n", *exportFormat)
+			os.Exit(1)
+		}
+	}
+	if *themeName != "dark" && *themeName != "light" {
+		fmt.Fprintf(os.Stderr, "Error: --theme must be \"dark\" or \"light\"\n")
+		os.Exit(1)
+	}
+	theme := style.DefaultTheme()
+	if *themeName == "light" {
+		theme = style.LightTheme()
+	}
+	keys := ui.DefaultKeyMap()
+	if remapPath, err := config.DefaultKeyRemapPath(); err == nil {
+		overrides, err := config.LoadKeyRemap(remapPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else if overrides != nil {
+			warnings, err := keys.ApplyRemap(overrides)
+			for _, w := range warnings {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+	caps := style.DetectCapabilities()
+	if *asciiFlag {
+		caps.ASCII = true
+	}
+	if *unicodeFlag {
+		caps.ASCII = false
+	}
+
+	var minDirSizeBytes int64
+	if *minDirSize != "" {
+		var err error
+		minDirSizeBytes, err = util.ParseSize(*minDirSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid -min-dir-size %q: %v\n", *minDirSize, err)
+			os.Exit(1)
+		}
+		if minDirSizeBytes > 0 && *streamExport {
+			fmt.Fprintf(os.Stderr, "Error: --stream-export does not support --min-dir-size (each subtree is written and freed before the rest of the tree exists to prune against)\n")
+			os.Exit(1)
+		}
+	}
+
 	// Import mode
 	if *importPath != "" {
 		if flag.NArg() > 0 {
@@ -98,14 +296,26 @@ func main() {
 			os.Exit(1)
 		}
 
+		importPaths := splitComma(*importPath)
+
 		if *exportPath != "" {
-			// Re-export an imported scan
-			root, err := ops.ImportJSON(*importPath)
+			// Re-export an imported scan, merging first if multiple were given.
+			root, err := importAndMerge(importPaths)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error importing: %v\n", err)
 				os.Exit(1)
 			}
-			if err := ops.ExportJSON(root, *exportPath, version); err != nil {
+			if *anonymize {
+				root = ops.Anonymize(root)
+			}
+			if *exportFormat == "csv" {
+				err = ops.ExportCSV(root, *exportPath)
+			} else if *exportFormat == "html" {
+				err = ops.ExportHTML(root, *exportPath)
+			} else {
+				err = ops.ExportJSON(root, *exportPath, version, *exportPretty)
+			}
+			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
 				os.Exit(1)
 			}
@@ -115,9 +325,14 @@ func main() {
 			os.Exit(0)
 		}
 
-		app := ui.NewAppFromImport(*importPath)
+		app := ui.NewAppFromImport(importPaths...)
 		app.Version = version
-		p := tea.NewProgram(app, tea.WithAltScreen())
+		app.DangerThreshold = *dangerThreshold
+		app.MinDirSize = minDirSizeBytes
+		app.SetCapabilities(caps)
+		app.SetTheme(theme)
+		app.SetKeyMap(keys)
+		p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 		if _, err := p.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -126,22 +341,76 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		if summary := app.DeletionSummary(); summary != "" {
+			fmt.Println(summary)
+		}
 		return
 	}
 
 	// Build scan options
 	opts := scanner.DefaultOptions()
+	if *debugLog != "" {
+		f, err := os.Create(*debugLog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot open -debug log file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		opts.Logger = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
 	opts.ShowHidden = *showHidden
 	if *noHidden {
 		opts.ShowHidden = false
 	}
 	opts.DisableGC = *disableGC
+	opts.NoHiddenTop = *noHiddenTop
 	opts.FollowSymlinks = *followSymlinks
+	opts.CountSymlinkSizes = *countSymlinkSizes
+	if *maxDirEntries < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -max-dir-entries must be >= 0\n")
+		os.Exit(1)
+	}
+	opts.MaxDirEntries = *maxDirEntries
 	if *concurrency < 0 {
 		fmt.Fprintf(os.Stderr, "Error: concurrency (-j) must be >= 0\n")
 		os.Exit(1)
 	}
 	opts.Concurrency = *concurrency
+	if *minSize != "" {
+		minSizeBytes, err := util.ParseSize(*minSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid -min-size %q: %v\n", *minSize, err)
+			os.Exit(1)
+		}
+		opts.MinSize = minSizeBytes
+	}
+	if *maxDepth < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -max-depth must be >= 0\n")
+		os.Exit(1)
+	}
+	opts.MaxDepth = *maxDepth
+
+	if *olderThan != "" {
+		age, err := util.ParseAge(*olderThan)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid -older-than %q: %v\n", *olderThan, err)
+			os.Exit(1)
+		}
+		opts.OlderThan = time.Now().Add(-age)
+	}
+
+	if *dangerThreshold < 0 || *dangerThreshold > 1 {
+		fmt.Fprintf(os.Stderr, "Error: -danger-threshold must be between 0 and 1\n")
+		os.Exit(1)
+	}
+
+	if *scanTimeout < 0 {
+		fmt.Fprintf(os.Stderr, "Error: timeout must be >= 0\n")
+		os.Exit(1)
+	}
+	if *scanTimeout > 0 {
+		opts.Timeout = time.Duration(*scanTimeout) * time.Second
+	}
 
 	if *exclude != "" {
 		for _, e := range splitComma(*exclude) {
@@ -150,6 +419,16 @@ func main() {
 			}
 		}
 	}
+	opts.RespectGitignore = *gitignoreFlag
+	opts.OneFileSystem = *oneFileSystem
+
+	if *cross != "" {
+		for _, p := range splitComma(*cross) {
+			if p != "" {
+				opts.CrossMounts = append(opts.CrossMounts, p)
+			}
+		}
+	}
 
 	target, err := resolveScanTarget(flag.Args())
 	if err != nil {
@@ -157,14 +436,86 @@ func main() {
 		os.Exit(1)
 	}
 
+	resolvedSSHPort := *sshPort
+	if !sshPortSet && target.Remote && !strings.Contains(target.SSHDestination, "@") {
+		if entry, ok, _ := remote.LookupSSHConfig(target.SSHDestination); ok && entry.Port != 0 {
+			resolvedSSHPort = entry.Port
+		}
+	}
+
+	if *sshPrintFingerprint {
+		if !target.Remote {
+			fmt.Fprintf(os.Stderr, "Error: --ssh-print-fingerprint requires a user@host target\n")
+			os.Exit(1)
+		}
+		keyType, fingerprint, err := remote.FetchHostFingerprint(context.Background(), target.SSHDestination, resolvedSSHPort, time.Duration(*sshTimeout)*time.Second)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s key fingerprint is %s\n", keyType, fingerprint)
+		return
+	}
+
 	if target.Remote {
-		if err := runRemoteScan(target, *sshPort, *sshBatch, *sshTimeout, *sshScanTimeout, *exportPath, opts); err != nil {
+		if err := runRemoteScan(target, resolvedSSHPort, *sshBatch, *sshTimeout, *sshScanTimeout, *sshKeepAlive, *sshJump, *sshConcurrency, *sshRetries, *exportPath, *exportPretty, *dangerThreshold, minDirSizeBytes, caps, theme, keys, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 		return
 	}
 
+	if len(target.LocalPaths) > 1 {
+		if *oneline || *rootOnly || *topN > 0 || *summaryN > 0 || *report != "" || *exportPath != "" {
+			fmt.Fprintf(os.Stderr, "Error: scanning multiple paths is only supported in the interactive TUI, not with -oneline/-root-only/-top/-summary/-report/-export\n")
+			os.Exit(1)
+		}
+
+		absPaths := make([]string, len(target.LocalPaths))
+		for i, p := range target.LocalPaths {
+			abs, err := filepath.Abs(p)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			info, err := os.Stat(abs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if !info.IsDir() {
+				fmt.Fprintf(os.Stderr, "Error: %s is not a directory\n", abs)
+				os.Exit(1)
+			}
+			absPaths[i] = abs
+		}
+
+		app := ui.NewAppMulti(absPaths, opts)
+		app.ExportPath = "godu-export.json"
+		app.Version = version
+		app.DangerThreshold = *dangerThreshold
+		app.MinDirSize = minDirSizeBytes
+		app.UseTrash = *useTrash
+		app.ReadOnly = *readOnly
+		app.SetCapabilities(caps)
+		app.SetTheme(theme)
+		app.SetKeyMap(keys)
+
+		p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
+		if _, err := p.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := app.FatalError(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if summary := app.DeletionSummary(); summary != "" {
+			fmt.Println(summary)
+		}
+		return
+	}
+
 	absPath, err := filepath.Abs(target.LocalPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -182,18 +533,195 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Headless single-line mode: scan and print "<size> <path>", like du -sh.
+	if *oneline {
+		s := scanner.NewParallelScanner()
+		root, err := s.Scan(context.Background(), absPath, opts, nil)
+		if err != nil {
+			if !errors.Is(err, scanner.ErrScanTimedOut) {
+				fmt.Fprintf(os.Stderr, "Scan error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		size := root.GetUsage()
+		if *apparent {
+			size = root.GetSize()
+		}
+		fmt.Printf("%s %s\n", util.FormatSize(size), absPath)
+		return
+	}
+
+	// Headless root-only mode: scan and print only the total size, like
+	// du -s. No tree is formatted, making this the fastest headless path.
+	if *rootOnly {
+		s := scanner.NewParallelScanner()
+		root, err := s.Scan(context.Background(), absPath, opts, nil)
+		if err != nil {
+			if !errors.Is(err, scanner.ErrScanTimedOut) {
+				fmt.Fprintf(os.Stderr, "Scan error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		size := root.GetUsage()
+		if *apparent {
+			size = root.GetSize()
+		}
+		fmt.Println(util.FormatSize(size))
+		return
+	}
+
+	// Headless top-files mode: scan and print the N largest files, ranked
+	// with a bounded min-heap so memory and time stay proportional to N
+	// rather than to the total number of files in the tree.
+	if *topN > 0 {
+		s := scanner.NewParallelScanner()
+		root, err := s.Scan(context.Background(), absPath, opts, nil)
+		if err != nil {
+			if !errors.Is(err, scanner.ErrScanTimedOut) {
+				fmt.Fprintf(os.Stderr, "Scan error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		mode := model.SizeModeDisk
+		if *apparent {
+			mode = model.SizeModeApparent
+		}
+		results, total := ops.TopFiles(root, *topN, mode)
+		for _, r := range results {
+			fmt.Printf("%s %s\n", util.FormatSize(r.Size), r.Path)
+		}
+		if total > len(results) {
+			fmt.Fprintf(os.Stderr, "showing top %d of %d files\n", len(results), total)
+		}
+		return
+	}
+
+	// Headless summary mode: scan and print the N largest directories by
+	// disk usage, ranked the same bounded-heap way -top ranks files.
+	if *summaryN > 0 {
+		s := scanner.NewParallelScanner()
+		root, err := s.Scan(context.Background(), absPath, opts, nil)
+		if err != nil {
+			if !errors.Is(err, scanner.ErrScanTimedOut) {
+				fmt.Fprintf(os.Stderr, "Scan error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		mode := model.SizeModeDisk
+		if *apparent {
+			mode = model.SizeModeApparent
+		}
+		results, total := ops.TopDirectories(root, *summaryN, mode)
+		for _, r := range results {
+			fmt.Printf("%s %s\n", util.FormatSize(r.Size), r.Path)
+		}
+		if total > len(results) {
+			fmt.Fprintf(os.Stderr, "showing top %d of %d directories\n", len(results), total)
+		}
+		return
+	}
+
+	// Headless report mode
+	if *report != "" {
+		switch *report {
+		case "perms":
+			if err := runPermsReport(absPath, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Report error: %v\n", err)
+				os.Exit(1)
+			}
+		case "extensions":
+			if *reportFormat != "table" && *reportFormat != "json" {
+				fmt.Fprintf(os.Stderr, "Error: unknown -report-format %q (supported: table, json)\n", *reportFormat)
+				os.Exit(1)
+			}
+			if err := runExtensionsReport(absPath, opts, *apparent, *reportFormat); err != nil {
+				fmt.Fprintf(os.Stderr, "Report error: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unknown report %q (supported: perms, extensions)\n", *report)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Headless export mode
 	if *exportPath != "" {
+		if *jsonErrors != "" {
+			opts.ErrorCollector = scanner.NewErrorCollector()
+		}
+
+		if *streamExport {
+			if *exportPath == "-" {
+				fmt.Fprintf(os.Stderr, "Error: --stream-export requires a file path, not stdout\n")
+				os.Exit(1)
+			}
+			fmt.Printf("Scanning and exporting %s...\n", absPath)
+			s := scanner.NewParallelScanner()
+			_, err := ops.ScanAndExportStreaming(context.Background(), s, absPath, opts, nil, *exportPath, version)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Export error: %v\n", err)
+				os.Exit(1)
+			}
+			if *jsonErrors != "" {
+				if err := writeJSONErrors(*jsonErrors, opts.ErrorCollector.Errors()); err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing -json-errors: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			fmt.Printf("Exported to %s\n", *exportPath)
+			return
+		}
+
 		if *exportPath != "-" {
 			fmt.Printf("Scanning %s...\n", absPath)
 		}
 		s := scanner.NewParallelScanner()
-		root, err := s.Scan(context.Background(), absPath, opts, nil)
+		var progressCh chan scanner.Progress
+		var progressWg sync.WaitGroup
+		if *showProgress && *exportPath != "-" {
+			progressCh = make(chan scanner.Progress, 10)
+			progressWg.Add(1)
+			go func() {
+				defer progressWg.Done()
+				for p := range progressCh {
+					fmt.Fprintf(os.Stderr, "\rScanning %s: %d files, %d dirs, %d errors, %.0fs elapsed, %.0f items/sec...",
+						absPath, p.FilesScanned, p.DirsScanned, p.Errors, p.Duration.Seconds(), p.ItemsPerSecond())
+				}
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+		root, err := s.Scan(context.Background(), absPath, opts, progressCh)
+		if progressCh != nil {
+			close(progressCh)
+			progressWg.Wait()
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Scan error: %v\n", err)
-			os.Exit(1)
+			if !errors.Is(err, scanner.ErrScanTimedOut) {
+				fmt.Fprintf(os.Stderr, "Scan error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v, exporting partial results\n", err)
 		}
-		if err := ops.ExportJSON(root, *exportPath, version); err != nil {
+		if minDirSizeBytes > 0 {
+			ops.PruneBelow(root, minDirSizeBytes)
+		}
+		if *jsonErrors != "" {
+			if err := writeJSONErrors(*jsonErrors, opts.ErrorCollector.Errors()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing -json-errors: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if *exportFormat == "csv" {
+			err = ops.ExportCSV(root, *exportPath)
+		} else if *exportFormat == "html" {
+			err = ops.ExportHTML(root, *exportPath)
+		} else if *lowMemoryExport {
+			err = ops.ExportJSONLowMemory(root, *exportPath, version)
+		} else {
+			err = ops.ExportJSON(root, *exportPath, version, *exportPretty)
+		}
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Export error: %v\n", err)
 			os.Exit(1)
 		}
@@ -207,8 +735,15 @@ func main() {
 	app := ui.NewApp(absPath, opts)
 	app.ExportPath = "godu-export.json"
 	app.Version = version
+	app.DangerThreshold = *dangerThreshold
+	app.MinDirSize = minDirSizeBytes
+	app.UseTrash = *useTrash
+	app.ReadOnly = *readOnly
+	app.SetCapabilities(caps)
+	app.SetTheme(theme)
+	app.SetKeyMap(keys)
 
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -217,19 +752,29 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if summary := app.DeletionSummary(); summary != "" {
+		fmt.Println(summary)
+	}
 }
 
-func runRemoteScan(target scanTarget, sshPort int, sshBatch bool, sshTimeout int, sshScanTimeout int, exportPath string, opts scanner.ScanOptions) error {
+func runRemoteScan(target scanTarget, sshPort int, sshBatch bool, sshTimeout int, sshScanTimeout int, sshKeepAlive int, sshJump string, sshConcurrency int, sshRetries int, exportPath string, exportPretty bool, dangerThreshold float64, minDirSize int64, caps style.Capabilities, theme style.Theme, keys ui.KeyMap, opts scanner.ScanOptions) error {
 	cfg := remote.Config{
-		Target:    target.SSHDestination,
-		Port:      sshPort,
-		BatchMode: sshBatch,
-		Timeout:   time.Duration(sshTimeout) * time.Second,
+		Target:      target.SSHDestination,
+		Port:        sshPort,
+		BatchMode:   sshBatch,
+		Timeout:     time.Duration(sshTimeout) * time.Second,
+		Jump:        sshJump,
+		Concurrency: sshConcurrency,
+		Retries:     sshRetries,
 	}
 	if sshScanTimeout > 0 {
 		cfg.ScanTimeout = time.Duration(sshScanTimeout) * time.Second
 	}
+	if sshKeepAlive > 0 {
+		cfg.KeepAlive = time.Duration(sshKeepAlive) * time.Second
+	}
 	s := remote.NewSFTPScanner(cfg)
+	displayTarget := remote.DisplayTarget(cfg)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
@@ -241,8 +786,8 @@ func runRemoteScan(target scanTarget, sshPort int, sshBatch bool, sshTimeout int
 	go func() {
 		defer progressWg.Done()
 		for p := range progressCh {
-			fmt.Fprintf(os.Stderr, "\rScanning %s: %d files, %d dirs, %d errors...",
-				target.SSHDestination, p.FilesScanned, p.DirsScanned, p.Errors)
+			fmt.Fprintf(os.Stderr, "\rScanning %s: %d files, %d dirs, %d errors, %.0fs elapsed, %.0f items/sec...",
+				displayTarget, p.FilesScanned, p.DirsScanned, p.Errors, p.Duration.Seconds(), p.ItemsPerSecond())
 		}
 		fmt.Fprintln(os.Stderr)
 	}()
@@ -251,11 +796,15 @@ func runRemoteScan(target scanTarget, sshPort int, sshBatch bool, sshTimeout int
 	close(progressCh)
 	progressWg.Wait()
 	if err != nil {
-		return err
+		return fmt.Errorf("scanning %s: %w", displayTarget, err)
+	}
+
+	if minDirSize > 0 {
+		ops.PruneBelow(root, minDirSize)
 	}
 
 	if exportPath != "" {
-		if err := ops.ExportJSON(root, exportPath, version); err != nil {
+		if err := ops.ExportJSON(root, exportPath, version, exportPretty); err != nil {
 			return fmt.Errorf("export error: %w", err)
 		}
 		if exportPath != "-" {
@@ -264,6 +813,11 @@ func runRemoteScan(target scanTarget, sshPort int, sshBatch bool, sshTimeout int
 		return nil
 	}
 
+	// Label the root with its user@host:remotepath origin before it goes
+	// through the export/import round trip, so the header still shows which
+	// machine's data is being viewed instead of just the bare resolved path.
+	root.Name = displayTarget + ":" + root.Name
+
 	tempFile, err := os.CreateTemp("", "godu-remote-*.json")
 	if err != nil {
 		return fmt.Errorf("cannot create temporary file for remote scan: %w", err)
@@ -272,30 +826,71 @@ func runRemoteScan(target scanTarget, sshPort int, sshBatch bool, sshTimeout int
 	if err := tempFile.Close(); err != nil {
 		return err
 	}
-	defer os.Remove(tempPath)
+	ops.TrackTempFile(tempPath)
+	defer func() {
+		ops.UntrackTempFile(tempPath)
+		os.Remove(tempPath)
+	}()
 
-	exportErr := ops.ExportJSON(root, tempPath, version)
+	exportErr := ops.ExportJSON(root, tempPath, version, false)
 	if exportErr != nil {
 		return fmt.Errorf("export error: %w", exportErr)
 	}
 
 	app := ui.NewAppFromImport(tempPath)
 	app.Version = version
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	app.DangerThreshold = dangerThreshold
+	app.SetCapabilities(caps)
+	app.SetTheme(theme)
+	app.SetKeyMap(keys)
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		return err
 	}
 	if err := app.FatalError(); err != nil {
 		return err
 	}
+	if summary := app.DeletionSummary(); summary != "" {
+		fmt.Println(summary)
+	}
 	return nil
 }
 
+// applyPersistentSettings copies defaults from a loaded config file into the
+// flag variables they correspond to, skipping any flag the user explicitly
+// passed on the command line (tracked in explicitFlags) so a config file can
+// only ever change godu's built-in defaults, never override what was typed.
+// settings may be nil (no config file found), in which case this is a no-op.
+func applyPersistentSettings(settings *config.Settings, explicitFlags map[string]bool, showHidden, followSymlinks *bool, concurrency *int, exclude, theme *string) {
+	if settings == nil {
+		return
+	}
+	if settings.Hidden != nil && !explicitFlags["hidden"] && !explicitFlags["no-hidden"] {
+		*showHidden = *settings.Hidden
+	}
+	if settings.FollowSymlinks != nil && !explicitFlags["follow-symlinks"] {
+		*followSymlinks = *settings.FollowSymlinks
+	}
+	if settings.Concurrency != nil && !explicitFlags["j"] {
+		*concurrency = *settings.Concurrency
+	}
+	if len(settings.Exclude) > 0 && !explicitFlags["exclude"] {
+		*exclude = strings.Join(settings.Exclude, ",")
+	}
+	if settings.Theme != nil && !explicitFlags["theme"] {
+		*theme = *settings.Theme
+	}
+}
+
 func resolveScanTarget(args []string) (scanTarget, error) {
 	if len(args) == 0 {
 		return scanTarget{LocalPath: "."}, nil
 	}
 
+	if len(args) > 1 && allPathsExist(args) {
+		return scanTarget{LocalPaths: args}, nil
+	}
+
 	first := args[0]
 	if pathExists(first) {
 		if len(args) > 1 {
@@ -324,6 +919,25 @@ func resolveScanTarget(args []string) (scanTarget, error) {
 		}, nil
 	}
 
+	if !strings.ContainsAny(first, `/\`) {
+		if _, ok, _ := remote.LookupSSHConfig(first); ok {
+			if len(args) > 2 {
+				return scanTarget{}, fmt.Errorf("too many positional arguments for remote scan")
+			}
+
+			remotePath := "."
+			if len(args) == 2 && strings.TrimSpace(args[1]) != "" {
+				remotePath = args[1]
+			}
+
+			return scanTarget{
+				Remote:         true,
+				SSHDestination: first,
+				RemotePath:     remotePath,
+			}, nil
+		}
+	}
+
 	if len(args) > 1 {
 		return scanTarget{}, fmt.Errorf("too many positional arguments")
 	}
@@ -402,6 +1016,55 @@ func pathExists(path string) bool {
 	return err == nil
 }
 
+// allPathsExist reports whether every path in paths exists locally, used to
+// detect a multi-root local scan (e.g. "godu /var /home /opt") before
+// falling back to the single-target/remote resolution below.
+func allPathsExist(paths []string) bool {
+	for _, p := range paths {
+		if !pathExists(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// importAndMerge imports each path and, for more than one, combines them
+// under a synthetic root via ops.MergeTrees.
+func importAndMerge(paths []string) (*model.DirNode, error) {
+	if len(paths) == 1 {
+		return ops.ImportJSON(paths[0])
+	}
+
+	roots := make([]*model.DirNode, 0, len(paths))
+	for _, path := range paths {
+		root, err := ops.ImportJSON(path)
+		if err != nil {
+			return nil, fmt.Errorf("importing %s: %w", path, err)
+		}
+		roots = append(roots, root)
+	}
+	return ops.MergeTrees(roots...), nil
+}
+
+// writeJSONErrors writes scanErrors as a JSON array to path, or to stderr
+// when path is "-", for -json-errors.
+func writeJSONErrors(path string, scanErrors []scanner.ScanError) error {
+	if scanErrors == nil {
+		scanErrors = []scanner.ScanError{}
+	}
+	data, err := json.MarshalIndent(scanErrors, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if path == "-" {
+		_, err := os.Stderr.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
 func splitComma(s string) []string {
 	var result []string
 	for _, part := range strings.Split(s, ",") {
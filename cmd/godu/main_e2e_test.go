@@ -2,19 +2,23 @@ package main
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/sadopc/godu/internal/model"
 	"github.com/sadopc/godu/internal/ops"
+	"github.com/sadopc/godu/internal/scanner"
 )
 
 const helperEnvKey = "GO_WANT_GODU_HELPER_PROCESS"
@@ -115,6 +119,96 @@ func TestE2E_HeadlessExportImportRoundTrip(t *testing.T) {
 	}
 }
 
+func TestE2E_HeadlessExportFormatCSV(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.csv")
+
+	result := runCLI(t, "--export", exportPath, "--export-format", "csv", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+	if !strings.Contains(result.stdout, "Exported to "+exportPath) {
+		t.Fatalf("expected export confirmation in stdout, got:\n%s", result.stdout)
+	}
+
+	f, err := os.Open(exportPath)
+	if err != nil {
+		t.Fatalf("opening CSV export: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing export as CSV: %v", err)
+	}
+	if len(rows) < 2 {
+		t.Fatalf("expected header plus at least one row, got %d rows", len(rows))
+	}
+	if rows[0][0] != "path" {
+		t.Fatalf("unexpected CSV header: %v", rows[0])
+	}
+
+	var sawNested bool
+	for _, row := range rows[1:] {
+		if strings.HasSuffix(row[0], filepath.Join("keep", "sub", "b.go")) {
+			sawNested = true
+		}
+	}
+	if !sawNested {
+		t.Fatalf("expected keep/sub/b.go row in CSV export, rows: %v", rows)
+	}
+}
+
+func TestE2E_ExportFormatCSVRejectsPretty(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.csv")
+
+	result := runCLI(t, "--export", exportPath, "--export-format", "csv", "--export-pretty", scanRoot)
+	if result.exitCode == 0 {
+		t.Fatalf("expected non-zero exit code, got 0\nstdout:\n%s", result.stdout)
+	}
+	if !strings.Contains(result.stderr, "--export-format csv does not support --export-pretty") {
+		t.Fatalf("expected incompatibility error in stderr, got:\n%s", result.stderr)
+	}
+}
+
+func TestE2E_ImportExportAnonymizeStripsRealNames(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.json")
+
+	result := runCLI(t, "--export", exportPath, scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	imported, err := ops.ImportJSON(exportPath)
+	if err != nil {
+		t.Fatalf("importing exported JSON failed: %v", err)
+	}
+	wantSize := imported.GetSize()
+
+	anonPath := filepath.Join(t.TempDir(), "anon.json")
+	result = runCLI(t, "--import", exportPath, "--anonymize", "--export", anonPath)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	anonymized, err := ops.ImportJSON(anonPath)
+	if err != nil {
+		t.Fatalf("importing anonymized export failed: %v", err)
+	}
+
+	if anonymized.GetSize() != wantSize {
+		t.Fatalf("expected total size to stay %d after anonymizing, got %d", wantSize, anonymized.GetSize())
+	}
+	if findNode(anonymized, "keep") != nil {
+		t.Fatal("expected real directory name 'keep' to be stripped from anonymized export")
+	}
+	if findNode(anonymized, ".hidden.txt") != nil {
+		t.Fatal("expected real file name '.hidden.txt' to be stripped from anonymized export")
+	}
+}
+
 func TestE2E_HeadlessExportHonorsExcludePatterns(t *testing.T) {
 	scanRoot := createScanFixture(t)
 	exportPath := filepath.Join(t.TempDir(), "scan.json")
@@ -140,6 +234,148 @@ func TestE2E_HeadlessExportHonorsExcludePatterns(t *testing.T) {
 	}
 }
 
+func TestE2E_StreamExportMatchesRegularExport(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	regularPath := filepath.Join(t.TempDir(), "regular.json")
+	streamedPath := filepath.Join(t.TempDir(), "streamed.json")
+
+	if result := runCLI(t, "--export", regularPath, scanRoot); result.exitCode != 0 {
+		t.Fatalf("regular export failed: exit %d\nstderr:\n%s", result.exitCode, result.stderr)
+	}
+	if result := runCLI(t, "--export", streamedPath, "--stream-export", scanRoot); result.exitCode != 0 {
+		t.Fatalf("streamed export failed: exit %d\nstderr:\n%s", result.exitCode, result.stderr)
+	}
+
+	regular, err := ops.ImportJSON(regularPath)
+	if err != nil {
+		t.Fatalf("importing regular export failed: %v", err)
+	}
+	streamed, err := ops.ImportJSON(streamedPath)
+	if err != nil {
+		t.Fatalf("importing streamed export failed: %v", err)
+	}
+
+	if regular.GetSize() != streamed.GetSize() {
+		t.Fatalf("streamed export size %d != regular export size %d", streamed.GetSize(), regular.GetSize())
+	}
+	if findNode(streamed, "keep") == nil {
+		t.Fatal("expected streamed export to contain the keep directory")
+	}
+}
+
+func TestE2E_StreamExportRejectsPrettyAndMinDirSize(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.json")
+
+	if result := runCLI(t, "--export", exportPath, "--stream-export", "--export-pretty", scanRoot); result.exitCode == 0 {
+		t.Fatal("expected --stream-export combined with --export-pretty to fail")
+	}
+	if result := runCLI(t, "--export", exportPath, "--stream-export", "--min-dir-size", "1G", scanRoot); result.exitCode == 0 {
+		t.Fatal("expected --stream-export combined with --min-dir-size to fail")
+	}
+}
+
+func TestE2E_DebugFlagWritesScanLog(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.json")
+	debugPath := filepath.Join(t.TempDir(), "debug.log")
+
+	if result := runCLI(t, "--export", exportPath, "--exclude", "keep", "--debug", debugPath, scanRoot); result.exitCode != 0 {
+		t.Fatalf("scan failed: exit %d\nstderr:\n%s", result.exitCode, result.stderr)
+	}
+
+	data, err := os.ReadFile(debugPath)
+	if err != nil {
+		t.Fatalf("expected -debug log file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "excluded entry") {
+		t.Fatalf("expected debug log to record the exclusion, got:\n%s", data)
+	}
+}
+
+func TestE2E_ImportMergesMultipleExportsUnderSyntheticRoot(t *testing.T) {
+	scanRootA := createScanFixture(t)
+	scanRootB := createScanFixture(t)
+	exportA := filepath.Join(t.TempDir(), "host-a.json")
+	exportB := filepath.Join(t.TempDir(), "host-b.json")
+
+	if result := runCLI(t, "--export", exportA, scanRootA); result.exitCode != 0 {
+		t.Fatalf("export A failed: exit %d\nstderr:\n%s", result.exitCode, result.stderr)
+	}
+	if result := runCLI(t, "--export", exportB, scanRootB); result.exitCode != 0 {
+		t.Fatalf("export B failed: exit %d\nstderr:\n%s", result.exitCode, result.stderr)
+	}
+
+	mergedPath := filepath.Join(t.TempDir(), "merged.json")
+	result := runCLI(t, "--import", exportA+","+exportB, "--export", mergedPath)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	merged, err := ops.ImportJSON(mergedPath)
+	if err != nil {
+		t.Fatalf("importing merged export failed: %v", err)
+	}
+
+	children := merged.GetChildren()
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children under the synthetic root, got %d", len(children))
+	}
+	if findNode(merged, filepath.Base(scanRootA), "keep") == nil {
+		t.Fatal("expected host A's tree to be nested under its own labeled child")
+	}
+	if findNode(merged, filepath.Base(scanRootB), "keep") == nil {
+		t.Fatal("expected host B's tree to be nested under its own labeled child")
+	}
+}
+
+func TestE2E_JSONErrors_RecordsUnreadableDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("chmod 0o000 not effective on Windows")
+	}
+	if os.Getuid() == 0 {
+		t.Skip("running as root — permission checks are bypassed")
+	}
+
+	scanRoot := createScanFixture(t)
+	denied := filepath.Join(scanRoot, "noperm")
+	if err := os.Mkdir(denied, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(denied, 0o000); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chmod(denied, 0o755); err != nil {
+			t.Logf("cleanup chmod failed for %s: %v", denied, err)
+		}
+	})
+
+	exportPath := filepath.Join(t.TempDir(), "out.json")
+	errorsPath := filepath.Join(t.TempDir(), "errors.json")
+
+	result := runCLI(t, "--export", exportPath, "--json-errors", errorsPath, scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	data, err := os.ReadFile(errorsPath)
+	if err != nil {
+		t.Fatalf("reading errors file: %v", err)
+	}
+
+	var scanErrors []scanner.ScanError
+	if err := json.Unmarshal(data, &scanErrors); err != nil {
+		t.Fatalf("parsing errors JSON: %v", err)
+	}
+	if len(scanErrors) != 1 {
+		t.Fatalf("expected 1 scan error, got %d: %+v", len(scanErrors), scanErrors)
+	}
+	if scanErrors[0].Path != denied {
+		t.Fatalf("expected error path %q, got %q", denied, scanErrors[0].Path)
+	}
+}
+
 func TestE2E_ImportExportFailsWhenImportFileMissing(t *testing.T) {
 	missingImport := filepath.Join(t.TempDir(), "missing.json")
 	exportPath := filepath.Join(t.TempDir(), "out.json")
@@ -156,6 +392,165 @@ func TestE2E_ImportExportFailsWhenImportFileMissing(t *testing.T) {
 	}
 }
 
+func TestE2E_PermsReportFlagsWorldWritableDir(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	worldWritable := filepath.Join(scanRoot, "keep", "sub")
+	if err := os.Chmod(worldWritable, 0o777); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+
+	result := runCLI(t, "--report", "perms", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+	if !strings.Contains(result.stdout, "world-writable dir") || !strings.Contains(result.stdout, worldWritable) {
+		t.Fatalf("expected world-writable dir finding for %s, got:\n%s", worldWritable, result.stdout)
+	}
+}
+
+func TestE2E_ExtensionsReport_TableAndJSON(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--report", "extensions", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+	if !strings.Contains(result.stdout, "Extension") {
+		t.Fatalf("expected table header, got:\n%s", result.stdout)
+	}
+
+	result = runCLI(t, "--report", "extensions", "--report-format", "json", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+	var stats []struct {
+		Ext   string `json:"ext"`
+		Count int64  `json:"count"`
+		Size  int64  `json:"size"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &stats); err != nil {
+		t.Fatalf("parsing JSON report: %v\noutput:\n%s", err, result.stdout)
+	}
+	if len(stats) == 0 {
+		t.Fatal("expected at least one extension entry")
+	}
+}
+
+func TestE2E_OnelineOutput(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--oneline", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	line := strings.TrimSpace(result.stdout)
+	if !strings.HasSuffix(line, " "+scanRoot) {
+		t.Fatalf("expected '<size> %s', got:\n%q", scanRoot, line)
+	}
+}
+
+func TestE2E_RootOnlyOutput(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "-s", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	line := strings.TrimSpace(result.stdout)
+	if strings.Contains(line, scanRoot) {
+		t.Fatalf("expected only the total size with no path, got:\n%q", line)
+	}
+	if strings.Contains(line, "\n") {
+		t.Fatalf("expected a single line, got:\n%q", line)
+	}
+}
+
+func TestE2E_RootOnlySIUnits(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--root-only", "--si", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	line := strings.TrimSpace(result.stdout)
+	if strings.Contains(line, "iB") {
+		t.Fatalf("expected SI units (no 'iB' suffix), got:\n%q", line)
+	}
+}
+
+func TestE2E_TopFilesOutput(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--top", "2", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(result.stdout), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines for -top 2, got %d:\n%s", len(lines), result.stdout)
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, scanRoot) {
+			t.Fatalf("expected each line to contain a path under %s, got %q", scanRoot, line)
+		}
+	}
+}
+
+func TestE2E_TopFilesShowsCapIndicator(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--top", "1", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+	if !strings.Contains(result.stderr, "showing top 1 of") {
+		t.Fatalf("expected a 'showing top N of M' indicator on stderr, got:\n%s", result.stderr)
+	}
+}
+
+func TestE2E_SummaryOutput(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--summary", "3", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(result.stdout), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines for -summary 3, got %d:\n%s", len(lines), result.stdout)
+	}
+
+	// "keep" holds more data than either "skip-one" or "skip-two", so it
+	// should rank among the top directories alongside the scan root itself.
+	if !strings.Contains(result.stdout, filepath.Join(scanRoot, "keep")) {
+		t.Fatalf("expected output to contain the largest subdirectory, got:\n%s", result.stdout)
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			t.Fatalf("expected each line to be '<size> <path>', got %q", line)
+		}
+	}
+}
+
+func TestE2E_SummaryRespectsNoHiddenAndExclude(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--summary", "10", "--no-hidden", "--exclude", "skip-one,skip-two", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	if strings.Contains(result.stdout, "skip-one") || strings.Contains(result.stdout, "skip-two") {
+		t.Fatalf("expected excluded directories to be absent, got:\n%s", result.stdout)
+	}
+}
+
 func TestE2E_HeadlessExportToStdoutWritesJSONOnly(t *testing.T) {
 	scanRoot := createScanFixture(t)
 
@@ -182,6 +577,157 @@ func TestE2E_HeadlessExportToStdoutWritesJSONOnly(t *testing.T) {
 	}
 }
 
+func TestE2E_ProgressFlagEmitsProgressLineAndValidJSON(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.json")
+
+	result := runCLI(t, "--export", exportPath, "--progress", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+	if !strings.Contains(result.stderr, "Scanning "+scanRoot) {
+		t.Fatalf("expected a progress line on stderr, got:\n%s", result.stderr)
+	}
+
+	imported, err := ops.ImportJSON(exportPath)
+	if err != nil {
+		t.Fatalf("importing exported JSON failed: %v", err)
+	}
+	if findNode(imported, "keep") == nil {
+		t.Fatal("expected keep directory to be present in exported JSON")
+	}
+}
+
+func TestE2E_ProgressFlagSuppressedWhenExportingToStdout(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	result := runCLI(t, "--export", "-", "--progress", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+	if strings.TrimSpace(result.stderr) != "" {
+		t.Fatalf("expected empty stderr when exporting to stdout, got:\n%s", result.stderr)
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(strings.TrimSpace(result.stdout)), &raw); err != nil {
+		t.Fatalf("expected valid JSON in stdout, got error: %v\nstdout:\n%s", err, result.stdout)
+	}
+}
+
+func TestE2E_ImportFromStdinPipesExport(t *testing.T) {
+	scanRoot := createScanFixture(t)
+
+	exportResult := runCLI(t, "--export", "-", scanRoot)
+	if exportResult.exitCode != 0 {
+		t.Fatalf("expected export exit code 0, got %d\nstderr:\n%s", exportResult.exitCode, exportResult.stderr)
+	}
+
+	reExportPath := filepath.Join(t.TempDir(), "piped.json")
+	importResult := runCLIWithStdin(t, strings.NewReader(exportResult.stdout), "--import", "-", "--export", reExportPath)
+	if importResult.exitCode != 0 {
+		t.Fatalf("expected import exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", importResult.exitCode, importResult.stdout, importResult.stderr)
+	}
+	if !strings.Contains(importResult.stdout, "Exported to "+reExportPath) {
+		t.Fatalf("expected export confirmation in stdout, got:\n%s", importResult.stdout)
+	}
+
+	imported, err := ops.ImportJSON(reExportPath)
+	if err != nil {
+		t.Fatalf("importing re-exported JSON failed: %v", err)
+	}
+	if findNode(imported, "keep", "sub", "b.go") == nil {
+		t.Fatal("expected keep/sub/b.go to survive the stdin import round trip")
+	}
+}
+
+func TestE2E_MinDirSizePrunesSmallDirectories(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.json")
+
+	result := runCLI(t, "--export", exportPath, "--min-dir-size", "1M", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	imported, err := ops.ImportJSON(exportPath)
+	if err != nil {
+		t.Fatalf("importing exported JSON failed: %v", err)
+	}
+	for _, child := range imported.GetChildren() {
+		if child.IsDir() {
+			t.Fatalf("expected every directory under the fixture root to be pruned below 1M, found %q", child.GetName())
+		}
+	}
+
+	result = runCLI(t, "--export", "-", "--min-dir-size", "notasize", scanRoot)
+	if result.exitCode == 0 {
+		t.Fatalf("expected non-zero exit code for invalid -min-dir-size, stdout:\n%s", result.stdout)
+	}
+	if !strings.Contains(result.stderr, "invalid -min-dir-size") {
+		t.Fatalf("expected invalid -min-dir-size error, got stderr:\n%s", result.stderr)
+	}
+}
+
+func TestE2E_MinSizeExcludesSmallFiles(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.json")
+
+	result := runCLI(t, "--export", exportPath, "--min-size", "2K", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	imported, err := ops.ImportJSON(exportPath)
+	if err != nil {
+		t.Fatalf("importing exported JSON failed: %v", err)
+	}
+	if node := findNode(imported, "keep", "a.txt"); node != nil {
+		t.Fatalf("expected a.txt (a few bytes) to be excluded by --min-size 2K, found %v", node)
+	}
+
+	result = runCLI(t, "--export", "-", "--min-size", "notasize", scanRoot)
+	if result.exitCode == 0 {
+		t.Fatalf("expected non-zero exit code for invalid -min-size, stdout:\n%s", result.stdout)
+	}
+	if !strings.Contains(result.stderr, "invalid -min-size") {
+		t.Fatalf("expected invalid -min-size error, got stderr:\n%s", result.stderr)
+	}
+}
+
+func TestE2E_MaxDepthTruncatesDeeperDirectories(t *testing.T) {
+	scanRoot := createScanFixture(t)
+	exportPath := filepath.Join(t.TempDir(), "scan.json")
+
+	result := runCLI(t, "--export", exportPath, "--max-depth", "1", scanRoot)
+	if result.exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d\nstdout:\n%s\nstderr:\n%s", result.exitCode, result.stdout, result.stderr)
+	}
+
+	imported, err := ops.ImportJSON(exportPath)
+	if err != nil {
+		t.Fatalf("importing exported JSON failed: %v", err)
+	}
+
+	keep := findNode(imported, "keep")
+	keepDir, ok := keep.(*model.DirNode)
+	if !ok {
+		t.Fatalf("expected keep to be a directory, got %v", keep)
+	}
+	if len(keepDir.GetChildren()) == 0 {
+		t.Fatal("expected keep (depth 1) to have its own children populated")
+	}
+
+	sub := findNode(imported, "keep", "sub")
+	subDir, ok := sub.(*model.DirNode)
+	if !ok {
+		t.Fatalf("expected keep/sub to be a directory, got %v", sub)
+	}
+	if len(subDir.GetChildren()) != 0 {
+		t.Fatalf("expected keep/sub (depth 2) to be left unscanned, got %v", subDir.GetChildren())
+	}
+}
+
 func TestE2E_ImportRejectsScanTargets(t *testing.T) {
 	importPath := filepath.Join(t.TempDir(), "scan.json")
 
@@ -196,10 +742,16 @@ func TestE2E_ImportRejectsScanTargets(t *testing.T) {
 
 func runCLI(t *testing.T, args ...string) cliResult {
 	t.Helper()
+	return runCLIWithStdin(t, nil, args...)
+}
+
+func runCLIWithStdin(t *testing.T, stdin io.Reader, args ...string) cliResult {
+	t.Helper()
 
 	cmdArgs := append([]string{"-test.run=^TestCLIHelperProcess$", "--"}, args...)
 	cmd := exec.Command(os.Args[0], cmdArgs...)
 	cmd.Env = append(os.Environ(), helperEnvKey+"=1")
+	cmd.Stdin = stdin
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/sadopc/godu/internal/config"
 )
 
 func TestResolveScanTarget_DefaultLocal(t *testing.T) {
@@ -38,12 +40,51 @@ func TestResolveScanTarget_ExistingLocalPathWins(t *testing.T) {
 		t.Fatalf("unexpected local path: %q", target.LocalPath)
 	}
 
-	_, err = resolveScanTarget([]string{localPath, "/tmp"})
+	_, err = resolveScanTarget([]string{localPath, "/no/such/path/for/godu/tests"})
 	if err == nil {
 		t.Fatal("expected error for extra args in local mode")
 	}
 }
 
+func TestResolveScanTarget_MultipleExistingLocalPaths(t *testing.T) {
+	root := t.TempDir()
+	pathA := filepath.Join(root, "a")
+	pathB := filepath.Join(root, "b")
+	if err := os.Mkdir(pathA, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Mkdir(pathB, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	target, err := resolveScanTarget([]string{pathA, pathB})
+	if err != nil {
+		t.Fatalf("resolveScanTarget returned error: %v", err)
+	}
+	if target.Remote {
+		t.Fatal("expected local target")
+	}
+	if len(target.LocalPaths) != 2 || target.LocalPaths[0] != pathA || target.LocalPaths[1] != pathB {
+		t.Fatalf("unexpected local paths: %v", target.LocalPaths)
+	}
+}
+
+func TestResolveScanTarget_MixedLocalAndRemoteIsNotMultiLocal(t *testing.T) {
+	root := t.TempDir()
+	localPath := filepath.Join(root, "a")
+	if err := os.Mkdir(localPath, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	target, err := resolveScanTarget([]string{localPath, "alice@10.0.0.5"})
+	if target.LocalPaths != nil {
+		t.Fatalf("expected no multi-local target for a mixed local/remote argument list, got %v", target.LocalPaths)
+	}
+	if err == nil && !target.Remote {
+		t.Fatal("expected either an error or a remote target, not a silent local scan")
+	}
+}
+
 func TestResolveScanTarget_RemoteDefaultPath(t *testing.T) {
 	target, err := resolveScanTarget([]string{"alice@10.0.0.5"})
 	if err != nil {
@@ -105,3 +146,99 @@ func TestResolveScanTarget_RejectsBracketedIPv6HostPortInTarget(t *testing.T) {
 		t.Fatalf("expected ssh-port hint, got: %v", err)
 	}
 }
+
+func TestResolveScanTarget_SSHConfigAliasIsRemote(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "config"), []byte("Host myserver\n  HostName 203.0.113.5\n  User deploy\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := resolveScanTarget([]string{"myserver", "/var/log"})
+	if err != nil {
+		t.Fatalf("resolveScanTarget returned error: %v", err)
+	}
+	if !target.Remote {
+		t.Fatal("expected remote target for an ssh config alias")
+	}
+	if target.SSHDestination != "myserver" {
+		t.Fatalf("unexpected ssh destination: %q", target.SSHDestination)
+	}
+	if target.RemotePath != "/var/log" {
+		t.Fatalf("unexpected remote path: %q", target.RemotePath)
+	}
+}
+
+func TestResolveScanTarget_UnknownBareWordIsLocal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	target, err := resolveScanTarget([]string{"not-a-known-alias"})
+	if err != nil {
+		t.Fatalf("resolveScanTarget returned error: %v", err)
+	}
+	if target.Remote {
+		t.Fatal("expected local target for a name with no matching ssh config alias")
+	}
+}
+
+func boolPtr(b bool) *bool       { return &b }
+func intPtr(n int) *int          { return &n }
+func stringPtr(s string) *string { return &s }
+
+func TestApplyPersistentSettings_NilSettingsIsNoOp(t *testing.T) {
+	hidden, follow, conc, exclude, theme := boolPtr(true), boolPtr(false), intPtr(0), stringPtr(""), stringPtr("dark")
+	applyPersistentSettings(nil, map[string]bool{}, hidden, follow, conc, exclude, theme)
+	if *hidden != true || *follow != false || *conc != 0 || *exclude != "" || *theme != "dark" {
+		t.Fatal("expected no changes for nil settings")
+	}
+}
+
+func TestApplyPersistentSettings_AppliesWhenNotExplicit(t *testing.T) {
+	settings := &config.Settings{
+		Hidden:         boolPtr(false),
+		FollowSymlinks: boolPtr(true),
+		Concurrency:    intPtr(4),
+		Exclude:        []string{"node_modules", ".git"},
+		Theme:          stringPtr("light"),
+	}
+	hidden, follow, conc, exclude, theme := boolPtr(true), boolPtr(false), intPtr(0), stringPtr(""), stringPtr("dark")
+	applyPersistentSettings(settings, map[string]bool{}, hidden, follow, conc, exclude, theme)
+	if *hidden != false {
+		t.Errorf("expected hidden = false, got %v", *hidden)
+	}
+	if *follow != true {
+		t.Errorf("expected follow-symlinks = true, got %v", *follow)
+	}
+	if *conc != 4 {
+		t.Errorf("expected concurrency = 4, got %v", *conc)
+	}
+	if *exclude != "node_modules,.git" {
+		t.Errorf("expected exclude = %q, got %q", "node_modules,.git", *exclude)
+	}
+	if *theme != "light" {
+		t.Errorf("expected theme = light, got %v", *theme)
+	}
+}
+
+func TestApplyPersistentSettings_ExplicitFlagsWin(t *testing.T) {
+	settings := &config.Settings{
+		Hidden:      boolPtr(false),
+		Concurrency: intPtr(4),
+		Theme:       stringPtr("light"),
+	}
+	explicit := map[string]bool{"hidden": true, "j": true, "theme": true}
+	hidden, follow, conc, exclude, theme := boolPtr(true), boolPtr(false), intPtr(8), stringPtr(""), stringPtr("dark")
+	applyPersistentSettings(settings, explicit, hidden, follow, conc, exclude, theme)
+	if *hidden != true {
+		t.Errorf("expected explicit hidden flag to win, got %v", *hidden)
+	}
+	if *conc != 8 {
+		t.Errorf("expected explicit concurrency flag to win, got %v", *conc)
+	}
+	if *theme != "dark" {
+		t.Errorf("expected explicit theme flag to win, got %v", *theme)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/scanner"
+	"github.com/sadopc/godu/internal/util"
+)
+
+// permFinding describes a single entry flagged by the perms report.
+type permFinding struct {
+	path string
+	mode os.FileMode
+	tag  string
+}
+
+// runPermsReport scans absPath and prints world-writable directories,
+// setuid/setgid files, and other world-writable files, sorted by path.
+// This repurposes the permission bits already captured during scanning for
+// a quick security-audit pass.
+func runPermsReport(absPath string, opts scanner.ScanOptions) error {
+	s := scanner.NewParallelScanner()
+	root, err := s.Scan(context.Background(), absPath, opts, nil)
+	if err != nil && !errors.Is(err, scanner.ErrScanTimedOut) {
+		return err
+	}
+
+	var findings []permFinding
+	model.Walk(root, func(n model.TreeNode) {
+		mode := nodeMode(n)
+		switch {
+		case n.IsDir() && mode&0002 != 0:
+			findings = append(findings, permFinding{n.Path(), mode, "world-writable dir"})
+		case !n.IsDir() && mode&(os.ModeSetuid|os.ModeSetgid) != 0:
+			findings = append(findings, permFinding{n.Path(), mode, "setuid/setgid"})
+		case !n.IsDir() && mode&0002 != 0:
+			findings = append(findings, permFinding{n.Path(), mode, "world-writable file"})
+		}
+	})
+
+	sort.Slice(findings, func(i, j int) bool { return findings[i].path < findings[j].path })
+
+	for _, f := range findings {
+		fmt.Printf("%s  %-18s %s\n", f.mode, f.tag, f.path)
+	}
+	if len(findings) == 0 {
+		fmt.Println("No suspicious permissions found")
+	}
+	return nil
+}
+
+// extStat holds aggregated count and size for one file extension.
+type extStat struct {
+	Ext   string `json:"ext"`
+	Count int64  `json:"count"`
+	Size  int64  `json:"size"`
+}
+
+// runExtensionsReport scans absPath and reports total size and count per
+// file extension, sorted by size descending, as a table or as JSON.
+func runExtensionsReport(absPath string, opts scanner.ScanOptions, useApparent bool, format string) error {
+	s := scanner.NewParallelScanner()
+	root, err := s.Scan(context.Background(), absPath, opts, nil)
+	if err != nil && !errors.Is(err, scanner.ErrScanTimedOut) {
+		return err
+	}
+
+	byExt := make(map[string]*extStat)
+	model.Walk(root, func(n model.TreeNode) {
+		if n.IsDir() {
+			return
+		}
+		ext := strings.ToLower(filepath.Ext(n.GetName()))
+		if ext == "" {
+			ext = "(no extension)"
+		}
+		size := n.GetUsage()
+		if useApparent {
+			size = n.GetSize()
+		}
+		stat, ok := byExt[ext]
+		if !ok {
+			stat = &extStat{Ext: ext}
+			byExt[ext] = stat
+		}
+		stat.Count++
+		stat.Size += size
+	})
+
+	stats := make([]extStat, 0, len(byExt))
+	for _, stat := range byExt {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Size > stats[j].Size })
+
+	if format == "json" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("%-20s %12s %10s\n", "Extension", "Size", "Files")
+	for _, stat := range stats {
+		fmt.Printf("%-20s %12s %10d\n", stat.Ext, util.FormatSize(stat.Size), stat.Count)
+	}
+	return nil
+}
+
+func nodeMode(n model.TreeNode) os.FileMode {
+	switch t := n.(type) {
+	case *model.DirNode:
+		return t.Mode
+	case *model.FileNode:
+		return t.Mode
+	}
+	return 0
+}
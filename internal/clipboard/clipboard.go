@@ -0,0 +1,49 @@
+// Package clipboard copies text to the system clipboard by shelling out to
+// a platform-specific helper, since Go has no portable clipboard API.
+package clipboard
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// lookPath is overridden in tests so the Linux/BSD branch can be exercised
+// without depending on whatever clipboard utilities happen to be installed.
+var lookPath = exec.LookPath
+
+// Copy places text on the system clipboard using pbcopy on macOS, clip.exe
+// on Windows, and xclip (falling back to xsel) on Linux/BSD. It returns an
+// error if no suitable utility is available.
+func Copy(text string) error {
+	cmd, err := commandFor(runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("clipboard: %w", err)
+	}
+	return nil
+}
+
+// commandFor builds the exec.Cmd used to copy to the clipboard on goos,
+// separated from Copy so the platform-selection logic can be tested without
+// actually running anything.
+func commandFor(goos string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		return exec.Command("pbcopy"), nil
+	case "windows":
+		return exec.Command("clip.exe"), nil
+	default:
+		if path, err := lookPath("xclip"); err == nil {
+			return exec.Command(path, "-selection", "clipboard"), nil
+		}
+		if path, err := lookPath("xsel"); err == nil {
+			return exec.Command(path, "--clipboard", "--input"), nil
+		}
+		return nil, fmt.Errorf("no clipboard utility found on PATH (tried xclip, xsel)")
+	}
+}
@@ -0,0 +1,76 @@
+package clipboard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommandFor_Darwin(t *testing.T) {
+	cmd, err := commandFor("darwin")
+	if err != nil {
+		t.Fatalf("commandFor returned error: %v", err)
+	}
+	if cmd.Args[0] != "pbcopy" {
+		t.Fatalf("expected pbcopy, got %q", cmd.Args[0])
+	}
+}
+
+func TestCommandFor_Windows(t *testing.T) {
+	cmd, err := commandFor("windows")
+	if err != nil {
+		t.Fatalf("commandFor returned error: %v", err)
+	}
+	if cmd.Args[0] != "clip.exe" {
+		t.Fatalf("expected clip.exe, got %q", cmd.Args[0])
+	}
+}
+
+func TestCommandFor_LinuxPrefersXclip(t *testing.T) {
+	oldLookPath := lookPath
+	defer func() { lookPath = oldLookPath }()
+	lookPath = func(name string) (string, error) {
+		if name == "xclip" {
+			return "/usr/bin/xclip", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	cmd, err := commandFor("linux")
+	if err != nil {
+		t.Fatalf("commandFor returned error: %v", err)
+	}
+	if cmd.Path != "/usr/bin/xclip" {
+		t.Fatalf("expected xclip, got %q", cmd.Path)
+	}
+}
+
+func TestCommandFor_LinuxFallsBackToXsel(t *testing.T) {
+	oldLookPath := lookPath
+	defer func() { lookPath = oldLookPath }()
+	lookPath = func(name string) (string, error) {
+		if name == "xsel" {
+			return "/usr/bin/xsel", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	cmd, err := commandFor("linux")
+	if err != nil {
+		t.Fatalf("commandFor returned error: %v", err)
+	}
+	if cmd.Path != "/usr/bin/xsel" {
+		t.Fatalf("expected xsel, got %q", cmd.Path)
+	}
+}
+
+func TestCommandFor_LinuxErrorsWhenNothingAvailable(t *testing.T) {
+	oldLookPath := lookPath
+	defer func() { lookPath = oldLookPath }()
+	lookPath = func(name string) (string, error) {
+		return "", errors.New("not found")
+	}
+
+	if _, err := commandFor("linux"); err == nil {
+		t.Fatal("expected error when no clipboard utility is available")
+	}
+}
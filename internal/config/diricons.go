@@ -0,0 +1,58 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sadopc/godu/internal/util"
+)
+
+// DirIconOverride describes a user-configured icon/color for directories
+// matching a given name, as loaded from the directory icons config file.
+type DirIconOverride struct {
+	Icon  string `json:"icon"`
+	Color string `json:"color"`
+}
+
+// LoadDirIcons reads a JSON file mapping directory name patterns to
+// DirIconOverride entries and registers them with the internal/util icon
+// tables. A missing file is not an error: it simply means no overrides are
+// configured. This is a JSON stand-in for the TOML-based config format the
+// rest of godu's settings will eventually use; it exists so directory
+// icon/color overrides are usable now, ahead of that larger config system.
+func LoadDirIcons(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading dir icons config: %w", err)
+	}
+
+	var overrides map[string]DirIconOverride
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("parsing dir icons config: %w", err)
+	}
+
+	for name, override := range overrides {
+		if override.Icon != "" {
+			util.RegisterDirIcon(name, override.Icon)
+		}
+		if override.Color != "" {
+			util.RegisterDirColor(name, override.Color)
+		}
+	}
+	return nil
+}
+
+// DefaultDirIconsPath returns the default location of the directory icons
+// config file, ~/.config/godu/dir-icons.json.
+func DefaultDirIconsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "godu", "dir-icons.json"), nil
+}
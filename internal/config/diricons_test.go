@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sadopc/godu/internal/util"
+)
+
+func TestLoadDirIcons_MissingFileIsNotAnError(t *testing.T) {
+	dir := t.TempDir()
+	if err := LoadDirIcons(filepath.Join(dir, "does-not-exist.json")); err != nil {
+		t.Fatalf("expected no error for missing config file, got %v", err)
+	}
+}
+
+func TestLoadDirIcons_RegistersOverrides(t *testing.T) {
+	t.Cleanup(func() {
+		util.RegisterDirIcon("testfixtures", "📁")
+		util.RegisterDirColor("testfixtures", "")
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dir-icons.json")
+	overrides := map[string]DirIconOverride{
+		"testfixtures": {Icon: "🧪", Color: "#00ff00"},
+	}
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadDirIcons(path); err != nil {
+		t.Fatalf("LoadDirIcons: %v", err)
+	}
+
+	if got := util.DirIcon("testfixtures"); got != "🧪" {
+		t.Fatalf("expected registered icon, got %q", got)
+	}
+	color, ok := util.DirColor("testfixtures")
+	if !ok || color != "#00ff00" {
+		t.Fatalf("expected registered color #00ff00, got %q (ok=%v)", color, ok)
+	}
+}
+
+func TestLoadDirIcons_RejectsMalformedJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dir-icons.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadDirIcons(path); err == nil {
+		t.Fatal("expected error for malformed config")
+	}
+}
+
+func TestDefaultDirIconsPath_EndsInExpectedLocation(t *testing.T) {
+	path, err := DefaultDirIconsPath()
+	if err != nil {
+		t.Fatalf("DefaultDirIconsPath: %v", err)
+	}
+	want := filepath.Join(".config", "godu", "dir-icons.json")
+	if filepath.Base(filepath.Dir(path)) != "godu" || filepath.Base(path) != "dir-icons.json" {
+		t.Fatalf("expected path to end with %s, got %s", want, path)
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+// LoadFileCategories reads a JSON file mapping file extensions (with their
+// leading dot, e.g. ".parquet") to category names (e.g. "Data") and
+// registers them with model.ClassifyFile's override table. A missing file
+// is not an error: it simply means no overrides are configured. Unrecognized
+// category names are reported back as warnings and otherwise ignored.
+func LoadFileCategories(path string) (warnings []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading file categories config: %w", err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing file categories config: %w", err)
+	}
+
+	overrides := make(map[string]model.FileCategory, len(raw))
+	for ext, catName := range raw {
+		cat, ok := model.CategoryFromName(catName)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown file category %q for extension %q, ignoring", catName, ext))
+			continue
+		}
+		overrides[ext] = cat
+	}
+	model.RegisterExtensions(overrides)
+	return warnings, nil
+}
+
+// DefaultFileCategoriesPath returns the default location of the file
+// categories config file, ~/.config/godu/file-categories.json.
+func DefaultFileCategoriesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "godu", "file-categories.json"), nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestLoadFileCategories_MissingFileIsNotAnError(t *testing.T) {
+	dir := t.TempDir()
+	warnings, err := LoadFileCategories(filepath.Join(dir, "does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("expected no error for missing config file, got %v", err)
+	}
+	if warnings != nil {
+		t.Fatalf("expected no warnings for missing config file, got %v", warnings)
+	}
+}
+
+func TestLoadFileCategories_RegistersOverrides(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file-categories.json")
+	overrides := map[string]string{".parquet": "Data", ".orc": "Data"}
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, err := LoadFileCategories(path)
+	if err != nil {
+		t.Fatalf("LoadFileCategories: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+
+	if got := model.ClassifyFile("events.parquet"); got != model.CatData {
+		t.Errorf("expected .parquet to classify as Data, got %v", got)
+	}
+}
+
+func TestLoadFileCategories_WarnsOnUnknownCategoryName(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file-categories.json")
+	data, err := json.Marshal(map[string]string{".foo": "Nonsense"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, err := LoadFileCategories(path)
+	if err != nil {
+		t.Fatalf("LoadFileCategories: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning for unknown category, got %v", warnings)
+	}
+}
+
+func TestLoadFileCategories_RejectsMalformedJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file-categories.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadFileCategories(path); err == nil {
+		t.Fatal("expected error for malformed config")
+	}
+}
+
+func TestDefaultFileCategoriesPath_EndsInExpectedLocation(t *testing.T) {
+	path, err := DefaultFileCategoriesPath()
+	if err != nil {
+		t.Fatalf("DefaultFileCategoriesPath: %v", err)
+	}
+	if filepath.Base(filepath.Dir(path)) != "godu" || filepath.Base(path) != "file-categories.json" {
+		t.Fatalf("expected path to end with .config/godu/file-categories.json, got %s", path)
+	}
+}
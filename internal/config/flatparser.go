@@ -0,0 +1,35 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// parseFlatAssignments parses a minimal TOML-style file: one
+// "key = \"value\"" assignment per line, blank lines and "#" comments
+// ignored, optional "[section]" table headers ignored. It's the hand-rolled
+// subset of TOML shared by every godu config file that's just a flat list of
+// settings (key remapping, persistent flag defaults), ahead of the full
+// TOML-based config system the rest of godu's settings will eventually use.
+func parseFlatAssignments(data []byte) (map[string]string, error) {
+	assignments := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key = value\"", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		assignments[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LoadKeyRemap reads a minimal TOML-style key remapping file: one
+// "action = \"key\"" assignment per line, blank lines and "#" comments
+// ignored, an optional "[keys]" table header ignored. It returns the parsed
+// action-to-key overrides. A missing file is not an error: it simply means
+// no remapping is configured. Parsing is delegated to parseFlatAssignments,
+// the hand-rolled flat-TOML subset shared with LoadSettings.
+func LoadKeyRemap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading key remap config: %w", err)
+	}
+
+	overrides, err := parseFlatAssignments(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing key remap config: %w", err)
+	}
+	return overrides, nil
+}
+
+// DefaultKeyRemapPath returns the default location of the key remapping
+// config file, ~/.config/godu/keys.toml.
+func DefaultKeyRemapPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "godu", "keys.toml"), nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadKeyRemap_MissingFileIsNotAnError(t *testing.T) {
+	dir := t.TempDir()
+	overrides, err := LoadKeyRemap(filepath.Join(dir, "does-not-exist.toml"))
+	if err != nil {
+		t.Fatalf("expected no error for missing config file, got %v", err)
+	}
+	if overrides != nil {
+		t.Fatalf("expected nil overrides for missing config file, got %v", overrides)
+	}
+}
+
+func TestLoadKeyRemap_ParsesAssignments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keys.toml")
+	contents := "[keys]\n# remap delete to x\ndelete = \"x\"\nquit = 'Q'\n\nup = \"k\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	overrides, err := LoadKeyRemap(path)
+	if err != nil {
+		t.Fatalf("LoadKeyRemap: %v", err)
+	}
+	want := map[string]string{"delete": "x", "quit": "Q", "up": "k"}
+	if len(overrides) != len(want) {
+		t.Fatalf("expected %v, got %v", want, overrides)
+	}
+	for action, key := range want {
+		if overrides[action] != key {
+			t.Fatalf("expected overrides[%q] = %q, got %q", action, key, overrides[action])
+		}
+	}
+}
+
+func TestLoadKeyRemap_RejectsMalformedLine(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keys.toml")
+	if err := os.WriteFile(path, []byte("not an assignment"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadKeyRemap(path); err == nil {
+		t.Fatal("expected error for malformed config")
+	}
+}
+
+func TestDefaultKeyRemapPath_EndsInExpectedLocation(t *testing.T) {
+	path, err := DefaultKeyRemapPath()
+	if err != nil {
+		t.Fatalf("DefaultKeyRemapPath: %v", err)
+	}
+	if filepath.Base(filepath.Dir(path)) != "godu" || filepath.Base(path) != "keys.toml" {
+		t.Fatalf("expected path to end with .config/godu/keys.toml, got %s", path)
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Settings holds default values for command-line flags, loaded from the
+// persistent config file so a user doesn't have to repeat favorite flags
+// (e.g. -follow-symlinks or -theme) on every invocation. A nil field means
+// the setting wasn't present in the config file, leaving godu's normal
+// built-in default in place; an explicit flag on the command line always
+// wins over whatever the config file says.
+type Settings struct {
+	Hidden         *bool
+	FollowSymlinks *bool
+	Concurrency    *int
+	Exclude        []string
+	Theme          *string
+}
+
+// LoadSettings reads a minimal TOML-style config file of "key = value"
+// settings (see parseFlatAssignments), one of: hidden, follow-symlinks,
+// concurrency, exclude (comma-separated patterns), theme. A missing file is
+// not an error: it simply means every flag keeps its built-in default.
+func LoadSettings(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	assignments, err := parseFlatAssignments(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+
+	s := &Settings{}
+	for key, value := range assignments {
+		switch strings.ToLower(key) {
+		case "hidden":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing config file: invalid boolean for %q: %q", key, value)
+			}
+			s.Hidden = &b
+		case "follow-symlinks":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing config file: invalid boolean for %q: %q", key, value)
+			}
+			s.FollowSymlinks = &b
+		case "concurrency":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing config file: invalid integer for %q: %q", key, value)
+			}
+			s.Concurrency = &n
+		case "exclude":
+			for _, pattern := range strings.Split(value, ",") {
+				if pattern = strings.TrimSpace(pattern); pattern != "" {
+					s.Exclude = append(s.Exclude, pattern)
+				}
+			}
+		case "theme":
+			v := value
+			s.Theme = &v
+		default:
+			return nil, fmt.Errorf("parsing config file: unknown setting %q", key)
+		}
+	}
+	return s, nil
+}
+
+// DefaultSettingsPath returns the default location of the persistent
+// settings file, ~/.config/godu/config.toml.
+func DefaultSettingsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "godu", "config.toml"), nil
+}
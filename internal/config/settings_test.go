@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSettings_MissingFileIsNotAnError(t *testing.T) {
+	dir := t.TempDir()
+	settings, err := LoadSettings(filepath.Join(dir, "does-not-exist.toml"))
+	if err != nil {
+		t.Fatalf("expected no error for missing config file, got %v", err)
+	}
+	if settings != nil {
+		t.Fatalf("expected nil settings for missing config file, got %v", settings)
+	}
+}
+
+func TestLoadSettings_ParsesAllFields(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	contents := "hidden = \"false\"\nfollow-symlinks = \"true\"\nconcurrency = \"4\"\nexclude = \"node_modules, .git\"\ntheme = 'light'\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if settings.Hidden == nil || *settings.Hidden != false {
+		t.Fatalf("expected Hidden = false, got %v", settings.Hidden)
+	}
+	if settings.FollowSymlinks == nil || *settings.FollowSymlinks != true {
+		t.Fatalf("expected FollowSymlinks = true, got %v", settings.FollowSymlinks)
+	}
+	if settings.Concurrency == nil || *settings.Concurrency != 4 {
+		t.Fatalf("expected Concurrency = 4, got %v", settings.Concurrency)
+	}
+	wantExclude := []string{"node_modules", ".git"}
+	if len(settings.Exclude) != len(wantExclude) {
+		t.Fatalf("expected Exclude = %v, got %v", wantExclude, settings.Exclude)
+	}
+	for i, pattern := range wantExclude {
+		if settings.Exclude[i] != pattern {
+			t.Fatalf("expected Exclude[%d] = %q, got %q", i, pattern, settings.Exclude[i])
+		}
+	}
+	if settings.Theme == nil || *settings.Theme != "light" {
+		t.Fatalf("expected Theme = light, got %v", settings.Theme)
+	}
+}
+
+func TestLoadSettings_RejectsInvalidBoolean(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("hidden = \"maybe\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(path); err == nil {
+		t.Fatal("expected error for invalid boolean")
+	}
+}
+
+func TestLoadSettings_RejectsInvalidInteger(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("concurrency = \"many\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(path); err == nil {
+		t.Fatal("expected error for invalid integer")
+	}
+}
+
+func TestLoadSettings_RejectsUnknownSetting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("bogus = \"1\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(path); err == nil {
+		t.Fatal("expected error for unknown setting")
+	}
+}
+
+func TestDefaultSettingsPath_EndsInExpectedLocation(t *testing.T) {
+	path, err := DefaultSettingsPath()
+	if err != nil {
+		t.Fatalf("DefaultSettingsPath: %v", err)
+	}
+	if filepath.Base(filepath.Dir(path)) != "godu" || filepath.Base(path) != "config.toml" {
+		t.Fatalf("expected path ending in godu/config.toml, got %q", path)
+	}
+}
@@ -0,0 +1,38 @@
+// Package launcher opens a path in the OS file manager by shelling out to a
+// platform-specific command, since Go has no portable way to do this.
+package launcher
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Open launches the OS file manager on path using open on macOS, xdg-open
+// on Linux/BSD, and explorer on Windows.
+func Open(path string) error {
+	cmd, err := commandFor(runtime.GOOS, path)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	return nil
+}
+
+// commandFor builds the exec.Cmd used to open path on goos, separated from
+// Open so the platform-selection logic can be tested without actually
+// launching a file manager.
+func commandFor(goos, path string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		return exec.Command("open", path), nil
+	case "windows":
+		return exec.Command("explorer", path), nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.Command("xdg-open", path), nil
+	default:
+		return nil, fmt.Errorf("opening a file manager is not supported on %s", goos)
+	}
+}
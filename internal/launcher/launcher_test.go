@@ -0,0 +1,39 @@
+package launcher
+
+import "testing"
+
+func TestCommandFor_Darwin(t *testing.T) {
+	cmd, err := commandFor("darwin", "/tmp/dir")
+	if err != nil {
+		t.Fatalf("commandFor returned error: %v", err)
+	}
+	if cmd.Args[0] != "open" || cmd.Args[1] != "/tmp/dir" {
+		t.Fatalf("unexpected args: %v", cmd.Args)
+	}
+}
+
+func TestCommandFor_Windows(t *testing.T) {
+	cmd, err := commandFor("windows", `C:\Users\me`)
+	if err != nil {
+		t.Fatalf("commandFor returned error: %v", err)
+	}
+	if cmd.Args[0] != "explorer" || cmd.Args[1] != `C:\Users\me` {
+		t.Fatalf("unexpected args: %v", cmd.Args)
+	}
+}
+
+func TestCommandFor_Linux(t *testing.T) {
+	cmd, err := commandFor("linux", "/tmp/dir")
+	if err != nil {
+		t.Fatalf("commandFor returned error: %v", err)
+	}
+	if cmd.Args[0] != "xdg-open" || cmd.Args[1] != "/tmp/dir" {
+		t.Fatalf("unexpected args: %v", cmd.Args)
+	}
+}
+
+func TestCommandFor_UnsupportedGOOS(t *testing.T) {
+	if _, err := commandFor("plan9", "/tmp/dir"); err == nil {
+		t.Fatal("expected error for unsupported GOOS")
+	}
+}
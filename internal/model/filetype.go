@@ -1,6 +1,9 @@
 package model
 
-import "strings"
+import (
+	"strings"
+	"sync"
+)
 
 // FileCategory represents a high-level file type category.
 type FileCategory int
@@ -13,6 +16,7 @@ const (
 	CatDocument
 	CatSystem
 	CatExecutable
+	CatData
 )
 
 // CategoryName returns the display name for a category.
@@ -30,6 +34,8 @@ func CategoryName(cat FileCategory) string {
 		return "System"
 	case CatExecutable:
 		return "Executables"
+	case CatData:
+		return "Data"
 	default:
 		return "Other"
 	}
@@ -50,6 +56,8 @@ func CategoryColor(cat FileCategory) string {
 		return "#C678DD" // Purple
 	case CatExecutable:
 		return "#D19A66" // Orange
+	case CatData:
+		return "#56B6C2" // Cyan
 	default:
 		return "#ABB2BF" // Gray
 	}
@@ -128,9 +136,48 @@ var extMap = map[string]FileCategory{
 	".dockerignore": CatCode, ".makefile": CatCode,
 }
 
+// CategoryFromName returns the FileCategory matching name (case-insensitive,
+// matching CategoryName's output), for parsing category names out of config
+// files. ok is false for an unrecognized name.
+func CategoryFromName(name string) (cat FileCategory, ok bool) {
+	for _, c := range []FileCategory{CatOther, CatMedia, CatCode, CatArchive, CatDocument, CatSystem, CatExecutable, CatData} {
+		if strings.EqualFold(CategoryName(c), name) {
+			return c, true
+		}
+	}
+	return CatOther, false
+}
+
+// extOverrides holds user-registered extension -> category mappings, merged
+// over the built-in extMap by ClassifyFile. Populated at startup by
+// internal/config from the user's file category config.
+var (
+	extOverrideMu sync.RWMutex
+	extOverrides  = map[string]FileCategory{}
+)
+
+// RegisterExtensions merges the given extension -> category mappings over
+// the built-in classification table. Extensions should include the leading
+// dot (e.g. ".parquet") and are matched case-insensitively; a later call
+// overrides an earlier one for the same extension, and either overrides the
+// built-in default.
+func RegisterExtensions(overrides map[string]FileCategory) {
+	extOverrideMu.Lock()
+	defer extOverrideMu.Unlock()
+	for ext, cat := range overrides {
+		extOverrides[strings.ToLower(ext)] = cat
+	}
+}
+
 // ClassifyFile returns the category for a given filename.
 func ClassifyFile(name string) FileCategory {
 	ext := strings.ToLower(getExt(name))
+	extOverrideMu.RLock()
+	cat, ok := extOverrides[ext]
+	extOverrideMu.RUnlock()
+	if ok {
+		return cat
+	}
 	if cat, ok := extMap[ext]; ok {
 		return cat
 	}
@@ -0,0 +1,76 @@
+package model
+
+import "testing"
+
+func TestClassifyFile_BuiltinsRemainUnchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		want FileCategory
+	}{
+		{"photo.jpg", CatMedia},
+		{"main.go", CatCode},
+		{"archive.zip", CatArchive},
+		{"report.pdf", CatDocument},
+		{"debug.log", CatSystem},
+		{"a.out", CatExecutable},
+		{"unknown.xyz", CatOther},
+	}
+	for _, tt := range tests {
+		if got := ClassifyFile(tt.name); got != tt.want {
+			t.Errorf("ClassifyFile(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterExtensions_ClassifiesRegisteredExtension(t *testing.T) {
+	t.Cleanup(func() {
+		extOverrideMu.Lock()
+		delete(extOverrides, ".parquet")
+		delete(extOverrides, ".orc")
+		extOverrideMu.Unlock()
+	})
+
+	if got := ClassifyFile("events.parquet"); got != CatOther {
+		t.Fatalf("expected .parquet to be unclassified before registration, got %v", got)
+	}
+
+	RegisterExtensions(map[string]FileCategory{".parquet": CatData, ".orc": CatData})
+
+	if got := ClassifyFile("events.parquet"); got != CatData {
+		t.Errorf("ClassifyFile(%q) = %v, want %v", "events.parquet", got, CatData)
+	}
+	if got := ClassifyFile("events.ORC"); got != CatData {
+		t.Errorf("ClassifyFile(%q) = %v, want %v (case-insensitive)", "events.ORC", got, CatData)
+	}
+
+	if got := ClassifyFile("photo.jpg"); got != CatMedia {
+		t.Errorf("expected built-in .jpg classification to remain unchanged, got %v", got)
+	}
+}
+
+func TestRegisterExtensions_OverridesBuiltin(t *testing.T) {
+	t.Cleanup(func() {
+		extOverrideMu.Lock()
+		delete(extOverrides, ".log")
+		extOverrideMu.Unlock()
+	})
+
+	RegisterExtensions(map[string]FileCategory{".log": CatData})
+
+	if got := ClassifyFile("server.log"); got != CatData {
+		t.Errorf("expected registered override to take priority over built-in, got %v", got)
+	}
+}
+
+func TestCategoryFromName_RoundTripsCategoryName(t *testing.T) {
+	for _, cat := range []FileCategory{CatOther, CatMedia, CatCode, CatArchive, CatDocument, CatSystem, CatExecutable, CatData} {
+		got, ok := CategoryFromName(CategoryName(cat))
+		if !ok || got != cat {
+			t.Errorf("CategoryFromName(%q) = %v, %v; want %v, true", CategoryName(cat), got, ok, cat)
+		}
+	}
+
+	if _, ok := CategoryFromName("Nonsense"); ok {
+		t.Error("expected unrecognized category name to report ok=false")
+	}
+}
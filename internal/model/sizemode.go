@@ -0,0 +1,52 @@
+package model
+
+// SizeMode selects which size metric is used for sorting, weighting, and
+// display throughout the UI.
+type SizeMode int
+
+const (
+	// SizeModeDisk uses on-disk usage (blocks * block size).
+	SizeModeDisk SizeMode = iota
+	// SizeModeApparent uses the apparent (logical) file size.
+	SizeModeApparent
+	// SizeModeMax uses whichever of disk usage or apparent size is larger.
+	// This approximates "the space it actually takes" for both ordinary
+	// files, where disk usage rounds up to the next block, and sparse
+	// files, where apparent size can vastly exceed the blocks actually
+	// allocated.
+	SizeModeMax
+)
+
+// Next cycles to the next size mode in display order: disk -> apparent ->
+// max -> disk.
+func (m SizeMode) Next() SizeMode {
+	return (m + 1) % 3
+}
+
+// Label returns a short human-readable name for the mode, used in the
+// status bar and help text.
+func (m SizeMode) Label() string {
+	switch m {
+	case SizeModeApparent:
+		return "apparent"
+	case SizeModeMax:
+		return "max"
+	default:
+		return "disk"
+	}
+}
+
+// Size returns node's size under this mode.
+func (m SizeMode) Size(node TreeNode) int64 {
+	switch m {
+	case SizeModeApparent:
+		return node.GetSize()
+	case SizeModeMax:
+		if node.GetSize() > node.GetUsage() {
+			return node.GetSize()
+		}
+		return node.GetUsage()
+	default:
+		return node.GetUsage()
+	}
+}
@@ -11,7 +11,7 @@ import (
 type SortField int
 
 const (
-	SortBySize  SortField = iota
+	SortBySize SortField = iota
 	SortByName
 	SortByCount
 	SortByMtime
@@ -43,9 +43,10 @@ func DefaultSort() SortConfig {
 }
 
 // SortChildren sorts a slice of TreeNode in place according to config.
-func SortChildren(children []TreeNode, cfg SortConfig, useApparent bool) {
+func SortChildren(children []TreeNode, cfg SortConfig, sizeMode SizeMode) {
 	sort.SliceStable(children, func(i, j int) bool {
-		a, b := children[i], children[j]
+		origA, origB := children[i], children[j]
+		a, b := origA, origB
 
 		// Dirs first
 		if cfg.DirsFirst {
@@ -62,16 +63,12 @@ func SortChildren(children []TreeNode, cfg SortConfig, useApparent bool) {
 			a, b = b, a
 		}
 
+		var equal bool
 		var less bool
 		switch cfg.Field {
 		case SortBySize:
-			var sa, sb int64
-			if useApparent {
-				sa, sb = a.GetSize(), b.GetSize()
-			} else {
-				sa, sb = a.GetUsage(), b.GetUsage()
-			}
-			less = sa < sb
+			sa, sb := sizeMode.Size(a), sizeMode.Size(b)
+			less, equal = sa < sb, sa == sb
 		case SortByName:
 			less = natural.Less(strings.ToLower(a.GetName()), strings.ToLower(b.GetName()))
 		case SortByCount:
@@ -82,11 +79,21 @@ func SortChildren(children []TreeNode, cfg SortConfig, useApparent bool) {
 			if db, ok := b.(*DirNode); ok {
 				cb = db.ItemCount
 			}
-			less = ca < cb
+			less, equal = ca < cb, ca == cb
 		case SortByMtime:
-			less = a.GetMtime().Before(b.GetMtime())
+			ma, mb := a.GetMtime(), b.GetMtime()
+			less, equal = ma.Before(mb), ma.Equal(mb)
 		default:
-			less = a.GetSize() < b.GetSize()
+			sa, sb := a.GetSize(), b.GetSize()
+			less, equal = sa < sb, sa == sb
+		}
+
+		// Break ties by name in natural ascending order, regardless of sort
+		// direction, so output is deterministic instead of depending on
+		// directory-read order (which varies between runs/filesystems).
+		// SortByName already ties on name, so it needs no secondary key.
+		if equal && cfg.Field != SortByName {
+			return natural.Less(strings.ToLower(origA.GetName()), strings.ToLower(origB.GetName()))
 		}
 
 		return less
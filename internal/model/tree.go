@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"sync"
 	"time"
@@ -13,7 +14,7 @@ const (
 )
 
 // NodeFlag represents special file attributes.
-type NodeFlag uint8
+type NodeFlag uint16
 
 const (
 	FlagNone    NodeFlag = 0
@@ -22,8 +23,36 @@ const (
 	FlagHardlink
 	// FlagUsageEstimated marks nodes whose disk usage is estimated (not exact).
 	FlagUsageEstimated
+	// FlagCollapsed marks a directory whose immediate entry count exceeded
+	// ScanOptions.MaxDirEntries: its contents were summed into a single
+	// synthetic child instead of being scanned entry by entry.
+	FlagCollapsed
+	// FlagTruncated marks a directory whose contents were not scanned
+	// because it sits past ScanOptions.MaxDepth: it exists in the tree (so
+	// its name is visible) but has no children and its size is zero, not
+	// "empty".
+	FlagTruncated
+	// FlagStale marks a file whose mtime is older than
+	// ScanOptions.OlderThan. Directories are never flagged stale
+	// themselves, even when every file beneath them is.
+	FlagStale
+	// FlagMountSkipped marks a directory that was not descended into
+	// because ScanOptions.OneFileSystem is set and the directory's device
+	// id differs from the scan root's: it exists in the tree (so its name
+	// and that a mount point was there is visible) but has no children and
+	// its size is zero, not "empty".
+	FlagMountSkipped
+	// FlagSparse marks a file whose disk usage is dramatically smaller than
+	// its apparent size (see SparseUsageRatio), as happens with sparse files
+	// and on heavily-compressed filesystems.
+	FlagSparse
 )
 
+// SparseUsageRatio is the usage/size threshold below which a file is flagged
+// FlagSparse: usage less than this fraction of the apparent size counts as
+// "dramatically smaller".
+const SparseUsageRatio = 0.5
+
 // FileNode represents a single file in the tree.
 type FileNode struct {
 	Name   string    // Relative name (not full path)
@@ -31,6 +60,7 @@ type FileNode struct {
 	Usage  int64     // Disk usage (blocks * block size)
 	Mtime  time.Time // Last modification time
 	Inode  uint64    // Inode number for hardlink detection
+	Mode   os.FileMode
 	Flag   NodeFlag
 	Parent *DirNode // Parent directory (nil for root)
 }
@@ -39,7 +69,8 @@ type FileNode struct {
 type DirNode struct {
 	FileNode
 	Children  []TreeNode // Mixed files and subdirectories
-	ItemCount int64      // Total recursive item count
+	ItemCount int64      // Total recursive item count (files and dirs)
+	FileCount int64      // Total recursive file count (dirs excluded)
 	mu        sync.RWMutex
 }
 
@@ -51,6 +82,7 @@ type TreeNode interface {
 	GetMtime() time.Time
 	GetFlag() NodeFlag
 	GetParent() *DirNode
+	GetItemCount() int64
 	IsDir() bool
 	Path() string
 }
@@ -63,6 +95,7 @@ func (f *FileNode) GetUsage() int64     { return f.Usage }
 func (f *FileNode) GetMtime() time.Time { return f.Mtime }
 func (f *FileNode) GetFlag() NodeFlag   { return f.Flag }
 func (f *FileNode) GetParent() *DirNode { return f.Parent }
+func (f *FileNode) GetItemCount() int64 { return 0 }
 func (f *FileNode) IsDir() bool         { return false }
 
 func (f *FileNode) Path() string {
@@ -73,6 +106,9 @@ func (f *FileNode) Path() string {
 
 func (d *DirNode) IsDir() bool { return true }
 
+// GetItemCount returns the recursive file+dir count below this directory.
+func (d *DirNode) GetItemCount() int64 { return d.ItemCount }
+
 func (d *DirNode) Path() string {
 	return buildPath(d.Parent, d.Name)
 }
@@ -97,12 +133,15 @@ func (d *DirNode) GetChildren() []TreeNode {
 func (d *DirNode) UpdateSize() {
 	d.mu.RLock()
 	var size, usage int64
-	var count int64
+	var count, fileCount int64
 	for _, c := range d.Children {
 		size = saturatingAddInt64(size, c.GetSize())
 		usage = saturatingAddInt64(usage, c.GetUsage())
 		if cd, ok := c.(*DirNode); ok {
 			count = saturatingAddInt64(count, cd.ItemCount)
+			fileCount = saturatingAddInt64(fileCount, cd.FileCount)
+		} else {
+			fileCount = saturatingAddInt64(fileCount, 1)
 		}
 		count = saturatingAddInt64(count, 1)
 	}
@@ -111,6 +150,7 @@ func (d *DirNode) UpdateSize() {
 	d.Size = size
 	d.Usage = usage
 	d.ItemCount = count
+	d.FileCount = fileCount
 }
 
 func saturatingAddInt64(a, b int64) int64 {
@@ -151,12 +191,41 @@ func (d *DirNode) propagateSizeUpdate() {
 	}
 }
 
+// PropagateSizeUpdate recalculates this directory's Size/Usage/ItemCount/
+// FileCount and does the same up through every ancestor, for callers outside
+// this package that replaced a directory's children directly via SetChildren
+// (e.g. splicing in a freshly rescanned subtree) instead of going through
+// AddChild/RemoveChild, which already keep totals in sync on their own.
+func (d *DirNode) PropagateSizeUpdate() {
+	d.propagateSizeUpdate()
+}
+
 // ReadChildren returns the children slice directly without copying.
 // Safe for post-scan read-only access when no concurrent writes occur.
 func (d *DirNode) ReadChildren() []TreeNode {
 	return d.Children
 }
 
+// SetChildren replaces this directory's children slice directly, for
+// post-scan tree transformations (e.g. ops.PruneBelow) that need to drop
+// children without the size recalculation RemoveChild performs.
+func (d *DirNode) SetChildren(children []TreeNode) {
+	d.mu.Lock()
+	d.Children = children
+	d.mu.Unlock()
+}
+
+// FreeChildren drops this directory's children slice, allowing the garbage
+// collector to reclaim an already-processed subtree (e.g. once it has been
+// written out by a streaming exporter). Size and count totals computed
+// earlier via UpdateSize/UpdateSizeRecursive are unaffected since they are
+// stored on the node itself, not derived from Children.
+func (d *DirNode) FreeChildren() {
+	d.mu.Lock()
+	d.Children = nil
+	d.mu.Unlock()
+}
+
 // NewBrokenSymlinkNode creates a placeholder node for a broken symlink.
 func NewBrokenSymlinkNode(name string, parent *DirNode) *FileNode {
 	return &FileNode{
@@ -201,6 +270,17 @@ func (d *DirNode) UpdateSizeRecursiveContext(ctx context.Context) {
 	d.UpdateSize()
 }
 
+// Walk calls fn for every node in the tree rooted at root, including root
+// itself, visiting directories before their children.
+func Walk(root TreeNode, fn func(TreeNode)) {
+	fn(root)
+	if dir, ok := root.(*DirNode); ok {
+		for _, c := range dir.GetChildren() {
+			Walk(c, fn)
+		}
+	}
+}
+
 // buildPath reconstructs the full path by walking up the parent chain.
 func buildPath(parent *DirNode, name string) string {
 	if parent == nil {
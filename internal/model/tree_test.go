@@ -79,6 +79,10 @@ func TestDirNode_UpdateSize(t *testing.T) {
 	if dir.ItemCount != 4 {
 		t.Errorf("ItemCount = %d, want 4", dir.ItemCount)
 	}
+	// FileCount: 2 files + 1 file in subdir = 3 (subdir itself excluded)
+	if dir.FileCount != 3 {
+		t.Errorf("FileCount = %d, want 3", dir.FileCount)
+	}
 }
 
 func TestDirNode_UpdateSize_SaturatesOnOverflow(t *testing.T) {
@@ -129,7 +133,7 @@ func TestSortChildren(t *testing.T) {
 	}
 
 	// Sort by size descending, dirs first
-	SortChildren(children, DefaultSort(), false)
+	SortChildren(children, DefaultSort(), SizeModeDisk)
 	if children[0].GetName() != "a_dir" {
 		t.Errorf("expected dir first, got %q", children[0].GetName())
 	}
@@ -138,18 +142,39 @@ func TestSortChildren(t *testing.T) {
 	}
 
 	// Sort by name ascending
-	SortChildren(children, SortConfig{Field: SortByName, Order: SortAsc, DirsFirst: false}, false)
+	SortChildren(children, SortConfig{Field: SortByName, Order: SortAsc, DirsFirst: false}, SizeModeDisk)
 	if children[0].GetName() != "a_dir" || children[1].GetName() != "b.txt" || children[2].GetName() != "c.txt" {
 		t.Error("expected items sorted by name ascending")
 	}
 
 	// Sort by mtime descending
-	SortChildren(children, SortConfig{Field: SortByMtime, Order: SortDesc, DirsFirst: false}, false)
+	SortChildren(children, SortConfig{Field: SortByMtime, Order: SortDesc, DirsFirst: false}, SizeModeDisk)
 	if children[0].GetName() != "a_dir" { // most recent
 		t.Errorf("expected most recent first, got %q", children[0].GetName())
 	}
 }
 
+func TestSortChildren_EqualSizeBreaksTieByNameAscending(t *testing.T) {
+	// Two files tie on size; insertion order is reversed from the expected
+	// alphabetical tiebreak, so a naive stable sort (which just preserves
+	// input order on ties) would give the wrong, input-order-dependent
+	// result here.
+	children := []TreeNode{
+		&FileNode{Name: "z.txt", Size: 10, Usage: 10},
+		&FileNode{Name: "a.txt", Size: 10, Usage: 10},
+	}
+
+	SortChildren(children, SortConfig{Field: SortBySize, Order: SortDesc, DirsFirst: false}, SizeModeDisk)
+	if children[0].GetName() != "a.txt" || children[1].GetName() != "z.txt" {
+		t.Errorf("expected a.txt before z.txt on a size tie (descending), got %q, %q", children[0].GetName(), children[1].GetName())
+	}
+
+	SortChildren(children, SortConfig{Field: SortBySize, Order: SortAsc, DirsFirst: false}, SizeModeDisk)
+	if children[0].GetName() != "a.txt" || children[1].GetName() != "z.txt" {
+		t.Errorf("expected a.txt before z.txt on a size tie (ascending), got %q, %q", children[0].GetName(), children[1].GetName())
+	}
+}
+
 func TestClassifyFile(t *testing.T) {
 	tests := []struct {
 		name string
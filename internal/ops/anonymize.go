@@ -0,0 +1,32 @@
+package ops
+
+import (
+	"fmt"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+// Anonymize replaces every name in the tree with a sequential placeholder
+// ("dir1", "file1", ...) assigned in the same pre-order model.Walk uses, so
+// running it twice on an unmodified tree yields the same labels. Sizes,
+// usage, counts, and flags are left untouched, so the result is still useful
+// for diagnosing a size problem in a bug report without revealing real
+// directory or file names. It mutates root in place and returns it for
+// convenient chaining.
+func Anonymize(root *model.DirNode) *model.DirNode {
+	if root == nil {
+		return root
+	}
+	var dirs, files int
+	model.Walk(root, func(node model.TreeNode) {
+		switch n := node.(type) {
+		case *model.DirNode:
+			dirs++
+			n.Name = fmt.Sprintf("dir%d", dirs)
+		case *model.FileNode:
+			files++
+			n.Name = fmt.Sprintf("file%d", files)
+		}
+	})
+	return root
+}
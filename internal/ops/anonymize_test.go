@@ -0,0 +1,55 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestAnonymize_ReplacesNamesButKeepsSizesAndStructure(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/home/alice/secret-project"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "src", Parent: root}}
+	sub.AddChild(&model.FileNode{Name: "main.go", Size: 100, Usage: 100, Parent: sub})
+	root.AddChild(sub)
+	root.AddChild(&model.FileNode{Name: "README.md", Size: 20, Usage: 20, Parent: root})
+	root.UpdateSizeRecursive()
+
+	wantSize := root.GetSize()
+
+	result := Anonymize(root)
+
+	if result != root {
+		t.Fatal("expected Anonymize to mutate and return the same root")
+	}
+	if root.Name == "/home/alice/secret-project" {
+		t.Fatal("expected root name to be replaced")
+	}
+	if root.GetSize() != wantSize {
+		t.Fatalf("expected size to stay %d, got %d", wantSize, root.GetSize())
+	}
+
+	children := root.GetChildren()
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	for _, c := range children {
+		if c.GetName() == "src" || c.GetName() == "README.md" {
+			t.Fatalf("expected real name %q to be replaced", c.GetName())
+		}
+	}
+
+	subDir := children[0].(*model.DirNode)
+	subChildren := subDir.GetChildren()
+	if len(subChildren) != 1 || subChildren[0].GetName() == "main.go" {
+		t.Fatalf("expected nested file name to be replaced, got %v", subChildren)
+	}
+	if subChildren[0].GetSize() != 100 {
+		t.Fatalf("expected nested file size to stay 100, got %d", subChildren[0].GetSize())
+	}
+}
+
+func TestAnonymize_NilRootIsNoOp(t *testing.T) {
+	if Anonymize(nil) != nil {
+		t.Fatal("expected Anonymize(nil) to return nil")
+	}
+}
@@ -0,0 +1,26 @@
+package ops
+
+import "github.com/sadopc/godu/internal/model"
+
+// DeepestPath returns the full path of the node nested most deeply under
+// dir, giving a concrete sense of how far a recursive delete actually
+// reaches instead of just a flat item count. Ties keep the first node found
+// in pre-order. Returns dir's own path if it has no children.
+func DeepestPath(dir *model.DirNode) string {
+	deepest := dir.Path()
+	maxDepth := 0
+	var walk func(node model.TreeNode, depth int)
+	walk = func(node model.TreeNode, depth int) {
+		if depth > maxDepth {
+			maxDepth = depth
+			deepest = node.Path()
+		}
+		if d, ok := node.(*model.DirNode); ok {
+			for _, c := range d.GetChildren() {
+				walk(c, depth+1)
+			}
+		}
+	}
+	walk(dir, 0)
+	return deepest
+}
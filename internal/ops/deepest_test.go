@@ -0,0 +1,32 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestDeepestPath_FindsMostNestedNode(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	root.AddChild(&model.FileNode{Name: "shallow.txt", Parent: root})
+
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	nested := &model.DirNode{FileNode: model.FileNode{Name: "nested", Parent: sub}}
+	nested.AddChild(&model.FileNode{Name: "deep.txt", Parent: nested})
+	sub.AddChild(nested)
+	root.AddChild(sub)
+
+	got := DeepestPath(root)
+	want := nested.Path() + "/deep.txt"
+	if got != want {
+		t.Fatalf("expected deepest path %q, got %q", want, got)
+	}
+}
+
+func TestDeepestPath_EmptyDirReturnsOwnPath(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/empty"}}
+
+	if got := DeepestPath(root); got != root.Path() {
+		t.Fatalf("expected own path %q, got %q", root.Path(), got)
+	}
+}
@@ -1,6 +1,7 @@
 package ops
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -14,36 +15,59 @@ import (
 // However, paths that traverse through a symlinked directory are blocked to prevent
 // deleting files outside the scan root.
 func Delete(path string, rootPath string) error {
+	return DeleteWithProgress(context.Background(), path, rootPath, nil)
+}
+
+// DeleteWithProgress behaves like Delete, but additionally reports how many
+// filesystem entries have been removed so far via onProgress (called from
+// whatever goroutine is doing the deleting), and aborts as soon as ctx is
+// canceled. Entries removed before cancellation stay deleted; only the rest
+// of the walk is abandoned.
+func DeleteWithProgress(ctx context.Context, path string, rootPath string, onProgress func(removed int)) error {
+	realParent, baseName, realPath, err := resolveForDelete(path, rootPath)
+	if err != nil {
+		return err
+	}
+	if err := deleteResolvedPathWithProgress(ctx, realParent, baseName, onProgress); err != nil {
+		return fmt.Errorf("cannot delete %s: %w", realPath, err)
+	}
+	return nil
+}
+
+// resolveForDelete validates that path is safe to remove — strictly inside
+// rootPath, with no symlink-traversal escape through an intermediate
+// directory — and returns the pieces both Delete and Trash need to act on
+// it: the real (symlink-resolved) parent directory, the final path
+// component, and the real full path (for error messages).
+func resolveForDelete(path, rootPath string) (realParent, baseName, realPath string, err error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return fmt.Errorf("cannot resolve path %s: %w", path, err)
+		return "", "", "", fmt.Errorf("cannot resolve path %s: %w", path, err)
 	}
 	absRoot, err := filepath.Abs(rootPath)
 	if err != nil {
-		return fmt.Errorf("cannot resolve root %s: %w", rootPath, err)
+		return "", "", "", fmt.Errorf("cannot resolve root %s: %w", rootPath, err)
 	}
 
 	// Resolve symlinks on the PARENT dir to catch traversal attacks,
 	// while keeping the final component lexical (safe to delete symlinks themselves).
-	realParent, err := filepath.EvalSymlinks(filepath.Dir(absPath))
+	realParent, err = filepath.EvalSymlinks(filepath.Dir(absPath))
 	if err != nil {
-		return fmt.Errorf("cannot resolve parent of %s: %w", absPath, err)
+		return "", "", "", fmt.Errorf("cannot resolve parent of %s: %w", absPath, err)
 	}
 	realRoot, err := filepath.EvalSymlinks(absRoot)
 	if err != nil {
-		return fmt.Errorf("cannot resolve root %s: %w", absRoot, err)
+		return "", "", "", fmt.Errorf("cannot resolve root %s: %w", absRoot, err)
 	}
 
-	realPath := filepath.Join(realParent, filepath.Base(absPath))
+	baseName = filepath.Base(absPath)
+	realPath = filepath.Join(realParent, baseName)
 
 	// Ensure the target is strictly inside the root (not the root itself).
 	rel, err := filepath.Rel(realRoot, realPath)
 	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
-		return fmt.Errorf("refusing to delete %s: outside scan root %s", absPath, absRoot)
+		return "", "", "", fmt.Errorf("refusing to delete %s: outside scan root %s", absPath, absRoot)
 	}
 
-	if err := deleteResolvedPath(realParent, filepath.Base(absPath)); err != nil {
-		return fmt.Errorf("cannot delete %s: %w", realPath, err)
-	}
-	return nil
+	return realParent, baseName, realPath, nil
 }
@@ -3,6 +3,7 @@
 package ops
 
 import (
+	"context"
 	"errors"
 	"io/fs"
 	"os"
@@ -11,19 +12,36 @@ import (
 )
 
 func deleteResolvedPath(parentPath, baseName string) error {
+	return deleteResolvedPathWithProgress(context.Background(), parentPath, baseName, nil)
+}
+
+func deleteResolvedPathWithProgress(ctx context.Context, parentPath, baseName string, onProgress func(int)) error {
 	parentFD, err := unix.Open(parentPath, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
 	if err != nil {
 		return err
 	}
 	defer unix.Close(parentFD)
 
-	return deleteAt(parentFD, baseName)
+	removed := 0
+	return deleteAt(ctx, parentFD, baseName, &removed, onProgress)
 }
 
-// deleteAt removes name relative to parentFD without following symlinks.
-func deleteAt(parentFD int, name string) error {
+// deleteAt removes name relative to parentFD without following symlinks. It
+// checks ctx before touching each entry so a cancellation stops the walk
+// partway, leaving everything removed so far deleted. removed and onProgress
+// track how many entries have been removed across the whole recursive call,
+// not just this invocation.
+func deleteAt(ctx context.Context, parentFD int, name string, removed *int, onProgress func(int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Fast path for files/symlinks.
 	if err := unix.Unlinkat(parentFD, name, 0); err == nil {
+		*removed++
+		if onProgress != nil {
+			onProgress(*removed)
+		}
 		return nil
 	} else if !errors.Is(err, unix.EISDIR) && !errors.Is(err, unix.EPERM) {
 		if errors.Is(err, unix.ENOENT) {
@@ -41,6 +59,10 @@ func deleteAt(parentFD int, name string) error {
 		// Entry may have changed type concurrently. Retry file/symlink unlink once.
 		if errors.Is(err, unix.ENOTDIR) {
 			if unlinkErr := unix.Unlinkat(parentFD, name, 0); unlinkErr == nil {
+				*removed++
+				if onProgress != nil {
+					onProgress(*removed)
+				}
 				return nil
 			} else if errors.Is(unlinkErr, unix.ENOENT) {
 				return fs.ErrNotExist
@@ -59,7 +81,7 @@ func deleteAt(parentFD int, name string) error {
 	}
 
 	for _, entry := range entries {
-		if err := deleteAt(childFD, entry.Name()); err != nil {
+		if err := deleteAt(ctx, childFD, entry.Name(), removed, onProgress); err != nil {
 			_ = childDir.Close()
 			return err
 		}
@@ -75,5 +97,9 @@ func deleteAt(parentFD int, name string) error {
 		}
 		return err
 	}
+	*removed++
+	if onProgress != nil {
+		onProgress(*removed)
+	}
 	return nil
 }
@@ -3,18 +3,36 @@
 package ops
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 )
 
 func deleteResolvedPath(parentPath, baseName string) error {
+	return deleteResolvedPathWithProgress(context.Background(), parentPath, baseName, nil)
+}
+
+// deleteResolvedPathWithProgress removes realPath in one shot via
+// os.RemoveAll, since Windows has no equivalent of Unix's *at syscalls to
+// walk and report per-entry progress cheaply. ctx is only checked before
+// starting; once underway the removal can't be interrupted partway.
+func deleteResolvedPathWithProgress(ctx context.Context, parentPath, baseName string, onProgress func(int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	realPath := filepath.Join(parentPath, baseName)
 	info, err := os.Lstat(realPath)
 	if err != nil {
 		return err
 	}
 	if info.IsDir() {
-		return os.RemoveAll(realPath)
+		err = os.RemoveAll(realPath)
+	} else {
+		err = os.Remove(realPath)
+	}
+	if err == nil && onProgress != nil {
+		onProgress(1)
 	}
-	return os.Remove(realPath)
+	return err
 }
@@ -1,6 +1,8 @@
 package ops
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -158,6 +160,47 @@ func TestDelete_DotDotInName_Allowed(t *testing.T) {
 	}
 }
 
+func TestDeleteWithProgress_CancellationStopsTheWalkPartway(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "big")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		f := filepath.Join(dir, fmt.Sprintf("file%d.txt", i))
+		if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	removedAtCancel := 0
+	err := DeleteWithProgress(ctx, dir, root, func(removed int) {
+		if removed == 2 {
+			removedAtCancel = removed
+			cancel()
+		}
+	})
+	if err == nil {
+		t.Fatal("expected an error from a canceled delete")
+	}
+	if removedAtCancel != 2 {
+		t.Fatalf("expected onProgress to fire with removed=2 before canceling, got %d", removedAtCancel)
+	}
+
+	if _, statErr := os.Lstat(dir); statErr != nil {
+		t.Fatalf("expected the directory itself to survive a canceled delete, got %v", statErr)
+	}
+
+	entries, readErr := os.ReadDir(dir)
+	if readErr != nil {
+		t.Fatalf("reading remaining entries: %v", readErr)
+	}
+	if len(entries) == 0 || len(entries) == 5 {
+		t.Fatalf("expected the walk to stop partway through, found %d of 5 entries remaining", len(entries))
+	}
+}
+
 func TestDelete_NestedFile(t *testing.T) {
 	root := t.TempDir()
 	sub := filepath.Join(root, "a", "b")
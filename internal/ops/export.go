@@ -2,6 +2,8 @@ package ops
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/sadopc/godu/internal/model"
@@ -24,10 +27,20 @@ import (
 //   ]
 // ]
 
+// CurrentSchemaVersion is bumped whenever godu adds an export field that an
+// older godu wouldn't understand. It is recorded in the ncdu header under an
+// extra "godu_schema" key, which plain ncdu ignores (unknown keys are valid
+// ncdu JSON) but godu's own importer reads to warn when a file predicts data
+// this build can't interpret.
+const CurrentSchemaVersion = 1
+
 type ncduHeader struct {
 	Progname  string `json:"progname"`
 	Progver   string `json:"progver"`
 	Timestamp int64  `json:"timestamp"`
+	// SchemaVersion is godu-specific, not part of the ncdu format; see
+	// CurrentSchemaVersion.
+	SchemaVersion int `json:"godu_schema,omitempty"`
 }
 
 type ncduEntry struct {
@@ -70,9 +83,31 @@ func (ew *errWriter) Write(data []byte) (int, error) {
 // ExportJSON exports the tree to ncdu-compatible JSON format.
 // For file targets (not stdout), writes to a temp file first and atomically
 // renames on success, so a partial file is never left behind on error.
-func ExportJSON(root *model.DirNode, path string, version string) (retErr error) {
+// When pretty is true, the output is indented for readability (still valid
+// ncdu JSON); the default compact form is smaller and faster to write.
+// If path ends in ".gz", the output is gzip-compressed transparently.
+func ExportJSON(root *model.DirNode, path string, version string, pretty bool) (retErr error) {
+	return exportJSON(root, path, version, pretty, false)
+}
+
+// ExportJSONLowMemory behaves like ExportJSON but frees each subtree from
+// the in-memory model as soon as it has been written, via
+// model.DirNode.FreeChildren. This only reduces the memory held during the
+// write itself; the scanner still has to build the whole tree in memory
+// first, so it does not help a scan that can't complete on its own. Pretty
+// output is incompatible with it (pretty mode buffers the full document
+// before indenting, which defeats the point) and returns an error.
+func ExportJSONLowMemory(root *model.DirNode, path string, version string) (retErr error) {
+	return exportJSON(root, path, version, false, true)
+}
+
+func exportJSON(root *model.DirNode, path string, version string, pretty, freeMemory bool) (retErr error) {
+	if freeMemory && pretty {
+		return fmt.Errorf("low-memory export does not support pretty output")
+	}
+
 	if path == "-" {
-		return exportToWriter(root, os.Stdout, version)
+		return exportToWriter(root, os.Stdout, version, pretty, freeMemory)
 	}
 
 	dir := filepath.Dir(path)
@@ -81,16 +116,30 @@ func ExportJSON(root *model.DirNode, path string, version string) (retErr error)
 		return fmt.Errorf("cannot create export file: %w", err)
 	}
 	tmpPath := tmp.Name()
+	TrackTempFile(tmpPath)
 	defer func() {
+		UntrackTempFile(tmpPath)
 		if retErr != nil {
 			tmp.Close()
 			os.Remove(tmpPath)
 		}
 	}()
 
-	if err := exportToWriter(root, tmp, version); err != nil {
+	var w io.Writer = tmp
+	var gzw *gzip.Writer
+	if strings.HasSuffix(path, ".gz") {
+		gzw = gzip.NewWriter(tmp)
+		w = gzw
+	}
+
+	if err := exportToWriter(root, w, version, pretty, freeMemory); err != nil {
 		return err
 	}
+	if gzw != nil {
+		if err := gzw.Close(); err != nil {
+			return err
+		}
+	}
 	if err := tmp.Close(); err != nil {
 		return err
 	}
@@ -109,7 +158,30 @@ func ExportJSON(root *model.DirNode, path string, version string) (retErr error)
 	return nil
 }
 
-func exportToWriter(root *model.DirNode, out io.Writer, version string) error {
+// exportToWriter writes the compact ncdu JSON form, then, for pretty output,
+// re-indents it with json.Indent. Compact generation plus indenting once the
+// whole document is built is simpler than threading indentation through the
+// hand-rolled recursive writer, at the cost of buffering the full output in
+// memory for the pretty case.
+func exportToWriter(root *model.DirNode, out io.Writer, version string, pretty, freeMemory bool) error {
+	if !pretty {
+		return writeCompact(root, out, version, freeMemory)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCompact(root, &buf, version, freeMemory); err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+	_, err := out.Write(indented.Bytes())
+	return err
+}
+
+func writeCompact(root *model.DirNode, out io.Writer, version string, freeMemory bool) error {
 	bw := bufio.NewWriterSize(out, 64*1024)
 	ew := &errWriter{w: bw}
 
@@ -119,9 +191,10 @@ func exportToWriter(root *model.DirNode, out io.Writer, version string) error {
 		version = "dev"
 	}
 	header := ncduHeader{
-		Progname:  "godu",
-		Progver:   version,
-		Timestamp: time.Now().Unix(),
+		Progname:      "godu",
+		Progver:       version,
+		Timestamp:     time.Now().Unix(),
+		SchemaVersion: CurrentSchemaVersion,
 	}
 	headerJSON, err := json.Marshal(header)
 	if err != nil {
@@ -131,7 +204,7 @@ func exportToWriter(root *model.DirNode, out io.Writer, version string) error {
 	ew.WriteString(",\n")
 
 	// Write tree recursively
-	writeDir(ew, root)
+	writeDir(ew, root, freeMemory)
 
 	ew.WriteString("\n]\n")
 	if ew.err != nil {
@@ -140,7 +213,7 @@ func exportToWriter(root *model.DirNode, out io.Writer, version string) error {
 	return bw.Flush()
 }
 
-func writeDir(ew *errWriter, dir *model.DirNode) {
+func writeDir(ew *errWriter, dir *model.DirNode, freeMemory bool) {
 	if ew.err != nil {
 		return
 	}
@@ -182,34 +255,45 @@ func writeDir(ew *errWriter, dir *model.DirNode) {
 
 		switch c := child.(type) {
 		case *model.DirNode:
-			writeDir(ew, c)
+			writeDir(ew, c, freeMemory)
 		case *model.FileNode:
-			entry := ncduEntry{
-				Name:  c.Name,
-				Asize: c.Size,
-				Dsize: c.Usage,
-				Ino:   c.Inode,
-			}
-			if c.Flag&model.FlagHardlink != 0 {
-				entry.Hlnkc = true
-			}
-			if c.Flag&model.FlagError != 0 {
-				entry.Err = true
-			}
-			if c.Flag&model.FlagSymlink != 0 {
-				entry.Symlink = true
-			}
-			if c.Flag&model.FlagUsageEstimated != 0 {
-				entry.UsageEstimated = true
-			}
-			data, err := json.Marshal(entry)
-			if err != nil {
-				ew.err = err
-				return
-			}
-			_, _ = ew.Write(data)
+			writeFileEntry(ew, c)
 		}
 	}
 
 	ew.WriteString("]")
+
+	if freeMemory {
+		dir.FreeChildren()
+	}
+}
+
+// writeFileEntry writes the ncdu JSON object for a single file node.
+// Factored out of writeDir so a streaming exporter can write loose top-level
+// files without going through a whole-tree recursive writer.
+func writeFileEntry(ew *errWriter, f *model.FileNode) {
+	entry := ncduEntry{
+		Name:  f.Name,
+		Asize: f.Size,
+		Dsize: f.Usage,
+		Ino:   f.Inode,
+	}
+	if f.Flag&model.FlagHardlink != 0 {
+		entry.Hlnkc = true
+	}
+	if f.Flag&model.FlagError != 0 {
+		entry.Err = true
+	}
+	if f.Flag&model.FlagSymlink != 0 {
+		entry.Symlink = true
+	}
+	if f.Flag&model.FlagUsageEstimated != 0 {
+		entry.UsageEstimated = true
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		ew.err = err
+		return
+	}
+	_, _ = ew.Write(data)
 }
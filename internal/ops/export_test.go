@@ -29,7 +29,7 @@ func TestExportJSON_Stdout(t *testing.T) {
 	defer r.Close()
 	os.Stdout = w
 
-	exportErr := ExportJSON(root, "-", "test-version")
+	exportErr := ExportJSON(root, "-", "test-version", false)
 	closeErr := w.Close()
 	os.Stdout = oldStdout
 
@@ -71,7 +71,7 @@ func TestExportJSON_AtomicNoPartialFile(t *testing.T) {
 	root.AddChild(&model.FileNode{Name: "a.txt", Size: 1, Usage: 1, Parent: root})
 	root.UpdateSize()
 
-	if err := ExportJSON(root, target, "test"); err != nil {
+	if err := ExportJSON(root, target, "test", false); err != nil {
 		t.Fatalf("export: %v", err)
 	}
 	if _, err := os.Stat(target); err != nil {
@@ -101,7 +101,7 @@ func TestExportJSON_DirFlags(t *testing.T) {
 
 	tmp := t.TempDir()
 	path := filepath.Join(tmp, "flags.json")
-	if err := ExportJSON(root, path, "test"); err != nil {
+	if err := ExportJSON(root, path, "test", false); err != nil {
 		t.Fatalf("export: %v", err)
 	}
 
@@ -114,6 +114,105 @@ func TestExportJSON_DirFlags(t *testing.T) {
 	}
 }
 
+func TestExportJSON_PrettyIndentsAndRoundTrips(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 1, Usage: 1, Parent: root})
+	root.UpdateSizeRecursive()
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "pretty.json")
+	if err := ExportJSON(root, path, "test", true); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "\n  ") {
+		t.Fatalf("expected indented output, got:\n%s", data)
+	}
+
+	imported, err := ImportJSON(path)
+	if err != nil {
+		t.Fatalf("import of pretty export failed: %v", err)
+	}
+	if imported.GetSize() != 1 {
+		t.Fatalf("expected size 1, got %d", imported.GetSize())
+	}
+}
+
+func TestExportJSON_Gzip_RoundTrips(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	sub.AddChild(&model.FileNode{Name: "a.txt", Size: 1, Usage: 2, Parent: sub})
+	root.AddChild(sub)
+	root.AddChild(&model.FileNode{Name: "b.txt", Size: 3, Usage: 4, Parent: root})
+	root.UpdateSizeRecursive()
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "scan.json.gz")
+	if err := ExportJSON(root, path, "test", false); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// gzip magic bytes.
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Fatalf("expected gzip-compressed output, got header bytes %v", data[:min(2, len(data))])
+	}
+
+	imported, err := ImportJSON(path)
+	if err != nil {
+		t.Fatalf("import of gzip export failed: %v", err)
+	}
+	if imported.GetSize() != root.GetSize() {
+		t.Fatalf("expected size %d, got %d", root.GetSize(), imported.GetSize())
+	}
+	if imported.GetUsage() != root.GetUsage() {
+		t.Fatalf("expected usage %d, got %d", root.GetUsage(), imported.GetUsage())
+	}
+	if len(imported.ReadChildren()) != len(root.ReadChildren()) {
+		t.Fatalf("expected %d top-level children, got %d", len(root.ReadChildren()), len(imported.ReadChildren()))
+	}
+}
+
+func TestExportJSONLowMemory_RoundTripsAndFreesChildren(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	sub.AddChild(&model.FileNode{Name: "a.txt", Size: 1, Usage: 1, Parent: sub})
+	root.AddChild(sub)
+	root.UpdateSizeRecursive()
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "low-memory.json")
+	if err := ExportJSONLowMemory(root, path, "test"); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if len(sub.ReadChildren()) != 0 {
+		t.Fatalf("expected sub's children to be freed after export, got %d", len(sub.ReadChildren()))
+	}
+
+	imported, err := ImportJSON(path)
+	if err != nil {
+		t.Fatalf("import of low-memory export failed: %v", err)
+	}
+	if imported.GetSize() != 1 {
+		t.Fatalf("expected size 1, got %d", imported.GetSize())
+	}
+}
+
+func TestExportJSONLowMemory_RejectsPretty(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
+	if err := exportJSON(root, filepath.Join(t.TempDir(), "x.json"), "test", true, true); err == nil {
+		t.Fatal("expected error combining pretty with low-memory export")
+	}
+}
+
 func TestExportJSON_OverwriteExistingFile(t *testing.T) {
 	tmp := t.TempDir()
 	path := filepath.Join(tmp, "scan.json")
@@ -121,14 +220,14 @@ func TestExportJSON_OverwriteExistingFile(t *testing.T) {
 	rootA := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
 	rootA.AddChild(&model.FileNode{Name: "a.txt", Size: 1, Usage: 1, Parent: rootA})
 	rootA.UpdateSizeRecursive()
-	if err := ExportJSON(rootA, path, "test"); err != nil {
+	if err := ExportJSON(rootA, path, "test", false); err != nil {
 		t.Fatalf("first export failed: %v", err)
 	}
 
 	rootB := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
 	rootB.AddChild(&model.FileNode{Name: "b.txt", Size: 7, Usage: 7, Parent: rootB})
 	rootB.UpdateSizeRecursive()
-	if err := ExportJSON(rootB, path, "test"); err != nil {
+	if err := ExportJSON(rootB, path, "test", false); err != nil {
 		t.Fatalf("second export failed: %v", err)
 	}
 
@@ -0,0 +1,141 @@
+package ops
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+var csvHeader = []string{"path", "apparent_size", "disk_usage", "is_dir", "mtime", "flags"}
+
+// ExportCSV exports the tree to a flat CSV file, one row per file or
+// directory, for loading scan results into a spreadsheet. Unlike ExportJSON,
+// rows carry no parent/child structure; the path column (the full
+// TreeNode.Path(), not just the entry name) is what ties a row back to its
+// place in the tree.
+//
+// For file targets (not stdout), writes to a temp file first and atomically
+// renames on success, so a partial file is never left behind on error.
+func ExportCSV(root *model.DirNode, path string) (retErr error) {
+	if path == "-" {
+		return writeCSV(root, os.Stdout)
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".godu-export-*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create export file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	TrackTempFile(tmpPath)
+	defer func() {
+		UntrackTempFile(tmpPath)
+		if retErr != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if err := writeCSV(root, tmp); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		// On Windows, Rename cannot replace an existing destination.
+		if runtime.GOOS != "windows" {
+			return err
+		}
+		if rmErr := os.Remove(path); rmErr != nil && !os.IsNotExist(rmErr) {
+			return fmt.Errorf("cannot replace export file %s: %w", path, err)
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV streams rows through a bufio.Writer, the same buffering
+// exportToWriter uses for JSON, so exporting a large tree does not require
+// building the whole CSV document in memory first.
+func writeCSV(root *model.DirNode, out io.Writer) error {
+	bw := bufio.NewWriterSize(out, 64*1024)
+	w := csv.NewWriter(bw)
+
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+
+	var walkErr error
+	model.Walk(root, func(node model.TreeNode) {
+		if walkErr != nil {
+			return
+		}
+		row := []string{
+			node.Path(),
+			strconv.FormatInt(node.GetSize(), 10),
+			strconv.FormatInt(node.GetUsage(), 10),
+			strconv.FormatBool(node.IsDir()),
+			node.GetMtime().UTC().Format(time.RFC3339),
+			flagNames(node.GetFlag()),
+		}
+		if err := w.Write(row); err != nil {
+			walkErr = err
+		}
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// flagNames renders a NodeFlag bitmask as a "|"-separated list of set flag
+// names, empty when no flags are set, for a single human-readable CSV column
+// rather than one boolean column per flag.
+func flagNames(flag model.NodeFlag) string {
+	var names []string
+	if flag&model.FlagSymlink != 0 {
+		names = append(names, "symlink")
+	}
+	if flag&model.FlagError != 0 {
+		names = append(names, "error")
+	}
+	if flag&model.FlagHardlink != 0 {
+		names = append(names, "hardlink")
+	}
+	if flag&model.FlagUsageEstimated != 0 {
+		names = append(names, "usage_estimated")
+	}
+	if flag&model.FlagCollapsed != 0 {
+		names = append(names, "collapsed")
+	}
+	if flag&model.FlagTruncated != 0 {
+		names = append(names, "truncated")
+	}
+	if flag&model.FlagMountSkipped != 0 {
+		names = append(names, "mount_skipped")
+	}
+	if flag&model.FlagStale != 0 {
+		names = append(names, "stale")
+	}
+	if flag&model.FlagSparse != 0 {
+		names = append(names, "sparse")
+	}
+	return strings.Join(names, "|")
+}
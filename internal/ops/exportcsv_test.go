@@ -0,0 +1,114 @@
+package ops
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestExportCSV_RoundTrips(t *testing.T) {
+	mtime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root", Mtime: mtime}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root, Mtime: mtime}}
+	root.AddChild(sub)
+
+	file := &model.FileNode{Name: "a.txt", Size: 10, Usage: 4096, Parent: root, Mtime: mtime}
+	linked := &model.FileNode{Name: "b.txt", Size: 5, Usage: 0, Parent: sub, Mtime: mtime, Flag: model.FlagSymlink}
+	root.AddChild(file)
+	sub.AddChild(linked)
+	root.UpdateSize()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "export.csv")
+
+	if err := ExportCSV(root, out); err != nil {
+		t.Fatalf("ExportCSV returned error: %v", err)
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("opening export: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing export as CSV: %v", err)
+	}
+
+	if len(rows) != 5 {
+		t.Fatalf("expected header + 4 rows, got %d: %v", len(rows), rows)
+	}
+	if got, want := rows[0], csvHeader; len(got) != len(want) {
+		t.Fatalf("unexpected header: %v", got)
+	}
+
+	byPath := make(map[string][]string)
+	for _, row := range rows[1:] {
+		byPath[row[0]] = row
+	}
+
+	rootRow, ok := byPath[root.Path()]
+	if !ok {
+		t.Fatalf("missing row for root, rows: %v", byPath)
+	}
+	if rootRow[3] != "true" {
+		t.Fatalf("expected root is_dir=true, got %q", rootRow[3])
+	}
+
+	fileRow, ok := byPath[file.Path()]
+	if !ok {
+		t.Fatalf("missing row for %s", file.Path())
+	}
+	if fileRow[1] != "10" || fileRow[2] != "4096" || fileRow[3] != "false" {
+		t.Fatalf("unexpected row for a.txt: %v", fileRow)
+	}
+	if fileRow[4] != mtime.Format(time.RFC3339) {
+		t.Fatalf("unexpected mtime for a.txt: %q", fileRow[4])
+	}
+
+	linkedRow, ok := byPath[linked.Path()]
+	if !ok {
+		t.Fatalf("missing row for %s", linked.Path())
+	}
+	if linkedRow[5] != "symlink" {
+		t.Fatalf("expected flags=symlink for b.txt, got %q", linkedRow[5])
+	}
+}
+
+func TestExportCSV_Stdout(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
+	root.AddChild(&model.FileNode{Name: "file.txt", Size: 1, Parent: root})
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer r.Close()
+	os.Stdout = w
+
+	exportErr := ExportCSV(root, "-")
+	closeErr := w.Close()
+	os.Stdout = oldStdout
+
+	if exportErr != nil {
+		t.Fatalf("ExportCSV returned error: %v", exportErr)
+	}
+	if closeErr != nil {
+		t.Fatalf("closing pipe writer failed: %v", closeErr)
+	}
+
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing stdout as CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d: %v", len(rows), rows)
+	}
+}
@@ -0,0 +1,277 @@
+package ops
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/util"
+)
+
+// ExportHTML exports the tree to a single self-contained HTML file: a
+// squarified treemap overview of the root's direct children, followed by a
+// collapsible <details> tree with inline sizes, for sharing a scan with
+// someone who isn't going to install godu to read it.
+//
+// For file targets (not stdout), writes to a temp file first and atomically
+// renames on success, so a partial file is never left behind on error.
+func ExportHTML(root *model.DirNode, path string) (retErr error) {
+	if path == "-" {
+		return writeHTML(root, os.Stdout)
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".godu-export-*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create export file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	TrackTempFile(tmpPath)
+	defer func() {
+		UntrackTempFile(tmpPath)
+		if retErr != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if err := writeHTML(root, tmp); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		// On Windows, Rename cannot replace an existing destination.
+		if runtime.GOOS != "windows" {
+			return err
+		}
+		if rmErr := os.Remove(path); rmErr != nil && !os.IsNotExist(rmErr) {
+			return fmt.Errorf("cannot replace export file %s: %w", path, err)
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// htmlTreeNode is the template-facing view of one tree node. Sizes are
+// pre-formatted since html/template has no arithmetic to call util.FormatSize
+// from within the template itself.
+type htmlTreeNode struct {
+	Name     string
+	SizeStr  string
+	UsageStr string
+	IsDir    bool
+	Children []htmlTreeNode
+}
+
+// htmlTreemapBox is one rectangle of the root-level treemap overview,
+// positioned and sized as percentages so it lays out responsively at any
+// browser width.
+type htmlTreemapBox struct {
+	Name          string
+	SizeStr       string
+	Left, Top     float64
+	Width, Height float64
+}
+
+func buildHTMLTree(node model.TreeNode) htmlTreeNode {
+	h := htmlTreeNode{
+		Name:     node.GetName(),
+		SizeStr:  util.FormatSize(node.GetSize()),
+		UsageStr: util.FormatSize(node.GetUsage()),
+		IsDir:    node.IsDir(),
+	}
+	if dir, ok := node.(*model.DirNode); ok {
+		for _, c := range dir.ReadChildren() {
+			h.Children = append(h.Children, buildHTMLTree(c))
+		}
+	}
+	return h
+}
+
+// buildHTMLTreemap lays out dir's direct children as a squarified treemap
+// (Bruls, Huizing & van Wijk) in a 100x100 percentage box, skipping
+// zero-size children since they would render as invisible slivers anyway.
+func buildHTMLTreemap(dir *model.DirNode) []htmlTreemapBox {
+	children := dir.ReadChildren()
+	items := make([]treemapItem, 0, len(children))
+	for _, c := range children {
+		if c.GetSize() > 0 {
+			items = append(items, treemapItem{name: c.GetName(), size: c.GetSize()})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].size > items[j].size })
+
+	rects := squarify(items, rect{x: 0, y: 0, w: 100, h: 100})
+	boxes := make([]htmlTreemapBox, len(items))
+	for i, it := range items {
+		boxes[i] = htmlTreemapBox{
+			Name:    it.name,
+			SizeStr: util.FormatSize(it.size),
+			Left:    rects[i].x,
+			Top:     rects[i].y,
+			Width:   rects[i].w,
+			Height:  rects[i].h,
+		}
+	}
+	return boxes
+}
+
+type treemapItem struct {
+	name string
+	size int64
+}
+
+type rect struct {
+	x, y, w, h float64
+}
+
+// squarify lays out items, already sorted largest-first, into bounds using
+// the squarified treemap algorithm: rows are grown one item at a time as
+// long as doing so improves (lowers) the worst aspect ratio in the row,
+// keeping rectangles close to square instead of thin slivers.
+func squarify(items []treemapItem, bounds rect) []rect {
+	if len(items) == 0 {
+		return nil
+	}
+	var total float64
+	for _, it := range items {
+		total += float64(it.size)
+	}
+	if total <= 0 {
+		return make([]rect, len(items))
+	}
+	scale := (bounds.w * bounds.h) / total
+
+	result := make([]rect, 0, len(items))
+	var row []float64
+	remaining := bounds
+	for i := 0; i < len(items); i++ {
+		area := float64(items[i].size) * scale
+		candidate := append(append([]float64{}, row...), area)
+		if len(row) == 0 || worstAspect(row, shortestSide(remaining)) >= worstAspect(candidate, shortestSide(remaining)) {
+			row = candidate
+			continue
+		}
+		var laidOut []rect
+		laidOut, remaining = layoutRow(row, remaining)
+		result = append(result, laidOut...)
+		row = []float64{area}
+	}
+	if len(row) > 0 {
+		laidOut, _ := layoutRow(row, remaining)
+		result = append(result, laidOut...)
+	}
+	return result
+}
+
+func shortestSide(r rect) float64 {
+	if r.w < r.h {
+		return r.w
+	}
+	return r.h
+}
+
+// worstAspect returns the worst (largest) width/height ratio any rectangle
+// in row would have if laid out along a strip of the given side length.
+func worstAspect(row []float64, side float64) float64 {
+	var sum, max, min float64
+	min = row[0]
+	for _, v := range row {
+		sum += v
+		if v > max {
+			max = v
+		}
+		if v < min {
+			min = v
+		}
+	}
+	s2 := side * side
+	a := (s2 * max) / (sum * sum)
+	b := (sum * sum) / (s2 * min)
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// layoutRow places row's areas as a single strip of rectangles along the
+// shorter dimension of bounds, and returns the space remaining for the next
+// row.
+func layoutRow(row []float64, bounds rect) ([]rect, rect) {
+	var sum float64
+	for _, v := range row {
+		sum += v
+	}
+	result := make([]rect, len(row))
+	if bounds.w >= bounds.h {
+		stripW := sum / bounds.h
+		y := bounds.y
+		for i, v := range row {
+			h := v / stripW
+			result[i] = rect{x: bounds.x, y: y, w: stripW, h: h}
+			y += h
+		}
+		return result, rect{x: bounds.x + stripW, y: bounds.y, w: bounds.w - stripW, h: bounds.h}
+	}
+	stripH := sum / bounds.w
+	x := bounds.x
+	for i, v := range row {
+		w := v / stripH
+		result[i] = rect{x: x, y: bounds.y, w: w, h: stripH}
+		x += w
+	}
+	return result, rect{x: bounds.x, y: bounds.y + stripH, w: bounds.w, h: bounds.h - stripH}
+}
+
+var htmlExportTemplate = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>godu report: {{.Root.Name}}</title>
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2rem; color: #222; }
+h1 { font-size: 1.2rem; }
+.treemap { position: relative; width: 100%; height: 400px; border: 1px solid #ccc; margin-bottom: 2rem; }
+.treemap-box { position: absolute; box-sizing: border-box; border: 1px solid #fff; overflow: hidden; padding: 2px 4px; font-size: 0.75rem; color: #fff; background: #4A6FA5; }
+.tree { font-size: 0.9rem; }
+.tree summary { cursor: pointer; }
+.size { color: #666; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>godu report: {{.Root.Name}}</h1>
+<div class="treemap">
+{{range .Treemap}}<div class="treemap-box" style="left:{{.Left}}%;top:{{.Top}}%;width:{{.Width}}%;height:{{.Height}}%;">{{.Name}} <span class="size">{{.SizeStr}}</span></div>
+{{end}}</div>
+<div class="tree">
+{{template "node" .Root}}
+</div>
+</body>
+</html>
+{{define "node"}}{{if .IsDir}}<details{{if not .Children}} open{{end}}>
+<summary>{{.Name}}/ <span class="size">{{.SizeStr}} ({{.UsageStr}} on disk)</span></summary>
+{{range .Children}}{{template "node" .}}{{end}}
+</details>
+{{else}}<div>{{.Name}} <span class="size">{{.SizeStr}} ({{.UsageStr}} on disk)</span></div>
+{{end}}{{end}}
+`))
+
+func writeHTML(root *model.DirNode, out io.Writer) error {
+	data := struct {
+		Root    htmlTreeNode
+		Treemap []htmlTreemapBox
+	}{
+		Root:    buildHTMLTree(root),
+		Treemap: buildHTMLTreemap(root),
+	}
+	return htmlExportTemplate.Execute(out, data)
+}
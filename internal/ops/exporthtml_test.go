@@ -0,0 +1,78 @@
+package ops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestExportHTML_ContainsRootAndChild(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+	file := &model.FileNode{Name: "a.txt", Size: 10, Parent: root}
+	root.AddChild(file)
+	root.UpdateSize()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "export.html")
+
+	if err := ExportHTML(root, out); err != nil {
+		t.Fatalf("ExportHTML returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+	html := string(data)
+
+	if !strings.Contains(html, "<!DOCTYPE html>") {
+		t.Fatalf("expected output to be an HTML document, got: %s", html)
+	}
+	if !strings.Contains(html, root.Name) {
+		t.Errorf("expected output to mention root name %q", root.Name)
+	}
+	if !strings.Contains(html, "a.txt") {
+		t.Errorf("expected output to mention child file a.txt")
+	}
+	if !strings.Contains(html, "sub") {
+		t.Errorf("expected output to mention child directory sub")
+	}
+}
+
+func TestExportHTML_Stdout(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/root"}}
+	root.AddChild(&model.FileNode{Name: "file.txt", Size: 1, Parent: root})
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer r.Close()
+	os.Stdout = w
+
+	exportErr := ExportHTML(root, "-")
+	closeErr := w.Close()
+	os.Stdout = oldStdout
+
+	if exportErr != nil {
+		t.Fatalf("ExportHTML returned error: %v", exportErr)
+	}
+	if closeErr != nil {
+		t.Fatalf("closing pipe writer failed: %v", closeErr)
+	}
+
+	buf := make([]byte, 64*1024)
+	n, err := r.Read(buf)
+	if err != nil {
+		t.Fatalf("reading stdout pipe: %v", err)
+	}
+	if !strings.Contains(string(buf[:n]), "file.txt") {
+		t.Fatalf("expected stdout export to mention file.txt, got: %s", buf[:n])
+	}
+}
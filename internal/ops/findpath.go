@@ -0,0 +1,112 @@
+package ops
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+// FindByPath resolves target against the scanned tree rooted at root and
+// returns the matching directory. target may be the root's own path, an
+// absolute path nested under it, or a path relative to the root. It does not
+// touch the filesystem: resolution walks DirNode children by name only, so
+// it works equally for a live scan and an imported one.
+func FindByPath(root *model.DirNode, target string) (*model.DirNode, error) {
+	if root == nil {
+		return nil, fmt.Errorf("no scan root to search")
+	}
+
+	rootPath := root.Path()
+	clean := filepath.Clean(target)
+
+	if clean == rootPath || clean == "." {
+		return root, nil
+	}
+
+	rel, err := filepath.Rel(rootPath, clean)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		// Not nested under rootPath as an absolute path; try it as a path
+		// relative to the root instead.
+		rel = clean
+	}
+	if rel == "." {
+		return root, nil
+	}
+
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	current := root
+	for _, seg := range segments {
+		if seg == "" || seg == "." {
+			continue
+		}
+		next := findChildDir(current, seg)
+		if next == nil {
+			return nil, fmt.Errorf("path not found in scanned tree: %q", target)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// findChildDir returns the direct child directory of dir named name, or nil
+// if there is no such directory (files never match: there is nowhere to jump
+// to inside one).
+func findChildDir(dir *model.DirNode, name string) *model.DirNode {
+	for _, c := range dir.GetChildren() {
+		if cd, ok := c.(*model.DirNode); ok && cd.GetName() == name {
+			return cd
+		}
+	}
+	return nil
+}
+
+// FindNodeByPath resolves target against the scanned tree rooted at root and
+// returns the matching node, file or directory. Unlike FindByPath, it can
+// return a leaf file, which makes it suitable for looking up marked items
+// that may no longer be part of the current listing.
+func FindNodeByPath(root *model.DirNode, target string) (model.TreeNode, error) {
+	if root == nil {
+		return nil, fmt.Errorf("no scan root to search")
+	}
+
+	rootPath := root.Path()
+	clean := filepath.Clean(target)
+
+	if clean == rootPath || clean == "." {
+		return root, nil
+	}
+
+	rel, err := filepath.Rel(rootPath, clean)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		rel = clean
+	}
+	if rel == "." {
+		return root, nil
+	}
+
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	var current model.TreeNode = root
+	for _, seg := range segments {
+		if seg == "" || seg == "." {
+			continue
+		}
+		dir, ok := current.(*model.DirNode)
+		if !ok {
+			return nil, fmt.Errorf("path not found in scanned tree: %q", target)
+		}
+		var next model.TreeNode
+		for _, c := range dir.GetChildren() {
+			if c.GetName() == seg {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("path not found in scanned tree: %q", target)
+		}
+		current = next
+	}
+	return current, nil
+}
@@ -0,0 +1,56 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func buildFindPathFixture() *model.DirNode {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	nested := &model.DirNode{FileNode: model.FileNode{Name: "nested", Parent: sub}}
+	sub.AddChild(nested)
+	root.AddChild(sub)
+	root.AddChild(&model.FileNode{Name: "file.txt", Parent: root})
+	return root
+}
+
+func TestFindByPath_ResolvesAbsolutePath(t *testing.T) {
+	root := buildFindPathFixture()
+
+	dir, err := FindByPath(root, "/scan/root/sub/nested")
+	if err != nil {
+		t.Fatalf("FindByPath: %v", err)
+	}
+	if dir.GetName() != "nested" {
+		t.Fatalf("expected nested, got %q", dir.GetName())
+	}
+}
+
+func TestFindByPath_ResolvesRootAndRelativePath(t *testing.T) {
+	root := buildFindPathFixture()
+
+	if dir, err := FindByPath(root, "/scan/root"); err != nil || dir != root {
+		t.Fatalf("expected root, got %v, err %v", dir, err)
+	}
+	if dir, err := FindByPath(root, "sub"); err != nil || dir.GetName() != "sub" {
+		t.Fatalf("expected sub, got %v, err %v", dir, err)
+	}
+}
+
+func TestFindByPath_RejectsUnknownPath(t *testing.T) {
+	root := buildFindPathFixture()
+
+	if _, err := FindByPath(root, "/scan/root/missing"); err == nil {
+		t.Fatal("expected error for unknown path")
+	}
+}
+
+func TestFindByPath_RejectsFileAsPathSegment(t *testing.T) {
+	root := buildFindPathFixture()
+
+	if _, err := FindByPath(root, "/scan/root/file.txt"); err == nil {
+		t.Fatal("expected error because file.txt is a file, not a directory")
+	}
+}
@@ -0,0 +1,68 @@
+package ops
+
+import (
+	"sort"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+// HardlinkGroup is one set of files that share an inode: multiple directory
+// entries pointing at the same on-disk data. Size is that shared size
+// counted once, not once per path.
+type HardlinkGroup struct {
+	Inode uint64
+	Paths []string
+	Size  int64
+}
+
+// HardlinkGroups walks the tree rooted at root and groups files flagged
+// model.FlagHardlink by their Inode, so each returned group lists every
+// known path sharing that inode with its size counted once. Groups are
+// sorted by size descending. Inodes with only one surviving path (e.g. the
+// other links fell outside the scanned subtree) are omitted, since there's
+// nothing to group.
+func HardlinkGroups(root *model.DirNode, sizeOf func(model.TreeNode) int64) []HardlinkGroup {
+	if root == nil {
+		return nil
+	}
+
+	type accum struct {
+		paths []string
+		size  int64
+	}
+	byInode := make(map[uint64]*accum)
+
+	model.Walk(root, func(node model.TreeNode) {
+		if node.IsDir() || node.GetFlag()&model.FlagHardlink == 0 {
+			return
+		}
+		fn, ok := node.(*model.FileNode)
+		if !ok {
+			return
+		}
+		a, ok := byInode[fn.Inode]
+		if !ok {
+			a = &accum{size: sizeOf(node)}
+			byInode[fn.Inode] = a
+		}
+		a.paths = append(a.paths, node.Path())
+	})
+
+	var groups []HardlinkGroup
+	for inode, a := range byInode {
+		if len(a.paths) < 2 {
+			continue
+		}
+		sort.Strings(a.paths)
+		groups = append(groups, HardlinkGroup{Inode: inode, Paths: a.paths, Size: a.size})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Size != groups[j].Size {
+			return groups[i].Size > groups[j].Size
+		}
+		return groups[i].Inode < groups[j].Inode
+	})
+
+	return groups
+}
@@ -0,0 +1,53 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestHardlinkGroups_GroupsSharedInodeAndCountsSizeOnce(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+
+	linkA := &model.FileNode{Name: "a.txt", Size: 100, Inode: 42, Flag: model.FlagHardlink, Parent: root}
+	linkB := &model.FileNode{Name: "b.txt", Size: 100, Inode: 42, Flag: model.FlagHardlink, Parent: sub}
+	unique := &model.FileNode{Name: "c.txt", Size: 50, Inode: 7, Parent: root}
+	root.AddChild(linkA)
+	root.AddChild(unique)
+	sub.AddChild(linkB)
+
+	groups := HardlinkGroups(root, func(n model.TreeNode) int64 { return n.GetSize() })
+	if len(groups) != 1 {
+		t.Fatalf("expected exactly 1 hardlink group, got %d: %+v", len(groups), groups)
+	}
+
+	g := groups[0]
+	if g.Inode != 42 {
+		t.Fatalf("expected inode 42, got %d", g.Inode)
+	}
+	if g.Size != 100 {
+		t.Fatalf("expected shared size counted once as 100, got %d", g.Size)
+	}
+	if len(g.Paths) != 2 || g.Paths[0] != linkA.Path() || g.Paths[1] != linkB.Path() {
+		t.Fatalf("expected both member paths sorted, got %v", g.Paths)
+	}
+}
+
+func TestHardlinkGroups_OmitsUnflaggedAndSingletonInodes(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	root.AddChild(&model.FileNode{Name: "solo.txt", Size: 10, Inode: 1, Parent: root})
+	root.AddChild(&model.FileNode{Name: "flagged-but-alone.txt", Size: 20, Inode: 2, Flag: model.FlagHardlink, Parent: root})
+
+	groups := HardlinkGroups(root, func(n model.TreeNode) int64 { return n.GetSize() })
+	if len(groups) != 0 {
+		t.Fatalf("expected no groups when no inode has more than one member, got %+v", groups)
+	}
+}
+
+func TestHardlinkGroups_NilRoot(t *testing.T) {
+	if groups := HardlinkGroups(nil, func(n model.TreeNode) int64 { return n.GetSize() }); groups != nil {
+		t.Fatalf("expected nil groups for nil root, got %v", groups)
+	}
+}
@@ -1,6 +1,7 @@
 package ops
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -33,64 +34,128 @@ func validateName(name string) error {
 	return nil
 }
 
-// ImportJSON imports a tree from ncdu-compatible JSON format.
+// ImportMeta describes the scan metadata recorded in an import's ncdu
+// header, so the UI can show when (and by what) a tree was produced.
+type ImportMeta struct {
+	// ProgName and ProgVer echo the header's progname/progver fields, e.g.
+	// "godu" and "1.4.0".
+	ProgName string
+	// ProgVer is the exporter's version string.
+	ProgVer string
+	// Timestamp is when the export was written, or the zero time if the
+	// header omitted it.
+	Timestamp time.Time
+	// SchemaVersion is godu's own schema version recorded at export time (0
+	// for files with no godu_schema field, e.g. exported by plain ncdu or a
+	// godu predating this field).
+	SchemaVersion int
+	// Warning is set when the file's SchemaVersion is newer than
+	// CurrentSchemaVersion, so this build may not understand everything it
+	// exported. Empty when there's nothing to warn about.
+	Warning string
+}
+
+// ImportJSON imports a tree from ncdu-compatible JSON format. If path is
+// "-", it reads from os.Stdin instead of opening a file. If path ends in
+// ".gz", the input is transparently gzip-decompressed.
 func ImportJSON(path string) (*model.DirNode, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("cannot open import file: %w", err)
+	root, _, err := ImportJSONWithMeta(path)
+	return root, err
+}
+
+// ImportJSONWithMeta behaves like ImportJSON but also returns the scan
+// metadata recorded in the file's ncdu header.
+func ImportJSONWithMeta(path string) (*model.DirNode, ImportMeta, error) {
+	var f io.ReadCloser
+	if path == "-" {
+		f = io.NopCloser(os.Stdin)
+	} else {
+		var err error
+		f, err = os.Open(path)
+		if err != nil {
+			return nil, ImportMeta{}, fmt.Errorf("cannot open import file: %w", err)
+		}
 	}
 	defer f.Close()
 
-	dec := json.NewDecoder(f)
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gzr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, ImportMeta{}, fmt.Errorf("cannot decompress import file: %w", err)
+		}
+		defer gzr.Close()
+		r = gzr
+	}
+
+	dec := json.NewDecoder(r)
 	if err := expectDelim(dec, '[', "invalid JSON: top-level value must be an array"); err != nil {
-		return nil, err
+		return nil, ImportMeta{}, err
 	}
 
 	var root *model.DirNode
+	var header ncduHeader
 	elem := 0
 	for dec.More() {
 		switch elem {
-		case 0, 1, 2:
+		case 0, 1:
 			var discard any
 			if err := dec.Decode(&discard); err != nil {
-				return nil, fmt.Errorf("invalid ncdu format: cannot parse top-level element %d: %w", elem, err)
+				return nil, ImportMeta{}, fmt.Errorf("invalid ncdu format: cannot parse top-level element %d: %w", elem, err)
+			}
+		case 2:
+			if err := dec.Decode(&header); err != nil {
+				return nil, ImportMeta{}, fmt.Errorf("invalid ncdu format: cannot parse header: %w", err)
 			}
 		case 3:
 			subdir, err := parseDirFromDecoder(dec, nil, 0, false)
 			if err != nil {
-				return nil, fmt.Errorf("cannot parse root directory: %w", err)
+				return nil, ImportMeta{}, fmt.Errorf("cannot parse root directory: %w", err)
 			}
 			root = subdir
 		default:
 			// Ignore optional trailing top-level metadata while still validating JSON.
 			var discard any
 			if err := dec.Decode(&discard); err != nil {
-				return nil, fmt.Errorf("invalid ncdu format: cannot parse top-level element %d: %w", elem, err)
+				return nil, ImportMeta{}, fmt.Errorf("invalid ncdu format: cannot parse top-level element %d: %w", elem, err)
 			}
 		}
 		elem++
 	}
 	if err := expectDelim(dec, ']', "invalid JSON: malformed top-level array"); err != nil {
-		return nil, err
+		return nil, ImportMeta{}, err
 	}
 
 	if elem < 4 {
-		return nil, fmt.Errorf("invalid ncdu format: expected at least 4 elements, got %d", elem)
+		return nil, ImportMeta{}, fmt.Errorf("invalid ncdu format: expected at least 4 elements, got %d", elem)
 	}
 	if root == nil {
-		return nil, fmt.Errorf("invalid ncdu format: missing root directory")
+		return nil, ImportMeta{}, fmt.Errorf("invalid ncdu format: missing root directory")
 	}
 
 	// Reject trailing non-whitespace input.
 	if err := dec.Decode(&struct{}{}); err != io.EOF {
 		if err == nil {
-			return nil, fmt.Errorf("invalid JSON: trailing data after top-level array")
+			return nil, ImportMeta{}, fmt.Errorf("invalid JSON: trailing data after top-level array")
 		}
-		return nil, fmt.Errorf("invalid JSON: trailing data after top-level array: %w", err)
+		return nil, ImportMeta{}, fmt.Errorf("invalid JSON: trailing data after top-level array: %w", err)
 	}
 
 	root.UpdateSize()
-	return root, nil
+
+	meta := ImportMeta{
+		ProgName:      header.Progname,
+		ProgVer:       header.Progver,
+		SchemaVersion: header.SchemaVersion,
+	}
+	if header.Timestamp > 0 {
+		meta.Timestamp = time.Unix(header.Timestamp, 0)
+	}
+	if header.SchemaVersion > CurrentSchemaVersion {
+		meta.Warning = fmt.Sprintf("exported by a newer version of godu (schema %d, this build understands up to %d); some fields may be ignored", header.SchemaVersion, CurrentSchemaVersion)
+	}
+
+	return root, meta, nil
 }
 
 const maxImportDepth = 1000
@@ -156,6 +221,7 @@ func parseDirFromDecoder(dec *json.Decoder, parent *model.DirNode, depth int, op
 	}
 
 	// Remaining elements are children (objects = files, arrays = subdirs).
+	seenNames := make(map[string]struct{})
 	for i := 1; dec.More(); i++ {
 		tok, err := dec.Token()
 		if err != nil {
@@ -172,6 +238,10 @@ func parseDirFromDecoder(dec *json.Decoder, parent *model.DirNode, depth int, op
 			if err != nil {
 				return nil, err
 			}
+			if _, dup := seenNames[subDir.Name]; dup {
+				return nil, fmt.Errorf("duplicate child name %q in directory %q", subDir.Name, entry.Name)
+			}
+			seenNames[subDir.Name] = struct{}{}
 			dir.AddChild(subDir)
 		case '{':
 			fileEntry, err := parseNCDUEntry(dec, true)
@@ -203,6 +273,11 @@ func parseDirFromDecoder(dec *json.Decoder, parent *model.DirNode, depth int, op
 				return nil, err
 			}
 
+			if _, dup := seenNames[fileEntry.Name]; dup {
+				return nil, fmt.Errorf("duplicate child name %q in directory %q", fileEntry.Name, entry.Name)
+			}
+			seenNames[fileEntry.Name] = struct{}{}
+
 			fileNode := &model.FileNode{
 				Name:   fileEntry.Name,
 				Size:   fileEntry.Asize,
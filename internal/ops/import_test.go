@@ -45,6 +45,23 @@ garbage`
 	}
 }
 
+func TestImportJSON_RejectsDuplicateChildName(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "dup.json")
+	data := `[1,0,{"progname":"godu","progver":"dev","timestamp":0},[{"name":"/tmp/root"},{"name":"dup.txt","asize":1,"dsize":1},{"name":"dup.txt","asize":2,"dsize":2}]]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ImportJSON(path)
+	if err == nil {
+		t.Fatal("expected duplicate child name to fail import")
+	}
+	if !strings.Contains(err.Error(), "duplicate child name") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestImportJSON_RejectsNegativeDirectorySize(t *testing.T) {
 	tmp := t.TempDir()
 	path := filepath.Join(tmp, "bad-dir-size.json")
@@ -116,7 +133,7 @@ func TestImportJSON_DirFlagsRoundTrip(t *testing.T) {
 
 	tmp := t.TempDir()
 	path := filepath.Join(tmp, "flags.json")
-	if err := ExportJSON(root, path, "test"); err != nil {
+	if err := ExportJSON(root, path, "test", false); err != nil {
 		t.Fatalf("export: %v", err)
 	}
 
@@ -168,3 +185,52 @@ func TestImportJSON_DepthLimit(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestImportJSONWithMeta_ReadsBackExportHeader(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 5, Parent: root})
+	root.UpdateSize()
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "export.json")
+	if err := ExportJSON(root, path, "1.2.3", false); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+
+	_, meta, err := ImportJSONWithMeta(path)
+	if err != nil {
+		t.Fatalf("ImportJSONWithMeta: %v", err)
+	}
+	if meta.ProgName != "godu" {
+		t.Errorf("expected progname %q, got %q", "godu", meta.ProgName)
+	}
+	if meta.ProgVer != "1.2.3" {
+		t.Errorf("expected progver %q, got %q", "1.2.3", meta.ProgVer)
+	}
+	if meta.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", CurrentSchemaVersion, meta.SchemaVersion)
+	}
+	if meta.Timestamp.IsZero() {
+		t.Error("expected a non-zero timestamp")
+	}
+	if meta.Warning != "" {
+		t.Errorf("expected no warning for a file at the current schema version, got %q", meta.Warning)
+	}
+}
+
+func TestImportJSONWithMeta_WarnsOnNewerSchemaVersion(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "future.json")
+	data := `[1,0,{"progname":"godu","progver":"99.0","timestamp":1000,"godu_schema":999999},[{"name":"/tmp/root"}]]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, meta, err := ImportJSONWithMeta(path)
+	if err != nil {
+		t.Fatalf("ImportJSONWithMeta: %v", err)
+	}
+	if meta.Warning == "" {
+		t.Error("expected a warning for a file exported with a much newer schema version")
+	}
+}
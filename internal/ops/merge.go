@@ -0,0 +1,41 @@
+package ops
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+// MergeTrees combines several independently scanned/imported trees under a
+// single synthetic root, for fleet-wide analysis of per-host exports. Each
+// root becomes a direct child labeled with the base name of its own root
+// path (a root's Name is normally a full path, which is not a valid child
+// name); if two roots share a label, later ones are disambiguated with a
+// numeric suffix so no data is silently dropped or overwritten.
+func MergeTrees(roots ...*model.DirNode) *model.DirNode {
+	merged := &model.DirNode{FileNode: model.FileNode{Name: "merged"}}
+
+	used := make(map[string]int)
+	for _, root := range roots {
+		if root == nil {
+			continue
+		}
+		base := filepath.Base(root.Name)
+		if base == "" || base == "." || base == string(filepath.Separator) {
+			base = "root"
+		}
+		label := base
+		if n := used[base]; n > 0 {
+			label = fmt.Sprintf("%s (%d)", base, n+1)
+		}
+		used[base]++
+
+		root.Name = label
+		root.Parent = merged
+		merged.AddChild(root)
+	}
+
+	merged.UpdateSize()
+	return merged
+}
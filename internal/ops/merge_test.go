@@ -0,0 +1,58 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestMergeTrees_CombinesUnderSyntheticRoot(t *testing.T) {
+	hostA := &model.DirNode{FileNode: model.FileNode{Name: "host-a"}}
+	hostA.AddChild(&model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: hostA})
+	hostA.UpdateSizeRecursive()
+
+	hostB := &model.DirNode{FileNode: model.FileNode{Name: "host-b"}}
+	hostB.AddChild(&model.FileNode{Name: "b.txt", Size: 5, Usage: 5, Parent: hostB})
+	hostB.UpdateSizeRecursive()
+
+	merged := MergeTrees(hostA, hostB)
+
+	children := merged.GetChildren()
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	if merged.GetSize() != 15 {
+		t.Fatalf("expected merged size 15, got %d", merged.GetSize())
+	}
+	if children[0].GetName() != "host-a" || children[1].GetName() != "host-b" {
+		t.Fatalf("expected labeled children host-a/host-b, got %v", children)
+	}
+}
+
+func TestMergeTrees_DisambiguatesNameCollisions(t *testing.T) {
+	first := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	first.UpdateSizeRecursive()
+	second := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	second.UpdateSizeRecursive()
+
+	merged := MergeTrees(first, second)
+
+	children := merged.GetChildren()
+	if children[0].GetName() != "root" {
+		t.Fatalf("expected first child named root, got %q", children[0].GetName())
+	}
+	if children[1].GetName() != "root (2)" {
+		t.Fatalf("expected second child disambiguated, got %q", children[1].GetName())
+	}
+}
+
+func TestMergeTrees_SkipsNilRoots(t *testing.T) {
+	only := &model.DirNode{FileNode: model.FileNode{Name: "solo"}}
+	only.UpdateSizeRecursive()
+
+	merged := MergeTrees(nil, only, nil)
+
+	if len(merged.GetChildren()) != 1 {
+		t.Fatalf("expected nil roots to be skipped, got %d children", len(merged.GetChildren()))
+	}
+}
@@ -0,0 +1,31 @@
+package ops
+
+import "github.com/sadopc/godu/internal/model"
+
+// PruneBelow removes directory subtrees whose disk usage is below threshold
+// bytes, hiding small branches from the TUI or -export so only "big folder"
+// candidates remain. Ancestor Size/Usage/ItemCount totals are left exactly
+// as the scanner computed them rather than recalculated from the pruned
+// children, so percentages and totals still reflect the whole scan. Files
+// are never pruned directly; the threshold targets directories only.
+func PruneBelow(root *model.DirNode, threshold int64) {
+	if root == nil || threshold <= 0 {
+		return
+	}
+	pruneDir(root, threshold)
+}
+
+func pruneDir(dir *model.DirNode, threshold int64) {
+	children := dir.ReadChildren()
+	kept := make([]model.TreeNode, 0, len(children))
+	for _, c := range children {
+		if sub, ok := c.(*model.DirNode); ok {
+			if sub.GetUsage() < threshold {
+				continue
+			}
+			pruneDir(sub, threshold)
+		}
+		kept = append(kept, c)
+	}
+	dir.SetChildren(kept)
+}
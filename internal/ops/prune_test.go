@@ -0,0 +1,65 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestPruneBelow_RemovesSmallSubtreesButKeepsAncestorTotals(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+
+	big := &model.DirNode{FileNode: model.FileNode{Name: "big", Parent: root}}
+	big.AddChild(&model.FileNode{Name: "f.bin", Size: 2000, Usage: 2000, Parent: big})
+
+	small := &model.DirNode{FileNode: model.FileNode{Name: "small", Parent: root}}
+	small.AddChild(&model.FileNode{Name: "f.bin", Size: 10, Usage: 10, Parent: small})
+
+	root.AddChild(big)
+	root.AddChild(small)
+	root.UpdateSizeRecursive()
+
+	wantTotal := root.GetUsage()
+
+	PruneBelow(root, 1000)
+
+	children := root.GetChildren()
+	if len(children) != 1 || children[0].GetName() != "big" {
+		t.Fatalf("expected only 'big' to survive pruning, got %v", children)
+	}
+	if root.GetUsage() != wantTotal {
+		t.Fatalf("expected ancestor total to stay %d, got %d", wantTotal, root.GetUsage())
+	}
+}
+
+func TestPruneBelow_PrunesNestedSubtrees(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	mid := &model.DirNode{FileNode: model.FileNode{Name: "mid", Parent: root}}
+	bigLeaf := &model.DirNode{FileNode: model.FileNode{Name: "bigleaf", Parent: mid}}
+	bigLeaf.AddChild(&model.FileNode{Name: "f.bin", Size: 2000, Usage: 2000, Parent: bigLeaf})
+	smallLeaf := &model.DirNode{FileNode: model.FileNode{Name: "smallleaf", Parent: mid}}
+	smallLeaf.AddChild(&model.FileNode{Name: "f.bin", Size: 1, Usage: 1, Parent: smallLeaf})
+	mid.AddChild(bigLeaf)
+	mid.AddChild(smallLeaf)
+	root.AddChild(mid)
+	root.UpdateSizeRecursive()
+
+	PruneBelow(root, 1000)
+
+	midChildren := mid.GetChildren()
+	if len(midChildren) != 1 || midChildren[0].GetName() != "bigleaf" {
+		t.Fatalf("expected only 'bigleaf' to survive under mid, got %v", midChildren)
+	}
+}
+
+func TestPruneBelow_ZeroThresholdIsNoOp(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}})
+	root.UpdateSizeRecursive()
+
+	PruneBelow(root, 0)
+
+	if len(root.GetChildren()) != 1 {
+		t.Fatalf("expected no pruning with threshold 0, got %d children", len(root.GetChildren()))
+	}
+}
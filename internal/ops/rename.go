@@ -0,0 +1,37 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Rename changes the final path component of path to newName. It applies the
+// same root-containment safety checks as Delete and rejects newName with the
+// same rules ImportJSON uses to validate entry names (validateName), since a
+// name like "../escape" would let a rename move something outside the scan
+// root just as surely as a crafted import would. It refuses to overwrite an
+// existing file or directory at the destination. On success it returns the
+// new full path.
+func Rename(path, newName, rootPath string) (newPath string, err error) {
+	if err := validateName(newName); err != nil {
+		return "", fmt.Errorf("invalid name: %w", err)
+	}
+
+	realParent, _, realPath, err := resolveForDelete(path, rootPath)
+	if err != nil {
+		return "", err
+	}
+
+	newPath = filepath.Join(realParent, newName)
+	if _, err := os.Lstat(newPath); err == nil {
+		return "", fmt.Errorf("cannot rename %s: %s already exists", realPath, newPath)
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("cannot stat %s: %w", newPath, err)
+	}
+
+	if err := os.Rename(realPath, newPath); err != nil {
+		return "", fmt.Errorf("cannot rename %s: %w", realPath, err)
+	}
+	return newPath, nil
+}
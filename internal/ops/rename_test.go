@@ -0,0 +1,120 @@
+package ops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRename_NormalFile(t *testing.T) {
+	root := t.TempDir()
+	f := filepath.Join(root, "old.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newPath, err := Rename(f, "new.txt", root)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if want := filepath.Join(root, "new.txt"); newPath != want {
+		t.Fatalf("expected new path %s, got %s", want, newPath)
+	}
+	if _, err := os.Lstat(newPath); err != nil {
+		t.Fatal("renamed file should exist at new path")
+	}
+	if _, err := os.Lstat(f); !os.IsNotExist(err) {
+		t.Fatal("old path should no longer exist")
+	}
+}
+
+func TestRename_Directory(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "olddir")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newPath, err := Rename(dir, "newdir", root)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(newPath, "a.txt")); err != nil {
+		t.Fatal("contents should have moved with the directory")
+	}
+}
+
+func TestRename_RejectsNameWithSlash(t *testing.T) {
+	root := t.TempDir()
+	f := filepath.Join(root, "old.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Rename(f, "sub/new.txt", root); err == nil {
+		t.Fatal("expected rejection of name containing a path separator")
+	}
+	if _, err := os.Lstat(f); err != nil {
+		t.Fatal("original file should be untouched after a rejected rename")
+	}
+}
+
+func TestRename_RejectsDotDot(t *testing.T) {
+	root := t.TempDir()
+	f := filepath.Join(root, "old.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Rename(f, "..", root); err == nil {
+		t.Fatal("expected rejection of \"..\" as a new name")
+	}
+}
+
+func TestRename_RejectsEmptyName(t *testing.T) {
+	root := t.TempDir()
+	f := filepath.Join(root, "old.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Rename(f, "", root); err == nil {
+		t.Fatal("expected rejection of an empty new name")
+	}
+}
+
+func TestRename_RefusesToOverwriteExisting(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a.txt")
+	b := filepath.Join(root, "b.txt")
+	if err := os.WriteFile(a, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Rename(a, "b.txt", root); err == nil {
+		t.Fatal("expected rejection when destination already exists")
+	}
+	data, err := os.ReadFile(b)
+	if err != nil || string(data) != "b" {
+		t.Fatal("existing destination file should be untouched")
+	}
+}
+
+func TestRename_OutsideRoot_Blocked(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	target := filepath.Join(outside, "secret.txt")
+	if err := os.WriteFile(target, []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Rename(target, "renamed.txt", root); err == nil {
+		t.Fatal("renaming outside root should be blocked")
+	}
+}
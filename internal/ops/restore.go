@@ -0,0 +1,10 @@
+package ops
+
+// RestoreTrash moves an item previously trashed via Trash back to the
+// location it was trashed from, using whatever original-path metadata the
+// platform's trash implementation recorded when it trashed the item. Not
+// every platform's trash records enough to do this; see the
+// platform-specific restoreTrashedPath for what's actually supported.
+func RestoreTrash(trashedPath string) (originalPath string, err error) {
+	return restoreTrashedPath(trashedPath)
+}
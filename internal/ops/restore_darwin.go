@@ -0,0 +1,13 @@
+//go:build darwin
+
+package ops
+
+import "fmt"
+
+// restoreTrashedPath has no implementation on this platform: trashResolvedPath
+// moves items into ~/.Trash without recording where they came from, so there
+// is nothing to restore from here. Finder's own "Put Back" is not available
+// either, since it relies on metadata this package doesn't write.
+func restoreTrashedPath(trashedPath string) (string, error) {
+	return "", fmt.Errorf("restore is not supported on this platform; the item remains in ~/.Trash")
+}
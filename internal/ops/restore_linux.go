@@ -0,0 +1,66 @@
+//go:build linux
+
+package ops
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// restoreTrashedPath moves a file back out of the FreeDesktop home trash
+// (~/.local/share/Trash/files) to the original location recorded in its
+// matching Trash/info/*.trashinfo file, written by trashResolvedPath when
+// the item was trashed. The .trashinfo file is removed once the restore
+// succeeds.
+func restoreTrashedPath(trashedPath string) (string, error) {
+	filesDir := filepath.Dir(trashedPath)
+	trashDir := filepath.Dir(filesDir)
+	infoPath := filepath.Join(trashDir, "info", filepath.Base(trashedPath)+".trashinfo")
+
+	originalPath, err := readTrashInfoPath(infoPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot read trash metadata for %s: %w", trashedPath, err)
+	}
+
+	if _, err := os.Lstat(originalPath); err == nil {
+		return "", fmt.Errorf("cannot restore %s: %s already exists", trashedPath, originalPath)
+	}
+
+	if err := os.Rename(trashedPath, originalPath); err != nil {
+		return "", fmt.Errorf("cannot restore %s: %w", trashedPath, err)
+	}
+	_ = os.Remove(infoPath)
+	return originalPath, nil
+}
+
+// readTrashInfoPath reads the Path= line out of a .trashinfo file and
+// decodes it back into a filesystem path.
+func readTrashInfoPath(infoPath string) (string, error) {
+	f, err := os.Open(infoPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		rest, ok := strings.CutPrefix(line, "Path=")
+		if !ok {
+			continue
+		}
+		decoded, err := url.PathUnescape(rest)
+		if err != nil {
+			return "", fmt.Errorf("malformed Path= entry: %w", err)
+		}
+		return decoded, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no Path= entry found in %s", infoPath)
+}
@@ -0,0 +1,68 @@
+//go:build linux
+
+package ops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestoreTrash_MovesFileBackToOriginalPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	root := t.TempDir()
+	f := filepath.Join(root, "file.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	trashedPath, err := Trash(f, root)
+	if err != nil {
+		t.Fatalf("trash failed: %v", err)
+	}
+
+	originalPath, err := RestoreTrash(trashedPath)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if originalPath != f {
+		t.Fatalf("expected restored path %s, got %s", f, originalPath)
+	}
+	if _, err := os.Lstat(f); err != nil {
+		t.Fatalf("expected file to be restored to its original path, got %v", err)
+	}
+	if _, err := os.Lstat(trashedPath); !os.IsNotExist(err) {
+		t.Fatal("file should no longer exist in trash")
+	}
+
+	infoPath := filepath.Join(home, ".local", "share", "Trash", "info", "file.txt.trashinfo")
+	if _, err := os.Lstat(infoPath); !os.IsNotExist(err) {
+		t.Fatal(".trashinfo file should have been removed")
+	}
+}
+
+func TestRestoreTrash_FailsWhenOriginalPathOccupied(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	root := t.TempDir()
+	f := filepath.Join(root, "file.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	trashedPath, err := Trash(f, root)
+	if err != nil {
+		t.Fatalf("trash failed: %v", err)
+	}
+
+	if err := os.WriteFile(f, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := RestoreTrash(trashedPath); err == nil {
+		t.Fatal("expected restore to fail when the original path is occupied")
+	}
+}
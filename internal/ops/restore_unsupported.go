@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package ops
+
+import "fmt"
+
+// restoreTrashedPath has no implementation on this platform; trashing itself
+// is already rejected by trashResolvedPath, so this is only reachable if a
+// trashed path from another platform's trash directory is passed in.
+func restoreTrashedPath(trashedPath string) (string, error) {
+	return "", fmt.Errorf("restore is not supported on this platform")
+}
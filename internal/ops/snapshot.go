@@ -0,0 +1,15 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+)
+
+// WriteSnapshot writes content (expected to be a plain-text rendering of the
+// current view, with any ANSI styling already stripped) to path.
+func WriteSnapshot(content, path string) error {
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("cannot write snapshot: %w", err)
+	}
+	return nil
+}
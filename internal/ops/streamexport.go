@@ -0,0 +1,288 @@
+package ops
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/scanner"
+)
+
+// TreeSink receives each top-level subtree as soon as its scan finishes,
+// already sized bottom-up (model.DirNode.UpdateSizeRecursiveContext has been
+// applied), so a headless export can write ncdu JSON nodes out as the scan
+// progresses instead of holding the whole tree in memory twice — once built,
+// once serialized. ScanAndExportStreaming always uses ncduChildSink, which
+// writes straight to the export's scratch file; it takes no other sink.
+type TreeSink interface {
+	// OnDirectoryComplete is called once per finished top-level directory.
+	OnDirectoryComplete(dir *model.DirNode) error
+	// OnFile is called once per top-level file (scanning a single file
+	// needs no recursion, so there's nothing to wait on before emitting it).
+	OnFile(f *model.FileNode) error
+}
+
+// ncduChildSink is the TreeSink ScanAndExportStreaming uses: it
+// writes each completed node straight to ew, comma-separating successive
+// entries the way a JSON array requires.
+type ncduChildSink struct {
+	ew    *errWriter
+	first bool
+}
+
+func (s *ncduChildSink) writeSeparator() {
+	if !s.first {
+		s.ew.WriteString(",\n")
+	}
+	s.first = false
+}
+
+func (s *ncduChildSink) OnDirectoryComplete(dir *model.DirNode) error {
+	s.writeSeparator()
+	writeDir(s.ew, dir, true)
+	return s.ew.err
+}
+
+func (s *ncduChildSink) OnFile(f *model.FileNode) error {
+	s.writeSeparator()
+	writeFileEntry(s.ew, f)
+	return s.ew.err
+}
+
+// ScanAndExportStreaming scans rootPath one top-level entry at a time,
+// writing each finished subtree straight to the ncdu JSON export and
+// freeing it from memory before moving on to the next, instead of
+// ExportJSON's scan-the-whole-tree-then-export approach. Peak memory is
+// bounded by the largest single top-level subtree rather than the whole
+// scan, which matters once even the finished tree plus its JSON
+// serialization buffer no longer fit comfortably in memory.
+//
+// Each top-level directory is scanned with its own call to sc.Scan, so the
+// hardlink dedup map it builds is not shared across top-level siblings the
+// way a single whole-tree scan would share it: a file hardlinked across two
+// different top-level directories is counted in both instead of once.
+//
+// The returned root has its top-level children attached with their sizes
+// set, but each directory child's own children have already been freed via
+// model.DirNode.FreeChildren, matching what a caller gets back from
+// ExportJSONLowMemory.
+func ScanAndExportStreaming(ctx context.Context, sc scanner.Scanner, rootPath string, opts scanner.ScanOptions, progress chan<- scanner.Progress, exportPath, version string) (root *model.DirNode, retErr error) {
+	absPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &os.PathError{Op: "scan", Path: absPath, Err: os.ErrInvalid}
+	}
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		absPath = resolved
+	}
+
+	root = &model.DirNode{
+		FileNode: model.FileNode{
+			Name:  absPath,
+			Mtime: info.ModTime(),
+		},
+	}
+
+	entries, err := os.ReadDir(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", absPath, err)
+	}
+
+	excludeSet := make(map[string]struct{}, len(opts.ExcludePatterns))
+	for _, p := range opts.ExcludePatterns {
+		excludeSet[p] = struct{}{}
+	}
+
+	// Children are written to a scratch file as they are scanned, so the
+	// final export is assembled by copying this file's bytes in verbatim
+	// between the root entry (whose aggregate size is only known once every
+	// child has been scanned) and the closing brackets.
+	childrenTmp, err := os.CreateTemp(filepath.Dir(exportPath), ".godu-export-children-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create export scratch file: %w", err)
+	}
+	childrenPath := childrenTmp.Name()
+	TrackTempFile(childrenPath)
+	defer func() {
+		UntrackTempFile(childrenPath)
+		childrenTmp.Close()
+		os.Remove(childrenPath)
+	}()
+
+	bw := bufio.NewWriterSize(childrenTmp, 64*1024)
+	ew := &errWriter{w: bw}
+	sink := &ncduChildSink{ew: ew, first: true}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return root, err
+		}
+
+		name := entry.Name()
+		if _, excluded := excludeSet[name]; excluded {
+			continue
+		}
+		if isGoduTempExportName(name) {
+			continue
+		}
+		hideHidden := !opts.ShowHidden
+		if hideHidden && len(name) > 0 && name[0] == '.' {
+			continue
+		}
+
+		childPath := filepath.Join(absPath, name)
+		childInfo, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		var child model.TreeNode
+		if childInfo.IsDir() {
+			childRoot, err := sc.Scan(ctx, childPath, opts, progress)
+			if err != nil && !errors.Is(err, scanner.ErrScanTimedOut) {
+				return root, err
+			}
+			childRoot.Name = name
+			childRoot.Parent = root
+			if err := sink.OnDirectoryComplete(childRoot); err != nil {
+				return root, err
+			}
+			child = childRoot
+		} else {
+			fileNode := &model.FileNode{
+				Name:   name,
+				Size:   childInfo.Size(),
+				Usage:  scanner.DiskUsage(childInfo),
+				Mtime:  childInfo.ModTime(),
+				Mode:   childInfo.Mode(),
+				Parent: root,
+			}
+			if err := sink.OnFile(fileNode); err != nil {
+				return root, err
+			}
+			child = fileNode
+		}
+		root.AddChild(child)
+	}
+
+	if ew.err != nil {
+		return root, ew.err
+	}
+	if err := bw.Flush(); err != nil {
+		return root, err
+	}
+	if _, err := childrenTmp.Seek(0, 0); err != nil {
+		return root, err
+	}
+
+	// A plain UpdateSize (not the recursive variant) is correct here: every
+	// top-level child already has its final Size/Usage/ItemCount set by the
+	// Scan call that produced it, and the recursive variant would recompute
+	// (and zero out) those totals from Children, which writeDir has already
+	// freed.
+	root.UpdateSize()
+
+	if err := writeStreamedExport(root, childrenTmp, exportPath, version); err != nil {
+		return root, err
+	}
+	return root, nil
+}
+
+// writeStreamedExport assembles the final ncdu JSON document: header, root
+// entry, then the already-written children copied in verbatim, then the
+// closing brackets, written atomically the same way ExportJSON is.
+func writeStreamedExport(root *model.DirNode, children *os.File, exportPath, version string) (retErr error) {
+	dir := filepath.Dir(exportPath)
+	tmp, err := os.CreateTemp(dir, ".godu-export-*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create export file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	TrackTempFile(tmpPath)
+	defer func() {
+		UntrackTempFile(tmpPath)
+		if retErr != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	bw := bufio.NewWriterSize(tmp, 64*1024)
+	ew := &errWriter{w: bw}
+
+	ew.WriteString("[1, 0, ")
+	if version == "" {
+		version = "dev"
+	}
+	headerJSON, err := json.Marshal(ncduHeader{Progname: "godu", Progver: version, Timestamp: time.Now().Unix(), SchemaVersion: CurrentSchemaVersion})
+	if err != nil {
+		return err
+	}
+	_, _ = ew.Write(headerJSON)
+	ew.WriteString(",\n[")
+
+	rootEntry := ncduEntry{
+		Name:  root.Name,
+		Asize: root.GetSize(),
+		Dsize: root.GetUsage(),
+	}
+	rootJSON, err := json.Marshal(rootEntry)
+	if err != nil {
+		return err
+	}
+	_, _ = ew.Write(rootJSON)
+
+	if len(root.ReadChildren()) > 0 {
+		ew.WriteString(",\n")
+	}
+	if ew.err == nil {
+		if _, err := bw.ReadFrom(children); err != nil {
+			ew.err = err
+		}
+	}
+
+	ew.WriteString("\n]\n]\n")
+	if ew.err != nil {
+		return ew.err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, exportPath); err != nil {
+		if runtime.GOOS != "windows" {
+			return err
+		}
+		if rmErr := os.Remove(exportPath); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+			return fmt.Errorf("cannot replace export file %s: %w", exportPath, err)
+		}
+		if err := os.Rename(tmpPath, exportPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isGoduTempExportName reports whether name matches the temp file pattern
+// ExportJSON and ScanAndExportStreaming write to before atomically renaming
+// it into place, mirroring scanner.isGoduTempExport so a streaming export
+// scanning its own output directory never trips over its own scratch files.
+func isGoduTempExportName(name string) bool {
+	return strings.HasPrefix(name, ".godu-export-") && strings.HasSuffix(name, ".tmp")
+}
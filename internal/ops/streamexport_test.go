@@ -0,0 +1,241 @@
+package ops
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/scanner"
+)
+
+func TestScanAndExportStreaming_RoundTripsMatchingRegularExport(t *testing.T) {
+	dir := t.TempDir()
+	mustWriteFile(t, filepath.Join(dir, "top.txt"), "hello")
+	sub := filepath.Join(dir, "subdir")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	mustWriteFile(t, filepath.Join(sub, "nested.txt"), "world!!")
+
+	s := scanner.NewParallelScanner()
+	opts := scanner.DefaultOptions()
+	outPath := filepath.Join(t.TempDir(), "streamed.json")
+
+	streamedRoot, err := ScanAndExportStreaming(context.Background(), s, dir, opts, nil, outPath, "test-version")
+	if err != nil {
+		t.Fatalf("ScanAndExportStreaming: %v", err)
+	}
+
+	imported, err := ImportJSON(outPath)
+	if err != nil {
+		t.Fatalf("ImportJSON of streamed export: %v", err)
+	}
+
+	directRoot, err := s.Scan(context.Background(), dir, opts, nil)
+	if err != nil {
+		t.Fatalf("direct Scan: %v", err)
+	}
+	if directRoot.GetSize() != streamedRoot.GetSize() {
+		t.Fatalf("streamed root size %d != direct scan size %d", streamedRoot.GetSize(), directRoot.GetSize())
+	}
+	if directRoot.GetSize() != imported.GetSize() {
+		t.Fatalf("imported root size %d != direct scan size %d", imported.GetSize(), directRoot.GetSize())
+	}
+	if imported.ItemCount != directRoot.ItemCount {
+		t.Fatalf("imported item count %d != direct scan item count %d", imported.ItemCount, directRoot.ItemCount)
+	}
+
+	found := false
+	for _, c := range imported.GetChildren() {
+		if c.GetName() == "subdir" && c.IsDir() {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected imported export to contain the subdir entry")
+	}
+}
+
+func TestScanAndExportStreaming_FreesChildrenOfScannedSubdirs(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "subdir")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	mustWriteFile(t, filepath.Join(sub, "a.txt"), "data")
+
+	s := scanner.NewParallelScanner()
+	opts := scanner.DefaultOptions()
+	outPath := filepath.Join(t.TempDir(), "streamed.json")
+
+	root, err := ScanAndExportStreaming(context.Background(), s, dir, opts, nil, outPath, "test-version")
+	if err != nil {
+		t.Fatalf("ScanAndExportStreaming: %v", err)
+	}
+
+	for _, c := range root.GetChildren() {
+		dirNode, ok := c.(*model.DirNode)
+		if !ok || dirNode.GetName() != "subdir" {
+			continue
+		}
+		if len(dirNode.GetChildren()) != 0 {
+			t.Fatalf("expected subdir's children to be freed after streaming export, got %d", len(dirNode.GetChildren()))
+		}
+		if dirNode.GetSize() == 0 {
+			t.Fatal("expected subdir's cached size to survive FreeChildren")
+		}
+	}
+}
+
+// recordingSink is a TreeSink that just remembers what it was handed; the
+// test below calls it directly to check that it satisfies the interface.
+type recordingSink struct {
+	dirs  []string
+	files []string
+}
+
+func (r *recordingSink) OnDirectoryComplete(dir *model.DirNode) error {
+	r.dirs = append(r.dirs, dir.GetName())
+	return nil
+}
+
+func (r *recordingSink) OnFile(f *model.FileNode) error {
+	r.files = append(r.files, f.GetName())
+	return nil
+}
+
+func TestRecordingSink_ImplementsTreeSink(t *testing.T) {
+	var sink TreeSink = &recordingSink{}
+	if err := sink.OnFile(&model.FileNode{Name: "a.txt"}); err != nil {
+		t.Fatalf("OnFile: %v", err)
+	}
+	if err := sink.OnDirectoryComplete(&model.DirNode{FileNode: model.FileNode{Name: "sub"}}); err != nil {
+		t.Fatalf("OnDirectoryComplete: %v", err)
+	}
+
+	rs := sink.(*recordingSink)
+	if len(rs.files) != 1 || rs.files[0] != "a.txt" {
+		t.Fatalf("expected recorded file a.txt, got %v", rs.files)
+	}
+	if len(rs.dirs) != 1 || rs.dirs[0] != "sub" {
+		t.Fatalf("expected recorded directory sub, got %v", rs.dirs)
+	}
+}
+
+func TestNcduChildSink_WritesCommaSeparatedEntries(t *testing.T) {
+	tmp := t.TempDir()
+	f, err := os.Create(filepath.Join(tmp, "scratch"))
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+	ew := &errWriter{w: bw}
+	sink := &ncduChildSink{ew: ew, first: true}
+
+	if err := sink.OnFile(&model.FileNode{Name: "a.txt", Size: 1}); err != nil {
+		t.Fatalf("OnFile: %v", err)
+	}
+	if err := sink.OnDirectoryComplete(&model.DirNode{FileNode: model.FileNode{Name: "sub"}}); err != nil {
+		t.Fatalf("OnDirectoryComplete: %v", err)
+	}
+	if err := bw.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmp, "scratch"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	got := string(data)
+	if !strings.Contains(got, `"a.txt"`) || !strings.Contains(got, `"sub"`) {
+		t.Fatalf("expected both entries in output, got %q", got)
+	}
+	if !strings.Contains(got, ",\n") {
+		t.Fatalf("expected a comma separator between entries, got %q", got)
+	}
+}
+
+func TestScanAndExportStreaming_MatchesBuildThenExportOutput(t *testing.T) {
+	dir := t.TempDir()
+	mustWriteFile(t, filepath.Join(dir, "top.txt"), "hello")
+	sub := filepath.Join(dir, "subdir")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	mustWriteFile(t, filepath.Join(sub, "nested.txt"), "world!!")
+
+	s := scanner.NewParallelScanner()
+	opts := scanner.DefaultOptions()
+
+	streamedPath := filepath.Join(t.TempDir(), "streamed.json")
+	if _, err := ScanAndExportStreaming(context.Background(), s, dir, opts, nil, streamedPath, "test-version"); err != nil {
+		t.Fatalf("ScanAndExportStreaming: %v", err)
+	}
+
+	builtRoot, err := s.Scan(context.Background(), dir, opts, nil)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	builtPath := filepath.Join(t.TempDir(), "built.json")
+	if err := ExportJSON(builtRoot, builtPath, "test-version", false); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+
+	streamedImport, err := ImportJSON(streamedPath)
+	if err != nil {
+		t.Fatalf("ImportJSON(streamed): %v", err)
+	}
+	builtImport, err := ImportJSON(builtPath)
+	if err != nil {
+		t.Fatalf("ImportJSON(built): %v", err)
+	}
+
+	// Timestamps necessarily differ between the two exports, so compare the
+	// trees structurally rather than the raw JSON bytes.
+	assertSameTree(t, streamedImport, builtImport)
+}
+
+func assertSameTree(t *testing.T, a, b *model.DirNode) {
+	t.Helper()
+	if a.GetName() != b.GetName() {
+		t.Fatalf("name mismatch: %q != %q", a.GetName(), b.GetName())
+	}
+	if a.GetSize() != b.GetSize() || a.GetUsage() != b.GetUsage() {
+		t.Fatalf("size mismatch for %q: (%d,%d) != (%d,%d)", a.GetName(), a.GetSize(), a.GetUsage(), b.GetSize(), b.GetUsage())
+	}
+	aChildren, bChildren := a.GetChildren(), b.GetChildren()
+	if len(aChildren) != len(bChildren) {
+		t.Fatalf("child count mismatch for %q: %d != %d", a.GetName(), len(aChildren), len(bChildren))
+	}
+	byName := make(map[string]model.TreeNode, len(bChildren))
+	for _, c := range bChildren {
+		byName[c.GetName()] = c
+	}
+	for _, ac := range aChildren {
+		bc, ok := byName[ac.GetName()]
+		if !ok {
+			t.Fatalf("missing child %q under %q", ac.GetName(), a.GetName())
+		}
+		if ac.IsDir() != bc.IsDir() {
+			t.Fatalf("type mismatch for %q", ac.GetName())
+		}
+		if adir, ok := ac.(*model.DirNode); ok {
+			assertSameTree(t, adir, bc.(*model.DirNode))
+		} else if ac.GetSize() != bc.GetSize() || ac.GetUsage() != bc.GetUsage() {
+			t.Fatalf("size mismatch for file %q: (%d,%d) != (%d,%d)", ac.GetName(), ac.GetSize(), ac.GetUsage(), bc.GetSize(), bc.GetUsage())
+		}
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile(%s): %v", path, err)
+	}
+}
@@ -0,0 +1,60 @@
+package ops
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// activeTempFiles tracks temp files currently being written by this process
+// (e.g. ExportJSON's in-progress temp file, or a caller's own scratch file)
+// so InstallSignalCleanup can remove them if the process is interrupted
+// before the normal deferred cleanup gets a chance to run.
+var activeTempFiles sync.Map
+
+// TrackTempFile records path as in-progress so a later interrupt removes it
+// instead of leaking it. Callers must pair this with UntrackTempFile once the
+// file has been renamed into place or removed through their own cleanup path.
+func TrackTempFile(path string) {
+	activeTempFiles.Store(path, struct{}{})
+}
+
+// UntrackTempFile stops tracking path.
+func UntrackTempFile(path string) {
+	activeTempFiles.Delete(path)
+}
+
+// InstallSignalCleanup registers a SIGINT/SIGTERM handler that removes any
+// temp files currently tracked via TrackTempFile before the process exits.
+// Call this once from main(); the returned stop function undoes the
+// registration once normal shutdown no longer needs the safety net.
+func InstallSignalCleanup() (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigCh:
+			cleanupTrackedTempFiles()
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+// cleanupTrackedTempFiles removes every temp file currently tracked via
+// TrackTempFile. Split out from InstallSignalCleanup so it can be exercised
+// directly without delivering a real signal.
+func cleanupTrackedTempFiles() {
+	activeTempFiles.Range(func(key, _ any) bool {
+		os.Remove(key.(string))
+		return true
+	})
+}
@@ -0,0 +1,48 @@
+package ops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanupTrackedTempFiles_RemovesTrackedOnly(t *testing.T) {
+	dir := t.TempDir()
+	tracked := filepath.Join(dir, "tracked.tmp")
+	untracked := filepath.Join(dir, "untracked.tmp")
+
+	for _, p := range []string{tracked, untracked} {
+		if err := os.WriteFile(p, []byte("x"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+
+	TrackTempFile(tracked)
+	defer UntrackTempFile(tracked)
+
+	cleanupTrackedTempFiles()
+
+	if _, err := os.Stat(tracked); !os.IsNotExist(err) {
+		t.Errorf("expected tracked temp file to be removed, stat err=%v", err)
+	}
+	if _, err := os.Stat(untracked); err != nil {
+		t.Errorf("expected untracked file to survive, stat err=%v", err)
+	}
+}
+
+func TestUntrackTempFile_PreventsCleanup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.tmp")
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	TrackTempFile(path)
+	UntrackTempFile(path)
+
+	cleanupTrackedTempFiles()
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected untracked file to survive cleanup, stat err=%v", err)
+	}
+}
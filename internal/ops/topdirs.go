@@ -0,0 +1,67 @@
+package ops
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+// DirResult is one entry returned by CollectDirectories/TopDirectories: a
+// directory's full path together with the size metric it was ranked by.
+type DirResult struct {
+	Path string
+	Size int64
+}
+
+// CollectDirectories walks the tree rooted at root and returns the n largest
+// directories as measured by sizeOf, sorted largest first, along with the
+// total number of directories seen (root itself included). It ranks
+// directories with a bounded min-heap of capacity n, the same approach
+// CollectFiles uses for files.
+func CollectDirectories(root *model.DirNode, n int, sizeOf func(model.TreeNode) int64) (results []DirResult, total int) {
+	if root == nil || n <= 0 {
+		return nil, 0
+	}
+
+	h := make(dirHeap, 0, n)
+	model.Walk(root, func(node model.TreeNode) {
+		if !node.IsDir() {
+			return
+		}
+		total++
+		entry := DirResult{Path: node.Path(), Size: sizeOf(node)}
+		if h.Len() < n {
+			heap.Push(&h, entry)
+		} else if entry.Size > h[0].Size {
+			heap.Pop(&h)
+			heap.Push(&h, entry)
+		}
+	})
+
+	results = make([]DirResult, len(h))
+	copy(results, h)
+	sort.Slice(results, func(i, j int) bool { return results[i].Size > results[j].Size })
+	return results, total
+}
+
+// TopDirectories returns the n largest directories under root, ranked by the
+// given size mode (disk usage, apparent size, or the max of the two).
+func TopDirectories(root *model.DirNode, n int, mode model.SizeMode) (results []DirResult, total int) {
+	return CollectDirectories(root, n, mode.Size)
+}
+
+// dirHeap is a min-heap of DirResult ordered by Size, mirroring fileHeap.
+type dirHeap []DirResult
+
+func (h dirHeap) Len() int           { return len(h) }
+func (h dirHeap) Less(i, j int) bool { return h[i].Size < h[j].Size }
+func (h dirHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *dirHeap) Push(x any)        { *h = append(*h, x.(DirResult)) }
+func (h *dirHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,45 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestCollectDirectories_ReturnsLargestDirsSortedDescending(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	big := &model.DirNode{FileNode: model.FileNode{Name: "big", Parent: root}}
+	small := &model.DirNode{FileNode: model.FileNode{Name: "small", Parent: root}}
+	root.AddChild(big)
+	root.AddChild(small)
+
+	big.AddChild(&model.FileNode{Name: "a.bin", Size: 100, Parent: big})
+	small.AddChild(&model.FileNode{Name: "b.bin", Size: 10, Parent: small})
+	root.UpdateSizeRecursive()
+
+	results, total := CollectDirectories(root, 2, func(n model.TreeNode) int64 { return n.GetSize() })
+	if total != 3 {
+		t.Fatalf("expected total 3 directories seen (root, big, small), got %d", total)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results for n=2, got %d", len(results))
+	}
+	if results[0].Path != root.Path() {
+		t.Fatalf("expected root to rank first (contains everything), got %+v", results[0])
+	}
+	if results[1].Path != big.Path() {
+		t.Fatalf("expected big to rank second, got %+v", results[1])
+	}
+}
+
+func TestTopDirectories_NFewerThanAvailable(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+	root.UpdateSizeRecursive()
+
+	results, total := TopDirectories(root, 5, model.SizeModeApparent)
+	if total != 2 || len(results) != 2 {
+		t.Fatalf("expected 2 results for two directories, got total=%d len=%d", total, len(results))
+	}
+}
@@ -0,0 +1,69 @@
+package ops
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+// FileResult is one entry returned by CollectFiles/TopFiles: a file's full
+// path together with the size metric it was ranked by.
+type FileResult struct {
+	Path string
+	Size int64
+}
+
+// CollectFiles walks the tree rooted at root and returns the n largest files
+// as measured by sizeOf, sorted largest first, along with the total number
+// of files seen. It ranks files with a bounded min-heap of capacity n, so
+// memory stays O(n) and each file costs O(log n) instead of sorting the
+// whole tree just to keep the top few results.
+func CollectFiles(root *model.DirNode, n int, sizeOf func(model.TreeNode) int64) (results []FileResult, total int) {
+	if root == nil || n <= 0 {
+		return nil, 0
+	}
+
+	h := make(fileHeap, 0, n)
+	model.Walk(root, func(node model.TreeNode) {
+		if node.IsDir() {
+			return
+		}
+		total++
+		entry := FileResult{Path: node.Path(), Size: sizeOf(node)}
+		if h.Len() < n {
+			heap.Push(&h, entry)
+		} else if entry.Size > h[0].Size {
+			heap.Pop(&h)
+			heap.Push(&h, entry)
+		}
+	})
+
+	results = make([]FileResult, len(h))
+	copy(results, h)
+	sort.Slice(results, func(i, j int) bool { return results[i].Size > results[j].Size })
+	return results, total
+}
+
+// TopFiles returns the n largest files under root, ranked by the given size
+// mode (disk usage, apparent size, or the max of the two).
+func TopFiles(root *model.DirNode, n int, mode model.SizeMode) (results []FileResult, total int) {
+	return CollectFiles(root, n, mode.Size)
+}
+
+// fileHeap is a min-heap of FileResult ordered by Size, so the smallest of
+// the currently-kept top-n entries is always at the root and can be evicted
+// in O(log n) when a larger file is found.
+type fileHeap []FileResult
+
+func (h fileHeap) Len() int           { return len(h) }
+func (h fileHeap) Less(i, j int) bool { return h[i].Size < h[j].Size }
+func (h fileHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *fileHeap) Push(x any)        { *h = append(*h, x.(FileResult)) }
+func (h *fileHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
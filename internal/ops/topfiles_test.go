@@ -0,0 +1,70 @@
+package ops
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+)
+
+func TestCollectFiles_ReturnsLargestFilesSortedDescending(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+
+	small := &model.FileNode{Name: "small.txt", Size: 10, Parent: root}
+	medium := &model.FileNode{Name: "medium.txt", Size: 50, Parent: sub}
+	big := &model.FileNode{Name: "big.txt", Size: 100, Parent: root}
+	root.AddChild(small)
+	root.AddChild(big)
+	sub.AddChild(medium)
+
+	results, total := CollectFiles(root, 2, func(n model.TreeNode) int64 { return n.GetSize() })
+	if total != 3 {
+		t.Fatalf("expected total 3 files seen, got %d", total)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results for n=2, got %d", len(results))
+	}
+	if results[0].Path != big.Path() || results[0].Size != 100 {
+		t.Fatalf("expected largest result to be big.txt, got %+v", results[0])
+	}
+	if results[1].Path != medium.Path() || results[1].Size != 50 {
+		t.Fatalf("expected second result to be medium.txt, got %+v", results[1])
+	}
+}
+
+func TestCollectFiles_NFewerThanAvailable(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 5, Parent: root})
+
+	results, total := CollectFiles(root, 5, func(n model.TreeNode) int64 { return n.GetSize() })
+	if total != 1 || len(results) != 1 {
+		t.Fatalf("expected 1 result for a single file, got total=%d len=%d", total, len(results))
+	}
+}
+
+func TestCollectFiles_ZeroNReturnsNothing(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 5, Parent: root})
+
+	results, total := CollectFiles(root, 0, func(n model.TreeNode) int64 { return n.GetSize() })
+	if results != nil || total != 0 {
+		t.Fatalf("expected no results for n=0, got results=%v total=%d", results, total)
+	}
+}
+
+func TestTopFiles_RanksByDiskUsageByDefault(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/scan/root"}}
+	root.AddChild(&model.FileNode{Name: "sparse.bin", Size: 1000, Usage: 10, Parent: root})
+	root.AddChild(&model.FileNode{Name: "dense.bin", Size: 20, Usage: 4096, Parent: root})
+
+	results, _ := TopFiles(root, 1, model.SizeModeDisk)
+	if len(results) != 1 || results[0].Path != root.Path()+"/dense.bin" {
+		t.Fatalf("expected dense.bin to rank first by disk usage, got %+v", results)
+	}
+
+	results, _ = TopFiles(root, 1, model.SizeModeApparent)
+	if len(results) != 1 || results[0].Path != root.Path()+"/sparse.bin" {
+		t.Fatalf("expected sparse.bin to rank first by apparent size, got %+v", results)
+	}
+}
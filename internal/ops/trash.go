@@ -0,0 +1,21 @@
+package ops
+
+import "fmt"
+
+// Trash moves a file or directory at the given path to the platform
+// trash/recycle bin instead of permanently removing it. It applies the same
+// root-containment safety checks as Delete; only the final disposal of the
+// resolved path differs, via the platform-specific trashResolvedPath.
+// On success it returns trashedPath, the item's new location inside the
+// trash, so a caller can later pass it to RestoreTrash.
+func Trash(path string, rootPath string) (trashedPath string, err error) {
+	realParent, baseName, realPath, err := resolveForDelete(path, rootPath)
+	if err != nil {
+		return "", err
+	}
+	trashedPath, err = trashResolvedPath(realParent, baseName)
+	if err != nil {
+		return "", fmt.Errorf("cannot trash %s: %w", realPath, err)
+	}
+	return trashedPath, nil
+}
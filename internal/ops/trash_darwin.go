@@ -0,0 +1,52 @@
+//go:build darwin
+
+package ops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// trashResolvedPath moves parentPath/baseName into ~/.Trash. Finder itself
+// uses a more elaborate scheme (NSFileManager, per-volume .Trashes
+// directories, restore metadata), but a plain move into the legacy
+// per-user ~/.Trash shows up in Finder's Trash and needs no Cocoa bindings.
+// No restore metadata is written, so Finder's "Put Back" is not available
+// for items moved this way.
+func trashResolvedPath(parentPath, baseName string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	trashDir := filepath.Join(home, ".Trash")
+	if err := os.MkdirAll(trashDir, 0700); err != nil {
+		return "", err
+	}
+
+	srcPath := filepath.Join(parentPath, baseName)
+	destPath := uniqueDarwinTrashPath(trashDir, baseName)
+	if err := os.Rename(srcPath, destPath); err != nil {
+		return "", err
+	}
+	return destPath, nil
+}
+
+// uniqueDarwinTrashPath picks a destination under trashDir that doesn't
+// already exist, appending " N" before any extension on collision, matching
+// the naming Finder itself uses for trashed duplicates.
+func uniqueDarwinTrashPath(trashDir, baseName string) string {
+	destPath := filepath.Join(trashDir, baseName)
+	ext := filepath.Ext(baseName)
+	stem := strings.TrimSuffix(baseName, ext)
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(destPath); os.IsNotExist(err) {
+			return destPath
+		}
+		destPath = filepath.Join(trashDir, fmt.Sprintf("%s %d%s", stem, i, ext))
+		if i > 9999 {
+			return destPath
+		}
+	}
+}
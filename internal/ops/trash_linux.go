@@ -0,0 +1,83 @@
+//go:build linux
+
+package ops
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// trashResolvedPath moves parentPath/baseName into the FreeDesktop "home
+// trash" (~/.local/share/Trash/files), writing a matching
+// Trash/info/*.trashinfo file recording the original location and deletion
+// time so a file manager can restore it. Per-mount "$topdir/.Trash-$uid"
+// cans from the spec are not implemented: trashing something outside
+// $HOME's filesystem will fail with a plain cross-device rename error
+// instead of silently falling back to one.
+func trashResolvedPath(parentPath, baseName string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+
+	trashDir := filepath.Join(home, ".local", "share", "Trash")
+	filesDir := filepath.Join(trashDir, "files")
+	infoDir := filepath.Join(trashDir, "info")
+	if err := os.MkdirAll(filesDir, 0700); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(infoDir, 0700); err != nil {
+		return "", err
+	}
+
+	srcPath := filepath.Join(parentPath, baseName)
+	destPath, infoPath, err := uniqueTrashPaths(filesDir, infoDir, baseName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(srcPath, destPath); err != nil {
+		return "", err
+	}
+
+	info := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+		encodeTrashOriginalPath(srcPath), time.Now().Format("2006-01-02T15:04:05"))
+	if err := os.WriteFile(infoPath, []byte(info), 0600); err != nil {
+		return "", err
+	}
+	return destPath, nil
+}
+
+// uniqueTrashPaths picks a files/<name> + info/<name>.trashinfo pair that
+// doesn't already exist, appending ".N" before any extension on collision.
+func uniqueTrashPaths(filesDir, infoDir, baseName string) (destPath, infoPath string, err error) {
+	name := baseName
+	for i := 0; ; i++ {
+		if i > 0 {
+			ext := filepath.Ext(baseName)
+			stem := strings.TrimSuffix(baseName, ext)
+			name = fmt.Sprintf("%s.%d%s", stem, i, ext)
+		}
+		destPath = filepath.Join(filesDir, name)
+		infoPath = filepath.Join(infoDir, name+".trashinfo")
+		_, destErr := os.Lstat(destPath)
+		_, infoErr := os.Lstat(infoPath)
+		if os.IsNotExist(destErr) && os.IsNotExist(infoErr) {
+			return destPath, infoPath, nil
+		}
+		if i > 9999 {
+			return "", "", fmt.Errorf("cannot find unique trash name for %s", baseName)
+		}
+	}
+}
+
+// encodeTrashOriginalPath percent-encodes path the way the spec requires
+// for the Path= field, leaving path separators unescaped.
+func encodeTrashOriginalPath(path string) string {
+	u := &url.URL{Path: path}
+	return u.EscapedPath()
+}
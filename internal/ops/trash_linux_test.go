@@ -0,0 +1,121 @@
+//go:build linux
+
+package ops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTrash_MovesFileToTrashFilesDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	root := t.TempDir()
+	f := filepath.Join(root, "file.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	trashedPath, err := Trash(f, root)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, err := os.Lstat(f); !os.IsNotExist(err) {
+		t.Fatal("file should no longer exist at its original path")
+	}
+
+	wantTrashedPath := filepath.Join(home, ".local", "share", "Trash", "files", "file.txt")
+	if trashedPath != wantTrashedPath {
+		t.Fatalf("expected trashed path %s, got %s", wantTrashedPath, trashedPath)
+	}
+	if _, err := os.Lstat(trashedPath); err != nil {
+		t.Fatalf("expected file to be present in trash, got %v", err)
+	}
+
+	infoPath := filepath.Join(home, ".local", "share", "Trash", "info", "file.txt.trashinfo")
+	data, err := os.ReadFile(infoPath)
+	if err != nil {
+		t.Fatalf("expected .trashinfo to be written, got %v", err)
+	}
+	if !strings.Contains(string(data), "[Trash Info]") || !strings.Contains(string(data), f) {
+		t.Fatalf("unexpected .trashinfo contents: %s", data)
+	}
+}
+
+func TestTrash_Directory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "subdir")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Trash(dir, root); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, err := os.Lstat(dir); !os.IsNotExist(err) {
+		t.Fatal("directory should no longer exist at its original path")
+	}
+
+	trashedDir := filepath.Join(home, ".local", "share", "Trash", "files", "subdir")
+	if _, err := os.Lstat(filepath.Join(trashedDir, "a.txt")); err != nil {
+		t.Fatalf("expected nested file to be present in trash, got %v", err)
+	}
+}
+
+func TestTrash_CollidingNameGetsUniqueSuffix(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	root := t.TempDir()
+	f1 := filepath.Join(root, "dup.txt")
+	if err := os.WriteFile(f1, []byte("one"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Trash(f1, root); err != nil {
+		t.Fatalf("first trash failed: %v", err)
+	}
+
+	f2 := filepath.Join(root, "dup.txt")
+	if err := os.WriteFile(f2, []byte("two"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Trash(f2, root); err != nil {
+		t.Fatalf("second trash failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(home, ".local", "share", "Trash", "files"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 distinct trashed entries, got %d: %v", len(entries), entries)
+	}
+}
+
+func TestTrash_OutsideRoot_Blocked(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	target := filepath.Join(outside, "secret.txt")
+	if err := os.WriteFile(target, []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Trash(target, root); err == nil {
+		t.Fatal("trashing outside root should be blocked")
+	}
+	if _, err := os.Lstat(target); err != nil {
+		t.Fatal("file outside root should not have been moved")
+	}
+}
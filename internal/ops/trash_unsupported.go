@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package ops
+
+import "fmt"
+
+// trashResolvedPath has no implementation on this platform yet: neither the
+// FreeDesktop trash spec (Linux) nor ~/.Trash (macOS) applies, and the
+// Windows Recycle Bin needs a SHFileOperation-style shell call we don't have
+// a dependency for. --trash is rejected with an explicit error here rather
+// than silently falling back to permanent deletion.
+func trashResolvedPath(parentPath, baseName string) (string, error) {
+	return "", fmt.Errorf("trash is not supported on this platform; use permanent delete instead")
+}
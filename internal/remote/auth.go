@@ -39,6 +39,9 @@ func parseSSHTarget(target string) (string, string, error) {
 	if strings.TrimSpace(target) == "" {
 		return "", "", fmt.Errorf("remote target is required")
 	}
+	if !strings.Contains(target, "@") {
+		return resolveSSHAlias(target)
+	}
 	if strings.Count(target, "@") != 1 {
 		return "", "", fmt.Errorf("invalid remote target %q: expected user@host", target)
 	}
@@ -75,6 +78,24 @@ func parseSSHTarget(target string) (string, string, error) {
 	return user, host, nil
 }
 
+// resolveSSHAlias looks up target as a Host alias in ~/.ssh/config,
+// resolving it the way a bare "ssh target" invocation would.
+func resolveSSHAlias(target string) (string, string, error) {
+	entry, ok, err := LookupSSHConfig(target)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok || entry.User == "" {
+		return "", "", fmt.Errorf("invalid remote target %q: expected user@host, or a Host alias with a User in ~/.ssh/config", target)
+	}
+
+	host := entry.HostName
+	if host == "" {
+		host = target
+	}
+	return entry.User, host, nil
+}
+
 func looksLikeHostPort(host string) bool {
 	if strings.Count(host, ":") != 1 {
 		return false
@@ -96,7 +117,7 @@ func isAllDigits(s string) bool {
 }
 
 func hostKeyCallback(host string, port int, batchMode bool) (ssh.HostKeyCallback, error) {
-	knownHostsPath, err := ensureKnownHostsFile()
+	knownHostsPath, err := ensureKnownHostsFile(batchMode)
 	if err != nil {
 		return nil, err
 	}
@@ -177,18 +198,33 @@ func hostKeyCallback(host string, port int, batchMode bool) (ssh.HostKeyCallback
 	}, nil
 }
 
-func ensureKnownHostsFile() (string, error) {
+// ensureKnownHostsFile returns the path to ~/.ssh/known_hosts. In batch mode
+// it never creates or modifies anything: a missing file is treated as a hard
+// error instead of being created, matching immutable-infra setups where
+// known_hosts is pre-provisioned and read-only.
+func ensureKnownHostsFile(batchMode bool) (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("cannot determine home directory for known_hosts: %w", err)
 	}
 
 	sshDir := filepath.Join(home, ".ssh")
+	path := filepath.Join(sshDir, "known_hosts")
+
+	if batchMode {
+		if _, err := os.Stat(path); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("known_hosts not found at %s; batch mode requires hosts to already be trusted", path)
+			}
+			return "", fmt.Errorf("cannot access known_hosts: %w", err)
+		}
+		return path, nil
+	}
+
 	if err := os.MkdirAll(sshDir, 0o700); err != nil {
 		return "", fmt.Errorf("cannot create ~/.ssh directory: %w", err)
 	}
 
-	path := filepath.Join(sshDir, "known_hosts")
 	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
 		if err := os.WriteFile(path, nil, 0o600); err != nil {
 			return "", fmt.Errorf("cannot create known_hosts: %w", err)
@@ -451,14 +487,14 @@ func promptYesNo(prompt string) (bool, error) {
 	return a == "y" || a == "yes", nil
 }
 
-func buildAuthMethods(user, host string, batchMode bool) ([]ssh.AuthMethod, error) {
+func buildAuthMethods(user, host string, batchMode bool, identityFile string) ([]ssh.AuthMethod, error) {
 	methods := make([]ssh.AuthMethod, 0, 4)
 
 	if m := agentAuthMethod(); m != nil {
 		methods = append(methods, m)
 	}
 
-	signers := loadDefaultKeySigners()
+	signers := loadKeySigners(identityFile)
 	if len(signers) > 0 {
 		methods = append(methods, ssh.PublicKeys(signers...))
 	}
@@ -495,6 +531,32 @@ func agentAuthMethod() ssh.AuthMethod {
 	})
 }
 
+// loadKeySigners loads identityFile first, if given (the IdentityFile
+// resolved from a ~/.ssh/config Host alias), followed by any of the default
+// key filenames found in ~/.ssh.
+func loadKeySigners(identityFile string) []ssh.Signer {
+	var signers []ssh.Signer
+	if identityFile != "" {
+		if signer, ok := loadKeySigner(identityFile); ok {
+			signers = append(signers, signer)
+		}
+	}
+	return append(signers, loadDefaultKeySigners()...)
+}
+
+func loadKeySigner(path string) (ssh.Signer, bool) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	signer, err := ssh.ParsePrivateKey(pem)
+	if err != nil {
+		return nil, false
+	}
+	return signer, true
+}
+
 func loadDefaultKeySigners() []ssh.Signer {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -504,20 +566,9 @@ func loadDefaultKeySigners() []ssh.Signer {
 	signers := make([]ssh.Signer, 0, len(defaultPrivateKeyFiles))
 	for _, name := range defaultPrivateKeyFiles {
 		path := filepath.Join(home, ".ssh", name)
-		pem, err := os.ReadFile(path)
-		if err != nil {
-			continue
-		}
-
-		signer, err := ssh.ParsePrivateKey(pem)
-		if err != nil {
-			var passphraseErr *ssh.PassphraseMissingError
-			if errors.As(err, &passphraseErr) {
-				continue
-			}
-			continue
+		if signer, ok := loadKeySigner(path); ok {
+			signers = append(signers, signer)
 		}
-		signers = append(signers, signer)
 	}
 
 	return signers
@@ -49,6 +49,29 @@ func TestParseSSHTarget(t *testing.T) {
 	}
 }
 
+func TestDisplayTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{name: "default port omitted", cfg: Config{Target: "alice@example.com", Port: 22}, want: "alice@example.com"},
+		{name: "zero port treated as default", cfg: Config{Target: "alice@example.com"}, want: "alice@example.com"},
+		{name: "custom port shown", cfg: Config{Target: "alice@example.com", Port: 2222}, want: "alice@example.com:2222"},
+		{name: "bracketed ipv6 with custom port", cfg: Config{Target: "alice@[::1]", Port: 2222}, want: "alice@[::1]:2222"},
+		{name: "bracketed ipv6 with default port", cfg: Config{Target: "alice@[::1]", Port: 22}, want: "alice@::1"},
+		{name: "unparseable target falls back to raw string", cfg: Config{Target: "not-a-target", Port: 2222}, want: "not-a-target"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DisplayTarget(tc.cfg); got != tc.want {
+				t.Fatalf("DisplayTarget(%+v) = %q, want %q", tc.cfg, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestCleanRemotePath(t *testing.T) {
 	tests := []struct {
 		in   string
@@ -217,3 +240,58 @@ func TestWriteKnownHostsAtomic_ReplacesExisting(t *testing.T) {
 		t.Fatalf("expected replaced file content %q, got %q", "new", string(data))
 	}
 }
+
+func TestEnsureKnownHostsFile_BatchModeNeverWrites(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if _, err := ensureKnownHostsFile(true); err == nil {
+		t.Fatal("expected error for missing known_hosts in batch mode")
+	}
+	if _, err := os.Stat(filepath.Join(home, ".ssh")); err == nil {
+		t.Fatal("batch mode must not create ~/.ssh")
+	}
+}
+
+func TestEnsureKnownHostsFile_BatchModeUsesExistingFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	sshDir := filepath.Join(home, ".ssh")
+	if err := os.MkdirAll(sshDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(sshDir, "known_hosts")
+	if err := os.WriteFile(want, []byte("existing"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ensureKnownHostsFile(true)
+	if err != nil {
+		t.Fatalf("ensureKnownHostsFile failed: %v", err)
+	}
+	if got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	data, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "existing" {
+		t.Fatalf("batch mode must not modify known_hosts, got %q", string(data))
+	}
+}
+
+func TestEnsureKnownHostsFile_NonBatchCreatesFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path, err := ensureKnownHostsFile(false)
+	if err != nil {
+		t.Fatalf("ensureKnownHostsFile failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected known_hosts to be created: %v", err)
+	}
+}
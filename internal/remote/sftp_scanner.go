@@ -2,12 +2,13 @@ package remote
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	pathpkg "path"
-	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,10 +21,22 @@ import (
 )
 
 const defaultRemotePath = "."
+const defaultSSHPort = 22
+
+// defaultSSHConcurrency is the fallback number of concurrent SFTP operations
+// when Config.Concurrency isn't set. It's deliberately lower than the
+// CPU-based default used for local scans, since SFTP round-trips are
+// latency-bound rather than CPU-bound and too much parallelism just queues
+// up on the remote server.
+const defaultSSHConcurrency = 8
 
 const defaultRemoteBlockSize int64 = 4096
 const maxInt64 = int64(^uint64(0) >> 1)
 
+// defaultSSHRetryDelay is the initial backoff before the first retry of a
+// transient SFTP error; it doubles after each subsequent attempt.
+const defaultSSHRetryDelay = 200 * time.Millisecond
+
 // Config configures a remote SFTP scan.
 type Config struct {
 	Target      string
@@ -31,6 +44,38 @@ type Config struct {
 	BatchMode   bool
 	Timeout     time.Duration
 	ScanTimeout time.Duration
+	KeepAlive   time.Duration
+
+	// Jump is an optional "user@bastion" jump host. When set, dialSFTP first
+	// establishes an SSH connection to the jump host, then tunnels the SSH
+	// connection to Target through it instead of dialing Target directly.
+	Jump string
+
+	// Concurrency caps the number of SFTP operations in flight at once,
+	// independent of the local scan's -j flag (0 = defaultSSHConcurrency).
+	Concurrency int
+
+	// Retries is how many additional attempts a directory read or stat gets
+	// after a transient SFTP error (dropped packet, connection reset) before
+	// the path is given up on and flagged. Permission-denied and not-exist
+	// errors are never retried. 0 disables retrying.
+	Retries int
+}
+
+// DisplayTarget formats cfg.Target the way progress lines and error messages
+// should show it to the user: user@host, with a trailing :port appended (the
+// host bracketed when it's an IPv6 literal, via net.JoinHostPort) only when
+// cfg.Port differs from the default SSH port. Falls back to the raw target
+// string if it can't be parsed, so display never fails where a scan wouldn't.
+func DisplayTarget(cfg Config) string {
+	user, host, err := parseSSHTarget(cfg.Target)
+	if err != nil {
+		return cfg.Target
+	}
+	if cfg.Port == 0 || cfg.Port == defaultSSHPort {
+		return user + "@" + host
+	}
+	return user + "@" + net.JoinHostPort(host, strconv.Itoa(cfg.Port))
 }
 
 // SFTPScanner scans a remote filesystem over the SFTP subsystem.
@@ -94,7 +139,7 @@ func (s *SFTPScanner) scanWithClient(ctx context.Context, client sftpClient, rem
 		rootPath = cleanRemotePath(resolved)
 	}
 
-	info, err := client.Stat(rootPath)
+	info, err := statWithRetry(ctx, client, rootPath, s.cfg.Retries)
 	if err != nil {
 		return nil, fmt.Errorf("cannot stat remote path %q: %w", rootPath, err)
 	}
@@ -110,14 +155,13 @@ func (s *SFTPScanner) scanWithClient(ctx context.Context, client sftpClient, rem
 		},
 	}
 
-	excludeSet := make(map[string]struct{}, len(opts.ExcludePatterns))
-	for _, p := range opts.ExcludePatterns {
-		excludeSet[p] = struct{}{}
-	}
+	excludeMatcher := scanner.NewExcludeMatcher(opts.ExcludePatterns)
 
 	var filesScanned, dirsScanned, bytesFound, errCount atomic.Int64
 	startTime := time.Now()
 
+	fsTotalBytes, fsUsedBytes, _ := remoteFilesystemStats(client, rootPath)
+
 	var progressWg sync.WaitGroup
 	progressDone := make(chan struct{})
 	if progress != nil {
@@ -132,12 +176,14 @@ func (s *SFTPScanner) scanWithClient(ctx context.Context, client sftpClient, rem
 					elapsed := time.Since(startTime)
 					select {
 					case progress <- scanner.Progress{
-						FilesScanned: filesScanned.Load(),
-						DirsScanned:  dirsScanned.Load(),
-						BytesFound:   bytesFound.Load(),
-						Errors:       errCount.Load(),
-						StartTime:    startTime,
-						Duration:     elapsed,
+						FilesScanned:         filesScanned.Load(),
+						DirsScanned:          dirsScanned.Load(),
+						BytesFound:           bytesFound.Load(),
+						Errors:               errCount.Load(),
+						StartTime:            startTime,
+						Duration:             elapsed,
+						FilesystemUsedBytes:  fsUsedBytes,
+						FilesystemTotalBytes: fsTotalBytes,
 					}:
 					default:
 					}
@@ -155,9 +201,9 @@ func (s *SFTPScanner) scanWithClient(ctx context.Context, client sftpClient, rem
 		}()
 	}
 
-	concurrency := opts.Concurrency
+	concurrency := s.cfg.Concurrency
 	if concurrency <= 0 {
-		concurrency = runtime.GOMAXPROCS(0) * 3
+		concurrency = defaultSSHConcurrency
 	}
 	sem := make(chan struct{}, concurrency)
 	blockSize := remoteBlockSize(client, rootPath)
@@ -167,7 +213,7 @@ func (s *SFTPScanner) scanWithClient(ctx context.Context, client sftpClient, rem
 	var seenFiles sync.Map
 
 	var wg sync.WaitGroup
-	s.scanDir(ctx, client, rootPath, rootPath, root, opts, sem, &wg, &filesScanned, &dirsScanned, &bytesFound, &errCount, excludeSet, &visitedDirs, &seenFiles, blockSize)
+	s.scanDir(ctx, client, rootPath, rootPath, root, opts, sem, &wg, &filesScanned, &dirsScanned, &bytesFound, &errCount, excludeMatcher, &visitedDirs, &seenFiles, blockSize)
 	wg.Wait()
 
 	if err := ctx.Err(); err != nil {
@@ -179,13 +225,15 @@ func (s *SFTPScanner) scanWithClient(ctx context.Context, client sftpClient, rem
 		elapsed := time.Since(startTime)
 		select {
 		case progress <- scanner.Progress{
-			FilesScanned: filesScanned.Load(),
-			DirsScanned:  dirsScanned.Load(),
-			BytesFound:   bytesFound.Load(),
-			Errors:       errCount.Load(),
-			Done:         true,
-			StartTime:    startTime,
-			Duration:     elapsed,
+			FilesScanned:         filesScanned.Load(),
+			DirsScanned:          dirsScanned.Load(),
+			BytesFound:           bytesFound.Load(),
+			Errors:               errCount.Load(),
+			Done:                 true,
+			StartTime:            startTime,
+			Duration:             elapsed,
+			FilesystemUsedBytes:  fsUsedBytes,
+			FilesystemTotalBytes: fsTotalBytes,
 		}:
 		default:
 		}
@@ -207,7 +255,7 @@ func (s *SFTPScanner) scanDir(
 	sem chan struct{},
 	wg *sync.WaitGroup,
 	filesScanned, dirsScanned, bytesFound, errCount *atomic.Int64,
-	excludeSet map[string]struct{},
+	excludeMatcher scanner.ExcludeMatcher,
 	visitedDirs *sync.Map,
 	seenFiles *sync.Map,
 	blockSize int64,
@@ -218,7 +266,7 @@ func (s *SFTPScanner) scanDir(
 	default:
 	}
 
-	entries, err := readRemoteDir(ctx, client, dirPath)
+	entries, err := readRemoteDirWithRetry(ctx, client, dirPath, s.cfg.Retries)
 	if err != nil {
 		parent.Flag |= model.FlagError
 		errCount.Add(1)
@@ -234,10 +282,10 @@ func (s *SFTPScanner) scanDir(
 			go func(p string, d *model.DirNode) {
 				defer wg.Done()
 				defer func() { <-sem }()
-				s.scanDir(ctx, client, scanRoot, p, d, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, excludeSet, visitedDirs, seenFiles, blockSize)
+				s.scanDir(ctx, client, scanRoot, p, d, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, excludeMatcher, visitedDirs, seenFiles, blockSize)
 			}(path, dir)
 		default:
-			s.scanDir(ctx, client, scanRoot, path, dir, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, excludeSet, visitedDirs, seenFiles, blockSize)
+			s.scanDir(ctx, client, scanRoot, path, dir, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, excludeMatcher, visitedDirs, seenFiles, blockSize)
 		}
 	}
 
@@ -249,7 +297,7 @@ func (s *SFTPScanner) scanDir(
 		}
 
 		name := entry.Name()
-		if _, excluded := excludeSet[name]; excluded {
+		if excludeMatcher.Match(name) {
 			continue
 		}
 		if !opts.ShowHidden && isHidden(name) {
@@ -302,7 +350,10 @@ func (s *SFTPScanner) scanDir(
 
 				size := targetInfo.Size()
 				flag := model.FlagSymlink
-				if _, loaded := seenFiles.LoadOrStore(resolvedPath, true); loaded {
+				if !opts.OlderThan.IsZero() && targetInfo.ModTime().Before(opts.OlderThan) {
+					flag |= model.FlagStale
+				}
+				if _, loaded := seenFiles.LoadOrStore(resolvedPath, true); loaded && !opts.CountSymlinkSizes {
 					flag |= model.FlagHardlink
 					size = 0
 				}
@@ -321,12 +372,16 @@ func (s *SFTPScanner) scanDir(
 			}
 
 			size := entry.Size()
+			flag := model.FlagSymlink
+			if !opts.OlderThan.IsZero() && entry.ModTime().Before(opts.OlderThan) {
+				flag |= model.FlagStale
+			}
 			fileNode := &model.FileNode{
 				Name:   name,
 				Size:   size,
 				Usage:  estimateDiskUsage(size, blockSize),
 				Mtime:  entry.ModTime(),
-				Flag:   model.FlagSymlink,
+				Flag:   flag,
 				Parent: parent,
 			}
 			parent.AddChild(fileNode)
@@ -358,7 +413,13 @@ func (s *SFTPScanner) scanDir(
 		}
 
 		size := entry.Size()
+		if opts.MinSize > 0 && size < opts.MinSize {
+			continue
+		}
 		flag := model.NodeFlag(0)
+		if !opts.OlderThan.IsZero() && entry.ModTime().Before(opts.OlderThan) {
+			flag |= model.FlagStale
+		}
 		if opts.FollowSymlinks {
 			canonicalPath := fullPath
 			if resolvedPath, err := client.RealPath(fullPath); err == nil {
@@ -430,10 +491,14 @@ func estimateDiskUsage(size, blockSize int64) int64 {
 	return blocks * blockSize
 }
 
+// remoteStatVFS is the subset of *sftp.Client used to query filesystem
+// capacity for the remote path being scanned.
+type remoteStatVFS interface {
+	StatVFS(path string) (*sftp.StatVFS, error)
+}
+
 func remoteBlockSize(client sftpClient, rootPath string) int64 {
-	vfsClient, ok := client.(interface {
-		StatVFS(path string) (*sftp.StatVFS, error)
-	})
+	vfsClient, ok := client.(remoteStatVFS)
 	if !ok {
 		return defaultRemoteBlockSize
 	}
@@ -452,6 +517,29 @@ func remoteBlockSize(client sftpClient, rootPath string) int64 {
 	return defaultRemoteBlockSize
 }
 
+// remoteFilesystemStats reports the total and used byte capacity for the
+// filesystem backing rootPath on the remote host, via the same StatVFS
+// extension remoteBlockSize uses. ok is false when the server doesn't
+// support the extension or the call fails.
+func remoteFilesystemStats(client sftpClient, rootPath string) (total, used int64, ok bool) {
+	vfsClient, ok := client.(remoteStatVFS)
+	if !ok {
+		return 0, 0, false
+	}
+
+	stat, err := vfsClient.StatVFS(rootPath)
+	if err != nil || stat == nil {
+		return 0, 0, false
+	}
+
+	t := stat.TotalSpace()
+	f := stat.FreeSpace()
+	if t > uint64(maxInt64) || f > t {
+		return 0, 0, false
+	}
+	return int64(t), int64(t - f), true
+}
+
 func isHidden(name string) bool {
 	return len(name) > 0 && name[0] == '.'
 }
@@ -483,6 +571,67 @@ func readRemoteDir(ctx context.Context, client sftpClient, dirPath string) ([]os
 	return client.ReadDir(dirPath)
 }
 
+// readRemoteDirWithRetry retries readRemoteDir on transient errors, up to
+// retries additional attempts with exponential backoff.
+func readRemoteDirWithRetry(ctx context.Context, client sftpClient, dirPath string, retries int) ([]os.FileInfo, error) {
+	var entries []os.FileInfo
+	err := withSFTPRetry(ctx, retries, func() error {
+		var err error
+		entries, err = readRemoteDir(ctx, client, dirPath)
+		return err
+	})
+	return entries, err
+}
+
+// statWithRetry retries client.Stat on transient errors, up to retries
+// additional attempts with exponential backoff.
+func statWithRetry(ctx context.Context, client sftpClient, path string, retries int) (os.FileInfo, error) {
+	var info os.FileInfo
+	err := withSFTPRetry(ctx, retries, func() error {
+		var err error
+		info, err = client.Stat(path)
+		return err
+	})
+	return info, err
+}
+
+// withSFTPRetry calls fn, retrying up to retries additional times with
+// exponential backoff (starting at defaultSSHRetryDelay) as long as fn keeps
+// returning a transient error. Permission-denied, not-exist, and context
+// cancellation errors are never retried.
+func withSFTPRetry(ctx context.Context, retries int, fn func() error) error {
+	delay := defaultSSHRetryDelay
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= retries || !isTransientRemoteErr(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// isTransientRemoteErr reports whether err looks like a one-off network
+// hiccup worth retrying, as opposed to a permanent condition like a missing
+// or permission-denied path.
+func isTransientRemoteErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if os.IsNotExist(err) || os.IsPermission(err) {
+		return false
+	}
+	return true
+}
+
 func dialSFTP(ctx context.Context, cfg Config) (sftpClient, io.Closer, error) {
 	if cfg.Port < 1 || cfg.Port > 65535 {
 		return nil, nil, fmt.Errorf("ssh port must be between 1 and 65535")
@@ -493,12 +642,19 @@ func dialSFTP(ctx context.Context, cfg Config) (sftpClient, io.Closer, error) {
 		return nil, nil, err
 	}
 
+	var identityFile string
+	if !strings.Contains(cfg.Target, "@") {
+		if entry, ok, _ := LookupSSHConfig(cfg.Target); ok {
+			identityFile = entry.IdentityFile
+		}
+	}
+
 	hostCB, err := hostKeyCallback(host, cfg.Port, cfg.BatchMode)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	auth, err := buildAuthMethods(user, host, cfg.BatchMode)
+	auth, err := buildAuthMethods(user, host, cfg.BatchMode, identityFile)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -518,21 +674,157 @@ func dialSFTP(ctx context.Context, cfg Config) (sftpClient, io.Closer, error) {
 	}
 
 	addr := net.JoinHostPort(host, fmt.Sprintf("%d", cfg.Port))
-	sshClient, err := connectSSH(dialCtx, addr, sshConfig)
-	if err != nil {
-		return nil, nil, fmt.Errorf("SSH connection failed: %w", err)
+
+	var jumpClient *ssh.Client
+	var sshClient *ssh.Client
+	if cfg.Jump != "" {
+		jumpClient, err = dialJumpHost(dialCtx, cfg.Jump, cfg.BatchMode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jump host connection failed: %w", err)
+		}
+		sshClient, err = dialThroughJump(dialCtx, jumpClient, addr, sshConfig)
+		if err != nil {
+			_ = jumpClient.Close()
+			return nil, nil, fmt.Errorf("SSH connection through jump host failed: %w", err)
+		}
+	} else {
+		sshClient, err = connectSSH(dialCtx, addr, sshConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("SSH connection failed: %w", err)
+		}
 	}
 
 	sftpClient, err := sftp.NewClient(sshClient)
 	if err != nil {
 		_ = sshClient.Close()
+		if jumpClient != nil {
+			_ = jumpClient.Close()
+		}
 		return nil, nil, fmt.Errorf("cannot start SFTP subsystem: %w", err)
 	}
 
-	closer := &remoteCloser{ssh: sshClient, sftp: sftpClient}
+	closer := &remoteCloser{ssh: sshClient, sftp: sftpClient, jump: jumpClient}
+	if cfg.KeepAlive > 0 {
+		closer.startKeepAlive(sshClient, cfg.KeepAlive)
+	}
 	return sftpClient, closer, nil
 }
 
+// dialJumpHost connects to a "user@bastion" jump host, reusing the same
+// host-key verification and authentication machinery as a normal target
+// connection. It always uses the default SSH port, since --ssh-jump doesn't
+// carry its own port override.
+func dialJumpHost(ctx context.Context, jump string, batchMode bool) (*ssh.Client, error) {
+	user, host, err := parseSSHTarget(jump)
+	if err != nil {
+		return nil, err
+	}
+
+	var identityFile string
+	if !strings.Contains(jump, "@") {
+		if entry, ok, _ := LookupSSHConfig(jump); ok {
+			identityFile = entry.IdentityFile
+		}
+	}
+
+	hostCB, err := hostKeyCallback(host, defaultSSHPort, batchMode)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := buildAuthMethods(user, host, batchMode, identityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostCB,
+	}
+
+	addr := net.JoinHostPort(host, fmt.Sprintf("%d", defaultSSHPort))
+	return connectSSH(ctx, addr, sshConfig)
+}
+
+// sshDialer is the subset of *ssh.Client used to tunnel a second SSH
+// connection through an already-established one, narrowed down so
+// dialThroughJump can be exercised in tests against a fake jump client.
+type sshDialer interface {
+	Dial(network, addr string) (net.Conn, error)
+}
+
+// dialThroughJump opens a "direct-tcpip" channel for addr through an
+// already-connected jump client, then runs the normal SSH handshake over
+// that channel instead of over a raw TCP dial.
+func dialThroughJump(ctx context.Context, jumpClient sshDialer, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := jumpClient.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open tunnel to %s through jump host: %w", addr, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	c, chans, reqs, err := sshNewClientConn(conn, addr, config)
+	close(done)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// FetchHostFingerprint connects just far enough to observe the remote host's
+// SSH key type and SHA256 fingerprint, then disconnects without checking the
+// key against known_hosts or completing authentication. Used by
+// -ssh-print-fingerprint so a fingerprint can be verified out-of-band before
+// the normal trust-on-first-use prompt would accept it.
+func FetchHostFingerprint(ctx context.Context, target string, port int, timeout time.Duration) (keyType string, fingerprint string, err error) {
+	_, host, err := parseSSHTarget(target)
+	if err != nil {
+		return "", "", err
+	}
+	if port < 1 || port > 65535 {
+		return "", "", fmt.Errorf("ssh port must be between 1 and 65535")
+	}
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		User: "godu-fingerprint-probe",
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			keyType = key.Type()
+			fingerprint = ssh.FingerprintSHA256(key)
+			return nil
+		},
+		Timeout: timeout,
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(host, fmt.Sprintf("%d", port))
+	client, dialErr := connectSSH(dialCtx, addr, sshConfig)
+	if client != nil {
+		_ = client.Close()
+	}
+	if fingerprint == "" {
+		if dialErr != nil {
+			return "", "", fmt.Errorf("cannot connect to %s: %w", addr, dialErr)
+		}
+		return "", "", fmt.Errorf("cannot connect to %s: no host key observed", addr)
+	}
+	return keyType, fingerprint, nil
+}
+
 func connectSSH(ctx context.Context, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
 	conn, err := dialContext(ctx, "tcp", addr)
 	if err != nil {
@@ -561,9 +853,46 @@ func connectSSH(ctx context.Context, addr string, config *ssh.ClientConfig) (*ss
 type remoteCloser struct {
 	ssh  *ssh.Client
 	sftp *sftp.Client
+	jump *ssh.Client
+
+	keepAliveStop chan struct{}
+	keepAliveDone chan struct{}
+}
+
+// keepAliveSender is the subset of *ssh.Client used to send keepalive
+// requests, narrowed down so the keepalive goroutine can be exercised in
+// tests against a fake instead of a real SSH connection.
+type keepAliveSender interface {
+	SendRequest(name string, wantReply bool, payload []byte) (bool, []byte, error)
+}
+
+// startKeepAlive runs a background goroutine that sends an SSH keepalive
+// request every interval for as long as the connection is open, so a flaky
+// link doesn't silently drop a long scan. The goroutine stops as soon as
+// Close is called.
+func (c *remoteCloser) startKeepAlive(sshClient keepAliveSender, interval time.Duration) {
+	c.keepAliveStop = make(chan struct{})
+	c.keepAliveDone = make(chan struct{})
+	go func() {
+		defer close(c.keepAliveDone)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.keepAliveStop:
+				return
+			case <-ticker.C:
+				_, _, _ = sshClient.SendRequest("keepalive@openssh.com", true, nil)
+			}
+		}
+	}()
 }
 
 func (c *remoteCloser) Close() error {
+	if c.keepAliveStop != nil {
+		close(c.keepAliveStop)
+		<-c.keepAliveDone
+	}
 	var retErr error
 	if c.sftp != nil {
 		if err := c.sftp.Close(); err != nil {
@@ -575,5 +904,10 @@ func (c *remoteCloser) Close() error {
 			retErr = err
 		}
 	}
+	if c.jump != nil {
+		if err := c.jump.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
 	return retErr
 }
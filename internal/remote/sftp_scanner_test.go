@@ -2,12 +2,15 @@ package remote
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	pathpkg "path"
+	"sync"
 	"testing"
 	"time"
 
@@ -194,6 +197,58 @@ func TestScanWithClient_ReadDirError_SetsFlagError(t *testing.T) {
 	}
 }
 
+func TestScanWithClient_RetriesTransientReadDirError(t *testing.T) {
+	remaining := 2
+	client := newFakeSFTP(map[string]fakeNode{
+		"/root":                  {mode: os.ModeDir, children: []string{"flaky"}},
+		"/root/flaky":            {mode: os.ModeDir, children: []string{"inside.txt"}, flakyReads: &remaining},
+		"/root/flaky/inside.txt": {mode: 0, size: 5},
+	})
+
+	s := &SFTPScanner{cfg: Config{Target: "user@host", Port: 22, Retries: 2}, dial: fakeDial(client)}
+	root, err := s.Scan(context.Background(), "/root", scanner.ScanOptions{ShowHidden: true}, nil)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	flaky := findNode(root, "flaky")
+	if flaky == nil {
+		t.Fatal("expected flaky dir node")
+	}
+	if flaky.GetFlag()&model.FlagError != 0 {
+		t.Fatal("expected flaky directory to be scanned successfully, not flagged as an error")
+	}
+	if remaining != 0 {
+		t.Fatalf("expected both transient failures to be consumed, %d remaining", remaining)
+	}
+	if got := findNode(root, "flaky", "inside.txt"); got == nil {
+		t.Fatal("expected flaky directory's contents to be scanned")
+	}
+}
+
+func TestScanWithClient_GivesUpAfterExhaustingRetries(t *testing.T) {
+	remaining := 3
+	client := newFakeSFTP(map[string]fakeNode{
+		"/root":                  {mode: os.ModeDir, children: []string{"flaky"}},
+		"/root/flaky":            {mode: os.ModeDir, children: []string{"inside.txt"}, flakyReads: &remaining},
+		"/root/flaky/inside.txt": {mode: 0, size: 5},
+	})
+
+	s := &SFTPScanner{cfg: Config{Target: "user@host", Port: 22, Retries: 2}, dial: fakeDial(client)}
+	root, err := s.Scan(context.Background(), "/root", scanner.ScanOptions{ShowHidden: true}, nil)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	flaky := findNode(root, "flaky")
+	if flaky == nil {
+		t.Fatal("expected flaky dir node")
+	}
+	if flaky.GetFlag()&model.FlagError == 0 {
+		t.Fatal("expected flaky directory to still be flagged once retries are exhausted")
+	}
+}
+
 func TestScanWithClient_CanceledContext_WithProgressChannel_DoesNotPanic(t *testing.T) {
 	client := newFakeSFTP(map[string]fakeNode{
 		"/root":       {mode: os.ModeDir, children: []string{"a.txt"}},
@@ -402,6 +457,52 @@ func TestConnectSSH_RespectsContextCancellation(t *testing.T) {
 	}
 }
 
+func TestFetchHostFingerprint_ReturnsKeyEvenWhenAuthFails(t *testing.T) {
+	origDial := dialContext
+	origNewClientConn := sshNewClientConn
+	t.Cleanup(func() {
+		dialContext = origDial
+		sshNewClientConn = origNewClientConn
+	})
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	hostKey, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("ssh.NewPublicKey: %v", err)
+	}
+
+	dialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		server, _ := net.Pipe()
+		return server, nil
+	}
+	sshNewClientConn = func(_ net.Conn, _ string, config *ssh.ClientConfig) (ssh.Conn, <-chan ssh.NewChannel, <-chan *ssh.Request, error) {
+		if err := config.HostKeyCallback("example.com:22", &net.TCPAddr{}, hostKey); err != nil {
+			return nil, nil, nil, err
+		}
+		return nil, nil, nil, errors.New("simulated auth failure after host key exchange")
+	}
+
+	keyType, fingerprint, err := FetchHostFingerprint(context.Background(), "user@example.com", 22, time.Second)
+	if err != nil {
+		t.Fatalf("expected fingerprint despite auth failure, got error: %v", err)
+	}
+	if keyType != hostKey.Type() {
+		t.Fatalf("expected key type %q, got %q", hostKey.Type(), keyType)
+	}
+	if fingerprint != ssh.FingerprintSHA256(hostKey) {
+		t.Fatalf("expected fingerprint %q, got %q", ssh.FingerprintSHA256(hostKey), fingerprint)
+	}
+}
+
+func TestFetchHostFingerprint_RejectsInvalidTarget(t *testing.T) {
+	if _, _, err := FetchHostFingerprint(context.Background(), "not-a-target", 22, time.Second); err == nil {
+		t.Fatal("expected error for malformed target")
+	}
+}
+
 func fakeDial(client sftpClient) func(context.Context, Config) (sftpClient, io.Closer, error) {
 	return func(context.Context, Config) (sftpClient, io.Closer, error) {
 		return client, noopCloser{}, nil
@@ -447,6 +548,11 @@ type fakeNode struct {
 	target    string
 	children  []string
 	errOnRead bool // if true, ReadDir returns an error
+
+	// flakyReads, if non-nil, makes ReadDir fail with a transient error
+	// while its value is > 0, decrementing it on each failed call, then
+	// succeed once it reaches 0. Simulates a flaky connection.
+	flakyReads *int
 }
 
 type fakeSFTP struct {
@@ -476,6 +582,10 @@ func (f *fakeSFTP) ReadDir(path string) ([]os.FileInfo, error) {
 	if node.errOnRead {
 		return nil, fmt.Errorf("permission denied")
 	}
+	if node.flakyReads != nil && *node.flakyReads > 0 {
+		*node.flakyReads--
+		return nil, fmt.Errorf("connection reset by peer")
+	}
 
 	out := make([]os.FileInfo, 0, len(node.children))
 	for _, child := range node.children {
@@ -565,3 +675,149 @@ func (fi fakeInfo) Mode() os.FileMode  { return fi.mode }
 func (fi fakeInfo) ModTime() time.Time { return fi.mtime }
 func (fi fakeInfo) IsDir() bool        { return fi.mode.IsDir() }
 func (fi fakeInfo) Sys() any           { return nil }
+
+type fakeKeepAliveSender struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeKeepAliveSender) SendRequest(name string, wantReply bool, payload []byte) (bool, []byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return true, nil, nil
+}
+
+func (f *fakeKeepAliveSender) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestRemoteCloser_KeepAliveStopsOnClose(t *testing.T) {
+	sender := &fakeKeepAliveSender{}
+	closer := &remoteCloser{}
+	closer.startKeepAlive(sender, 5*time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for sender.callCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if sender.callCount() == 0 {
+		t.Fatal("expected at least one keepalive request to be sent")
+	}
+
+	if err := closer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	select {
+	case <-closer.keepAliveDone:
+	default:
+		t.Fatal("expected keepalive goroutine to have stopped after Close")
+	}
+
+	countAfterClose := sender.callCount()
+	time.Sleep(20 * time.Millisecond)
+	if sender.callCount() != countAfterClose {
+		t.Fatal("expected no further keepalive requests after Close")
+	}
+}
+
+type fakeJumpDialer struct {
+	dialedNetwork string
+	dialedAddr    string
+	conn          net.Conn
+}
+
+func (f *fakeJumpDialer) Dial(network, addr string) (net.Conn, error) {
+	f.dialedNetwork = network
+	f.dialedAddr = addr
+	return f.conn, nil
+}
+
+func TestDialThroughJump_TunnelsThroughJumpClient(t *testing.T) {
+	origNewClientConn := sshNewClientConn
+	t.Cleanup(func() { sshNewClientConn = origNewClientConn })
+
+	client, server := net.Pipe()
+	t.Cleanup(func() { server.Close() })
+
+	jump := &fakeJumpDialer{conn: client}
+
+	var handshakeConn net.Conn
+	var handshakeAddr string
+	sshNewClientConn = func(conn net.Conn, addr string, config *ssh.ClientConfig) (ssh.Conn, <-chan ssh.NewChannel, <-chan *ssh.Request, error) {
+		handshakeConn = conn
+		handshakeAddr = addr
+		return nil, nil, nil, errors.New("simulated handshake failure")
+	}
+
+	_, err := dialThroughJump(context.Background(), jump, "internal-host:22", &ssh.ClientConfig{
+		User:            "user",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err == nil {
+		t.Fatal("expected simulated handshake error")
+	}
+	if jump.dialedNetwork != "tcp" || jump.dialedAddr != "internal-host:22" {
+		t.Fatalf("expected jump client to dial tcp/internal-host:22, got %s/%s", jump.dialedNetwork, jump.dialedAddr)
+	}
+	if handshakeConn != client {
+		t.Fatal("expected SSH handshake to run over the connection returned by the jump client's Dial")
+	}
+	if handshakeAddr != "internal-host:22" {
+		t.Fatalf("unexpected handshake addr: %q", handshakeAddr)
+	}
+}
+
+// trackingSFTP wraps a sftpClient and records the maximum number of ReadDir
+// calls observed in flight at once, to verify the scanner's concurrency
+// limit is actually being respected.
+type trackingSFTP struct {
+	sftpClient
+	mu       sync.Mutex
+	inFlight int
+	maxSeen  int
+}
+
+func (f *trackingSFTP) ReadDir(path string) ([]os.FileInfo, error) {
+	f.mu.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxSeen {
+		f.maxSeen = f.inFlight
+	}
+	f.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+
+	return f.sftpClient.ReadDir(path)
+}
+
+func TestScanWithClient_UsesConfiguredConcurrencyNotCPUDefault(t *testing.T) {
+	nodes := map[string]fakeNode{
+		"/root": {mode: os.ModeDir, children: []string{"a", "b", "c", "d"}},
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		nodes["/root/"+name] = fakeNode{mode: os.ModeDir, children: []string{"f.txt"}}
+		nodes["/root/"+name+"/f.txt"] = fakeNode{mode: 0, size: 1}
+	}
+
+	tracked := &trackingSFTP{sftpClient: newFakeSFTP(nodes)}
+	s := &SFTPScanner{cfg: Config{Target: "user@host", Port: 22, Concurrency: 1}, dial: fakeDial(tracked)}
+
+	if _, err := s.Scan(context.Background(), "/root", scanner.ScanOptions{ShowHidden: true}, nil); err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	// The root directory is always scanned synchronously before any worker
+	// is spawned, so with Concurrency=1 at most one more ReadDir (the single
+	// semaphore slot) can run alongside it.
+	if tracked.maxSeen > 2 {
+		t.Fatalf("expected at most 2 concurrent ReadDir with Concurrency=1, saw %d", tracked.maxSeen)
+	}
+}
@@ -0,0 +1,141 @@
+package remote
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SSHConfigEntry holds the per-host settings resolved from ~/.ssh/config for
+// a single Host alias. Fields left unset by the config keep their zero value.
+type SSHConfigEntry struct {
+	HostName     string
+	User         string
+	Port         int
+	IdentityFile string
+}
+
+// LookupSSHConfig resolves alias against ~/.ssh/config, the way ssh(1) would
+// for a bare "ssh alias" invocation. ok is false when the config file does
+// not exist or has no Host block matching alias.
+func LookupSSHConfig(alias string) (entry SSHConfigEntry, ok bool, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return SSHConfigEntry{}, false, nil
+	}
+	return lookupSSHConfigFile(filepath.Join(home, ".ssh", "config"), alias)
+}
+
+func lookupSSHConfigFile(path, alias string) (SSHConfigEntry, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return SSHConfigEntry{}, false, nil
+		}
+		return SSHConfigEntry{}, false, fmt.Errorf("cannot read ssh config: %w", err)
+	}
+	defer f.Close()
+
+	var entry SSHConfigEntry
+	matched := false
+	matching := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		keyword, value, ok := splitSSHConfigLine(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		if keyword == "host" {
+			matching = hostMatchesPatterns(alias, strings.Fields(value))
+			if matching {
+				matched = true
+			}
+			continue
+		}
+		if !matching {
+			continue
+		}
+
+		// First obtained value for a keyword wins, matching ssh_config(5).
+		switch keyword {
+		case "hostname":
+			if entry.HostName == "" {
+				entry.HostName = value
+			}
+		case "user":
+			if entry.User == "" {
+				entry.User = value
+			}
+		case "port":
+			if entry.Port == 0 {
+				if port, err := strconv.Atoi(value); err == nil {
+					entry.Port = port
+				}
+			}
+		case "identityfile":
+			if entry.IdentityFile == "" {
+				entry.IdentityFile = expandHome(value)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return SSHConfigEntry{}, false, fmt.Errorf("cannot read ssh config: %w", err)
+	}
+
+	return entry, matched, nil
+}
+
+// splitSSHConfigLine parses a single ssh_config line into its lowercased
+// keyword and remaining value, skipping blank lines and comments. ssh_config
+// allows "Keyword value" or "Keyword=value"; both are accepted here.
+func splitSSHConfigLine(line string) (keyword, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	line = strings.Replace(line, "=", " ", 1)
+
+	fields := strings.SplitN(line, " ", 2)
+	if len(fields) != 2 {
+		return "", "", false
+	}
+	return strings.ToLower(fields[0]), strings.TrimSpace(fields[1]), true
+}
+
+// hostMatchesPatterns reports whether alias matches the space-separated
+// patterns following a Host keyword, honoring ssh_config's "!pattern"
+// negation (a negated match always excludes, regardless of pattern order).
+func hostMatchesPatterns(alias string, patterns []string) bool {
+	matched := false
+	for _, raw := range patterns {
+		negate := strings.HasPrefix(raw, "!")
+		pattern := strings.TrimPrefix(raw, "!")
+		if ok, _ := filepath.Match(pattern, alias); ok {
+			if negate {
+				return false
+			}
+			matched = true
+		}
+	}
+	return matched
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	if path == "~" {
+		return home
+	}
+	return filepath.Join(home, path[2:])
+}
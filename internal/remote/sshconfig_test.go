@@ -0,0 +1,149 @@
+package remote
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSSHConfig(t *testing.T, contents string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing test ssh config: %v", err)
+	}
+	return path
+}
+
+func TestLookupSSHConfigFile_ResolvesAlias(t *testing.T) {
+	path := writeSSHConfig(t, `
+Host myserver
+  HostName 203.0.113.5
+  User deploy
+  Port 2222
+  IdentityFile ~/.ssh/deploy_key
+
+Host other
+  HostName example.com
+`)
+
+	entry, ok, err := lookupSSHConfigFile(path, "myserver")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a matching Host entry")
+	}
+	if entry.HostName != "203.0.113.5" || entry.User != "deploy" || entry.Port != 2222 {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	home, _ := os.UserHomeDir()
+	if entry.IdentityFile != filepath.Join(home, ".ssh", "deploy_key") {
+		t.Fatalf("expected IdentityFile to be tilde-expanded, got %q", entry.IdentityFile)
+	}
+}
+
+func TestLookupSSHConfigFile_NoMatchingEntry(t *testing.T) {
+	path := writeSSHConfig(t, `
+Host other
+  HostName example.com
+`)
+
+	entry, ok, err := lookupSSHConfigFile(path, "myserver")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected no matching entry, got %+v", entry)
+	}
+}
+
+func TestLookupSSHConfigFile_MissingFile(t *testing.T) {
+	entry, ok, err := lookupSSHConfigFile(filepath.Join(t.TempDir(), "does-not-exist"), "myserver")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected no entry for a missing config file, got %+v", entry)
+	}
+}
+
+func TestLookupSSHConfigFile_WildcardHostPattern(t *testing.T) {
+	path := writeSSHConfig(t, `
+Host web-*
+  User www
+
+Host web-prod !web-prod-staging
+  HostName 198.51.100.1
+`)
+
+	entry, ok, err := lookupSSHConfigFile(path, "web-prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok || entry.User != "www" || entry.HostName != "198.51.100.1" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestLookupSSHConfigFile_NegatedPatternExcludes(t *testing.T) {
+	path := writeSSHConfig(t, `
+Host web-* !web-prod-staging
+  User www
+`)
+
+	_, ok, err := lookupSSHConfigFile(path, "web-prod-staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected negated pattern to exclude the alias")
+	}
+}
+
+func TestLookupSSHConfig_FallsBackWhenNoConfigFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entry, ok, err := LookupSSHConfig("myserver")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected no entry when ~/.ssh/config does not exist, got %+v", entry)
+	}
+}
+
+func TestResolveSSHAlias_RequiresUser(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "config"), []byte("Host myserver\n  HostName 203.0.113.5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := parseSSHTarget("myserver"); err == nil {
+		t.Fatal("expected error for an alias with no User configured")
+	}
+}
+
+func TestResolveSSHAlias_UsesConfiguredUserAndHostName(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "config"), []byte("Host myserver\n  HostName 203.0.113.5\n  User deploy\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	user, host, err := parseSSHTarget("myserver")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user != "deploy" || host != "203.0.113.5" {
+		t.Fatalf("unexpected resolution: user=%q host=%q", user, host)
+	}
+}
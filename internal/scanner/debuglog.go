@@ -0,0 +1,13 @@
+package scanner
+
+import "log/slog"
+
+// debugLog writes a structured debug-level entry to logger if one is
+// configured, and is a no-op otherwise. ScanOptions.Logger is nil by
+// default, so normal runs never pay the cost of formatting these messages.
+func debugLog(logger *slog.Logger, msg string, args ...any) {
+	if logger == nil {
+		return
+	}
+	logger.Debug(msg, args...)
+}
@@ -0,0 +1,44 @@
+package scanner
+
+import "sync"
+
+// ScanError records a single path that failed to be read during a scan,
+// along with the error text for automation to act on.
+type ScanError struct {
+	Path string `json:"path"`
+	Err  string `json:"error"`
+}
+
+// ErrorCollector accumulates ScanErrors from concurrent scanDir goroutines.
+// A nil *ErrorCollector is valid and simply discards everything, so callers
+// that don't need per-path errors can leave ScanOptions.ErrorCollector unset.
+type ErrorCollector struct {
+	mu     sync.Mutex
+	errors []ScanError
+}
+
+// NewErrorCollector creates an empty ErrorCollector.
+func NewErrorCollector() *ErrorCollector {
+	return &ErrorCollector{}
+}
+
+func (c *ErrorCollector) add(path string, err error) {
+	if c == nil || err == nil {
+		return
+	}
+	c.mu.Lock()
+	c.errors = append(c.errors, ScanError{Path: path, Err: err.Error()})
+	c.mu.Unlock()
+}
+
+// Errors returns a snapshot of the errors collected so far.
+func (c *ErrorCollector) Errors() []ScanError {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]ScanError, len(c.errors))
+	copy(out, c.errors)
+	return out
+}
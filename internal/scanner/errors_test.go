@@ -0,0 +1,28 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorCollector_CollectsAndSnapshots(t *testing.T) {
+	c := NewErrorCollector()
+	c.add("/a", errors.New("permission denied"))
+	c.add("/b", errors.New("not found"))
+
+	got := c.Errors()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(got))
+	}
+	if got[0].Path != "/a" || got[0].Err != "permission denied" {
+		t.Fatalf("unexpected first entry: %+v", got[0])
+	}
+}
+
+func TestErrorCollector_NilIsSafe(t *testing.T) {
+	var c *ErrorCollector
+	c.add("/a", errors.New("boom"))
+	if got := c.Errors(); got != nil {
+		t.Fatalf("expected nil slice from nil collector, got %v", got)
+	}
+}
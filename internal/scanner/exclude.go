@@ -0,0 +1,29 @@
+package scanner
+
+import "path/filepath"
+
+// ExcludeMatcher matches entry names against ScanOptions.ExcludePatterns,
+// which may be plain names (matched exactly) or shell glob patterns
+// understood by filepath.Match (e.g. "*.cache"). It applies equally to
+// files and directories — whatever entry name is passed in.
+type ExcludeMatcher struct {
+	patterns []string
+}
+
+// NewExcludeMatcher builds a matcher for the given patterns.
+func NewExcludeMatcher(patterns []string) ExcludeMatcher {
+	return ExcludeMatcher{patterns: patterns}
+}
+
+// Match reports whether name matches any of the configured patterns.
+func (m ExcludeMatcher) Match(name string) bool {
+	for _, p := range m.patterns {
+		if name == p {
+			return true
+		}
+		if ok, err := filepath.Match(p, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package scanner
+
+import "testing"
+
+func TestExcludeMatcher_Match(t *testing.T) {
+	m := NewExcludeMatcher([]string{"*.tmp", "node_modules"})
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"cache.tmp", true},
+		{"node_modules", true},
+		{"main.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.name); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
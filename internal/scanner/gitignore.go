@@ -0,0 +1,124 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitignoreRule is one parsed line from a .gitignore file.
+type gitignoreRule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// gitignoreFile holds the rules parsed from a single .gitignore, scoped to
+// the directory it was found in: patterns are matched relative to basePath.
+type gitignoreFile struct {
+	basePath string
+	rules    []gitignoreRule
+}
+
+// gitignoreChain is the ordered list of .gitignore files in effect for a
+// directory, from the scan root down to (and including) that directory
+// itself. A nil *gitignoreChain behaves as "no rules" so callers that never
+// enable ScanOptions.RespectGitignore pay no cost.
+type gitignoreChain struct {
+	files []gitignoreFile
+}
+
+// extend returns a chain with dirPath's own .gitignore (if any) appended,
+// without mutating the receiver, since sibling directories are scanned
+// concurrently and each needs its own view of the chain.
+func (c *gitignoreChain) extend(dirPath string) *gitignoreChain {
+	rules := parseGitignoreFile(filepath.Join(dirPath, ".gitignore"))
+	if len(rules) == 0 {
+		if c == nil {
+			return &gitignoreChain{}
+		}
+		return c
+	}
+
+	var files []gitignoreFile
+	if c != nil {
+		files = append(files, c.files...)
+	}
+	files = append(files, gitignoreFile{basePath: dirPath, rules: rules})
+	return &gitignoreChain{files: files}
+}
+
+// isIgnored reports whether fullPath should be skipped. Rules are applied in
+// root-to-leaf, top-to-bottom order so the last matching pattern anywhere in
+// the chain wins, matching git's own precedence: a nested .gitignore's rules
+// are consulted after (and so can override) its ancestors'.
+func (c *gitignoreChain) isIgnored(fullPath string, isDir bool) bool {
+	if c == nil {
+		return false
+	}
+
+	ignored := false
+	for _, gf := range c.files {
+		rel, err := filepath.Rel(gf.basePath, fullPath)
+		if err != nil || rel == "." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, r := range gf.rules {
+			if r.dirOnly && !isDir {
+				continue
+			}
+			var matched bool
+			if r.anchored {
+				matched, _ = filepath.Match(r.pattern, rel)
+			} else {
+				matched, _ = filepath.Match(r.pattern, filepath.Base(rel))
+			}
+			if matched {
+				ignored = !r.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// parseGitignoreFile reads and parses a .gitignore, returning nil if it
+// doesn't exist or can't be read. It supports comments, blank lines,
+// trailing-slash directory-only patterns, "!" negation, and patterns
+// anchored to their own directory via an embedded "/". Patterns needing a
+// git index (e.g. "**" double-star globs) are matched on a best-effort basis
+// via filepath.Match, which has no concept of "**".
+func parseGitignoreFile(path string) []gitignoreRule {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var rules []gitignoreRule
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		negate := strings.HasPrefix(line, "!")
+		if negate {
+			line = line[1:]
+		}
+
+		dirOnly := strings.HasSuffix(line, "/")
+		if dirOnly {
+			line = strings.TrimSuffix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		anchored := strings.Contains(line, "/")
+		pattern := strings.TrimPrefix(line, "/")
+		rules = append(rules, gitignoreRule{pattern: pattern, negate: negate, dirOnly: dirOnly, anchored: anchored})
+	}
+	return rules
+}
@@ -2,7 +2,10 @@ package scanner
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -50,6 +53,12 @@ func (s *ParallelScanner) Scan(ctx context.Context, path string, opts ScanOption
 		absPath = resolved
 	}
 
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
 	// Optionally disable GC during scan
 	var oldGC int
 	if opts.DisableGC {
@@ -69,15 +78,25 @@ func (s *ParallelScanner) Scan(ctx context.Context, path string, opts ScanOption
 	inodeMap := make(map[inodeKey]struct{})
 
 	// Progress tracking
-	var filesScanned, dirsScanned, bytesFound, errCount atomic.Int64
+	var filesScanned, dirsScanned, bytesFound, errCount, vanishedCount atomic.Int64
+	var currentPath atomic.Pointer[string]
 	startTime := time.Now()
 
-	// Exclude set for fast lookup
-	excludeSet := make(map[string]struct{}, len(opts.ExcludePatterns))
-	for _, p := range opts.ExcludePatterns {
-		excludeSet[p] = struct{}{}
+	// Capture the filesystem's total/used bytes upfront, for a real
+	// percentage-of-disk progress display and the disk-usage header. Only
+	// meaningful for whole-mount scans; subdirectory scans fall back to the
+	// count-only display.
+	var fsUsedBytes, fsTotalBytes int64
+	if isMountRoot(absPath) {
+		if total, free, ok := getFilesystemStats(absPath); ok {
+			fsTotalBytes = total
+			fsUsedBytes = total - free
+		}
 	}
 
+	// Exclude matcher: supports plain names and glob patterns.
+	excludeMatcher := NewExcludeMatcher(opts.ExcludePatterns)
+
 	// Create root node
 	root := &model.DirNode{
 		FileNode: model.FileNode{
@@ -99,14 +118,22 @@ func (s *ParallelScanner) Scan(ctx context.Context, path string, opts ScanOption
 				select {
 				case <-ticker.C:
 					elapsed := time.Since(startTime)
+					var path string
+					if p := currentPath.Load(); p != nil {
+						path = *p
+					}
 					select {
 					case progress <- Progress{
-						FilesScanned: filesScanned.Load(),
-						DirsScanned:  dirsScanned.Load(),
-						BytesFound:   bytesFound.Load(),
-						Errors:       errCount.Load(),
-						StartTime:    startTime,
-						Duration:     elapsed,
+						FilesScanned:         filesScanned.Load(),
+						DirsScanned:          dirsScanned.Load(),
+						BytesFound:           bytesFound.Load(),
+						Errors:               errCount.Load(),
+						Vanished:             vanishedCount.Load(),
+						StartTime:            startTime,
+						Duration:             elapsed,
+						FilesystemUsedBytes:  fsUsedBytes,
+						FilesystemTotalBytes: fsTotalBytes,
+						CurrentPath:          path,
 					}:
 					default:
 						// Drop if channel full
@@ -129,53 +156,82 @@ func (s *ParallelScanner) Scan(ctx context.Context, path string, opts ScanOption
 	var visitedDirs sync.Map
 	visitedDirs.Store(absPath, true)
 
+	// Capture the scan root's device id upfront so OneFileSystem mode can
+	// compare every descendant directory against it as the scan proceeds.
+	rootStat := getStatInfo(info)
+	rootDev, rootDevOK := rootStat.dev, rootStat.ok
+
 	// Recursive parallel scan
 	var wg sync.WaitGroup
-	s.scanDir(ctx, absPath, absPath, root, opts, sem, &wg, &filesScanned, &dirsScanned, &bytesFound, &errCount, inodeMap, &inodeMu, excludeSet, &visitedDirs)
+	var gitignore *gitignoreChain
+	s.scanDir(ctx, absPath, absPath, root, 0, opts, sem, &wg, &filesScanned, &dirsScanned, &bytesFound, &errCount, &vanishedCount, &currentPath, inodeMap, &inodeMu, excludeMatcher, &visitedDirs, gitignore, rootDev, rootDevOK)
 	wg.Wait()
 
 	// Bottom-up size calculation after all goroutines complete
 	if err := ctx.Err(); err != nil {
-		return root, err
+		return root, translateScanErr(err, opts)
 	}
 	root.UpdateSizeRecursiveContext(ctx)
 
 	// Send final progress
 	if progress != nil {
 		elapsed := time.Since(startTime)
+		var path string
+		if p := currentPath.Load(); p != nil {
+			path = *p
+		}
 		select {
 		case progress <- Progress{
-			FilesScanned: filesScanned.Load(),
-			DirsScanned:  dirsScanned.Load(),
-			BytesFound:   bytesFound.Load(),
-			Errors:       errCount.Load(),
-			Done:         true,
-			StartTime:    startTime,
-			Duration:     elapsed,
+			FilesScanned:         filesScanned.Load(),
+			DirsScanned:          dirsScanned.Load(),
+			BytesFound:           bytesFound.Load(),
+			Errors:               errCount.Load(),
+			Vanished:             vanishedCount.Load(),
+			Done:                 true,
+			StartTime:            startTime,
+			Duration:             elapsed,
+			FilesystemUsedBytes:  fsUsedBytes,
+			FilesystemTotalBytes: fsTotalBytes,
+			CurrentPath:          path,
 		}:
 		default:
 		}
 	}
 
 	if err := ctx.Err(); err != nil {
-		return root, err
+		return root, translateScanErr(err, opts)
 	}
 	return root, nil
 }
 
+// translateScanErr reports a scan-imposed deadline as ErrScanTimedOut so
+// callers can distinguish "ran out of time" from an externally canceled
+// context and choose to use the partial tree instead of failing outright.
+func translateScanErr(err error, opts ScanOptions) error {
+	if opts.Timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrScanTimedOut, opts.Timeout)
+	}
+	return err
+}
+
 func (s *ParallelScanner) scanDir(
 	ctx context.Context,
 	scanRoot string,
 	dirPath string,
 	parent *model.DirNode,
+	depth int,
 	opts ScanOptions,
 	sem chan struct{},
 	wg *sync.WaitGroup,
-	filesScanned, dirsScanned, bytesFound, errCount *atomic.Int64,
+	filesScanned, dirsScanned, bytesFound, errCount, vanishedCount *atomic.Int64,
+	currentPath *atomic.Pointer[string],
 	inodeMap map[inodeKey]struct{},
 	inodeMu *sync.Mutex,
-	excludeSet map[string]struct{},
+	excludeMatcher ExcludeMatcher,
 	visitedDirs *sync.Map,
+	gitignore *gitignoreChain,
+	rootDev uint64,
+	rootDevOK bool,
 ) {
 	select {
 	case <-ctx.Done():
@@ -183,14 +239,54 @@ func (s *ParallelScanner) scanDir(
 	default:
 	}
 
+	curPath := dirPath
+	currentPath.Store(&curPath)
+
+	if opts.RespectGitignore {
+		gitignore = gitignore.extend(dirPath)
+	}
+
 	dir, err := os.Open(dirPath)
 	if err != nil {
 		parent.Flag |= model.FlagError
 		errCount.Add(1)
+		opts.ErrorCollector.add(dirPath, err)
 		return
 	}
 	defer dir.Close()
 
+	// Detect a directory reachable via more than one path sharing the same
+	// underlying (dev, inode) pair, as happens with bind mounts: the same
+	// content would otherwise be walked and counted once per path. There is
+	// no mountinfo parsing here to identify bind mounts up front, but dev+ino
+	// identity catches the double-counting regardless of how the alias arose.
+	if dirInfo, statErr := dir.Stat(); statErr == nil {
+		if si := getStatInfo(dirInfo); si.ok {
+			ik := inodeKey{dev: si.dev, ino: si.inode}
+			if isDuplicateDirInode(inodeMap, inodeMu, ik) {
+				parent.Flag |= model.FlagHardlink
+				dirsScanned.Add(1)
+				return
+			}
+		}
+	}
+
+	if opts.MaxDirEntries > 0 {
+		names, _ := dir.Readdirnames(opts.MaxDirEntries + 1)
+		if len(names) > opts.MaxDirEntries {
+			dirsScanned.Add(1)
+			collapseDir(ctx, dirPath, parent, filesScanned, bytesFound, errCount, opts.ErrorCollector)
+			return
+		}
+		// Within budget: rewind so the normal read loop below sees every entry.
+		if _, err := dir.Seek(0, io.SeekStart); err != nil {
+			parent.Flag |= model.FlagError
+			errCount.Add(1)
+			opts.ErrorCollector.add(dirPath, err)
+			return
+		}
+	}
+
 	dirsScanned.Add(1)
 
 	// Run subdirectory scans with bounded goroutines.
@@ -203,10 +299,10 @@ func (s *ParallelScanner) scanDir(
 			go func(p string, d *model.DirNode) {
 				defer wg.Done()
 				defer func() { <-sem }()
-				s.scanDir(ctx, scanRoot, p, d, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, inodeMap, inodeMu, excludeSet, visitedDirs)
+				s.scanDir(ctx, scanRoot, p, d, depth+1, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, vanishedCount, currentPath, inodeMap, inodeMu, excludeMatcher, visitedDirs, gitignore, rootDev, rootDevOK)
 			}(path, dir)
 		default:
-			s.scanDir(ctx, scanRoot, path, dir, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, inodeMap, inodeMu, excludeSet, visitedDirs)
+			s.scanDir(ctx, scanRoot, path, dir, depth+1, opts, sem, wg, filesScanned, dirsScanned, bytesFound, errCount, vanishedCount, currentPath, inodeMap, inodeMu, excludeMatcher, visitedDirs, gitignore, rootDev, rootDevOK)
 		}
 	}
 
@@ -223,19 +319,39 @@ func (s *ParallelScanner) scanDir(
 			name := entry.Name()
 
 			// Skip excluded patterns
-			if _, excluded := excludeSet[name]; excluded {
+			if excludeMatcher.Match(name) {
+				debugLog(opts.Logger, "excluded entry", "path", filepath.Join(dirPath, name))
 				continue
 			}
 
-			// Skip hidden files/dirs when ShowHidden is false
-			if !opts.ShowHidden && len(name) > 0 && name[0] == '.' {
+			if opts.RespectGitignore && gitignore.isIgnored(filepath.Join(dirPath, name), entry.IsDir()) {
+				debugLog(opts.Logger, "gitignore excluded entry", "path", filepath.Join(dirPath, name))
+				continue
+			}
+
+			// Skip godu's own in-progress export temp files so a concurrent
+			// scan of the same directory never races with, or counts, a
+			// partially-written export.
+			if isGoduTempExport(name) {
+				continue
+			}
+
+			// Skip hidden files/dirs when ShowHidden is false, or when
+			// NoHiddenTop restricts hiding to just the scan root.
+			hideHidden := !opts.ShowHidden || (opts.NoHiddenTop && dirPath == scanRoot)
+			if hideHidden && len(name) > 0 && name[0] == '.' {
 				continue
 			}
 
 			fullPath := filepath.Join(dirPath, name)
 			info, err := entry.Info()
 			if err != nil {
-				errCount.Add(1)
+				if isVanished(err) {
+					vanishedCount.Add(1)
+				} else {
+					errCount.Add(1)
+					opts.ErrorCollector.add(fullPath, err)
+				}
 				continue
 			}
 
@@ -255,6 +371,7 @@ func (s *ParallelScanner) scanDir(
 			// Skip special files (devices, sockets, pipes, irregular).
 			// Check both dirent type and FileInfo mode for DT_UNKNOWN filesystems.
 			if isSpecialMode(mode) || isSpecialMode(infoMode) {
+				debugLog(opts.Logger, "skipped special file", "path", fullPath, "mode", mode.String())
 				continue
 			}
 
@@ -269,6 +386,7 @@ func (s *ParallelScanner) scanDir(
 				childDir := &model.DirNode{
 					FileNode: model.FileNode{
 						Name:   name,
+						Mode:   info.Mode(),
 						Parent: parent,
 					},
 				}
@@ -282,12 +400,26 @@ func (s *ParallelScanner) scanDir(
 					continue
 				}
 
+				if opts.MaxDepth > 0 && depth+1 > opts.MaxDepth {
+					childDir.Flag |= model.FlagTruncated
+					continue
+				}
+
+				if opts.OneFileSystem {
+					si := getStatInfo(info)
+					if crossesFilesystemBoundary(rootDev, rootDevOK, si.dev, si.ok) && !crossMountAllowed(scanPath, opts.CrossMounts) {
+						childDir.Flag |= model.FlagMountSkipped
+						continue
+					}
+				}
+
 				spawnScan(scanPath, childDir)
 			} else if mode&os.ModeSymlink != 0 && opts.FollowSymlinks {
 				// Resolve symlink — if it points to a directory, recurse into it
 				resolvedPath, err := filepath.EvalSymlinks(fullPath)
 				if err != nil {
 					errCount.Add(1)
+					opts.ErrorCollector.add(fullPath, err)
 					parent.AddChild(model.NewBrokenSymlinkNode(name, parent))
 					filesScanned.Add(1)
 					continue
@@ -295,6 +427,7 @@ func (s *ParallelScanner) scanDir(
 				targetInfo, err := os.Stat(resolvedPath)
 				if err != nil {
 					errCount.Add(1)
+					opts.ErrorCollector.add(fullPath, err)
 					parent.AddChild(model.NewBrokenSymlinkNode(name, parent))
 					filesScanned.Add(1)
 					continue
@@ -302,10 +435,12 @@ func (s *ParallelScanner) scanDir(
 				if isSpecialMode(targetInfo.Mode()) {
 					continue
 				}
+				debugLog(opts.Logger, "followed symlink", "path", fullPath, "target", resolvedPath)
 				if targetInfo.IsDir() {
 					childDir := &model.DirNode{
 						FileNode: model.FileNode{
 							Name:   name,
+							Mode:   targetInfo.Mode(),
 							Mtime:  targetInfo.ModTime(),
 							Flag:   model.FlagSymlink,
 							Parent: parent,
@@ -324,6 +459,19 @@ func (s *ParallelScanner) scanDir(
 						continue
 					}
 
+					if opts.MaxDepth > 0 && depth+1 > opts.MaxDepth {
+						childDir.Flag |= model.FlagTruncated
+						continue
+					}
+
+					if opts.OneFileSystem {
+						si := getStatInfo(targetInfo)
+						if crossesFilesystemBoundary(rootDev, rootDevOK, si.dev, si.ok) && !crossMountAllowed(resolvedPath, opts.CrossMounts) {
+							childDir.Flag |= model.FlagMountSkipped
+							continue
+						}
+					}
+
 					spawnScan(resolvedPath, childDir)
 					continue
 				}
@@ -331,15 +479,22 @@ func (s *ParallelScanner) scanDir(
 				info := targetInfo
 
 				flag := model.FlagSymlink
+				if !opts.OlderThan.IsZero() && info.ModTime().Before(opts.OlderThan) {
+					flag |= model.FlagStale
+				}
 				si := getStatInfo(info)
 
 				if si.ok {
-					// Dedup: symlink target may alias a regular file (even with Nlink==1)
+					// Dedup: symlink target may alias a regular file (even with Nlink==1).
+					// CountSymlinkSizes opts out of the zeroing so each symlink
+					// contributes the target's full size, for "as if dereferenced" sizing.
 					inodeMu.Lock()
 					ik := inodeKey{dev: si.dev, ino: si.inode}
-					if _, seen := inodeMap[ik]; seen {
+					_, seen := inodeMap[ik]
+					inodeMap[ik] = struct{}{}
+					inodeMu.Unlock()
+					if seen && !opts.CountSymlinkSizes {
 						flag |= model.FlagHardlink
-						inodeMu.Unlock()
 						fileNode := &model.FileNode{
 							Name:   name,
 							Size:   0,
@@ -353,8 +508,10 @@ func (s *ParallelScanner) scanDir(
 						filesScanned.Add(1)
 						continue
 					}
-					inodeMap[ik] = struct{}{}
-					inodeMu.Unlock()
+				}
+
+				if isSparse(info.Size(), si.diskUsage) {
+					flag |= model.FlagSparse
 				}
 
 				fileNode := &model.FileNode{
@@ -363,6 +520,7 @@ func (s *ParallelScanner) scanDir(
 					Usage:  si.diskUsage,
 					Mtime:  info.ModTime(),
 					Inode:  si.inode,
+					Mode:   info.Mode(),
 					Flag:   flag,
 					Parent: parent,
 				}
@@ -370,10 +528,17 @@ func (s *ParallelScanner) scanDir(
 				filesScanned.Add(1)
 				bytesFound.Add(info.Size())
 			} else {
+				if opts.MinSize > 0 && mode.IsRegular() && info.Size() < opts.MinSize {
+					continue
+				}
+
 				var flag model.NodeFlag
 				if mode&os.ModeSymlink != 0 {
 					flag = model.FlagSymlink
 				}
+				if !opts.OlderThan.IsZero() && info.ModTime().Before(opts.OlderThan) {
+					flag |= model.FlagStale
+				}
 
 				si := getStatInfo(info)
 
@@ -402,12 +567,17 @@ func (s *ParallelScanner) scanDir(
 					inodeMu.Unlock()
 				}
 
+				if isSparse(info.Size(), si.diskUsage) {
+					flag |= model.FlagSparse
+				}
+
 				fileNode := &model.FileNode{
 					Name:   name,
 					Size:   info.Size(),
 					Usage:  si.diskUsage,
 					Mtime:  info.ModTime(),
 					Inode:  si.inode,
+					Mode:   info.Mode(),
 					Flag:   flag,
 					Parent: parent,
 				}
@@ -424,11 +594,58 @@ func (s *ParallelScanner) scanDir(
 		if readErr != nil {
 			parent.Flag |= model.FlagError
 			errCount.Add(1)
+			opts.ErrorCollector.add(dirPath, readErr)
 			return
 		}
 	}
 }
 
+// collapseDir sums the entire subtree rooted at dirPath into a single
+// synthetic child of parent instead of building a node per entry, for
+// directories that exceeded ScanOptions.MaxDirEntries. Subdirectories within
+// it are walked for their sizes but never materialized as nodes themselves.
+func collapseDir(ctx context.Context, dirPath string, parent *model.DirNode, filesScanned, bytesFound, errCount *atomic.Int64, errorCollector *ErrorCollector) {
+	parent.Flag |= model.FlagCollapsed
+
+	var size, usage, count int64
+	_ = filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			errCount.Add(1)
+			errorCollector.add(path, err)
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			errCount.Add(1)
+			return nil
+		}
+		si := getStatInfo(info)
+		size += info.Size()
+		usage += si.diskUsage
+		count++
+		return nil
+	})
+
+	filesScanned.Add(count)
+	bytesFound.Add(size)
+
+	parent.AddChild(&model.FileNode{
+		Name:   fmt.Sprintf("(%d collapsed entries)", count),
+		Size:   size,
+		Usage:  usage,
+		Flag:   model.FlagCollapsed,
+		Parent: parent,
+	})
+}
+
 func isWithin(root, target string) bool {
 	rel, err := filepath.Rel(root, target)
 	if err != nil {
@@ -440,6 +657,89 @@ func isWithin(root, target string) bool {
 	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
 
+// isMountRoot reports whether absPath is the root of its filesystem, by
+// comparing its device number against its parent directory's. The
+// filesystem root (whose parent is itself) is trivially a mount root.
+func isMountRoot(absPath string) bool {
+	parent := filepath.Dir(absPath)
+	if parent == absPath {
+		return true
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return false
+	}
+	parentInfo, err := os.Stat(parent)
+	if err != nil {
+		return false
+	}
+	return getStatInfo(info).dev != getStatInfo(parentInfo).dev
+}
+
+// crossesFilesystemBoundary reports whether a directory sits on a different
+// device than the scan root, the comparison OneFileSystem mode uses to
+// decide whether to stop descending. It returns false whenever either
+// device id is unknown (its ok is false), since there is then nothing
+// reliable to compare — notably on Windows, where getStatInfo never
+// populates dev.
+func crossesFilesystemBoundary(rootDev uint64, rootDevOK bool, dirDev uint64, dirDevOK bool) bool {
+	if !rootDevOK || !dirDevOK {
+		return false
+	}
+	return rootDev != dirDev
+}
+
+// crossMountAllowed reports whether path matches one of the CrossMounts
+// whitelist entries, either exactly or as a descendant, letting
+// OneFileSystem mode still descend into specific mounts the caller wants
+// counted despite being on a different device.
+func crossMountAllowed(path string, crossMounts []string) bool {
+	for _, m := range crossMounts {
+		if path == m || isWithin(m, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSparse reports whether a file's disk usage is dramatically smaller than
+// its apparent size, per model.SparseUsageRatio. Zero-size files are never
+// flagged: an empty file legitimately has zero usage.
+func isSparse(size, usage int64) bool {
+	if size <= 0 {
+		return false
+	}
+	return float64(usage) < float64(size)*model.SparseUsageRatio
+}
+
+// isGoduTempExport reports whether name matches the temp file pattern
+// ops.ExportJSON writes to (".godu-export-*.tmp") before atomically
+// renaming it into place.
+func isGoduTempExport(name string) bool {
+	return strings.HasPrefix(name, ".godu-export-") && strings.HasSuffix(name, ".tmp")
+}
+
 func isSpecialMode(mode os.FileMode) bool {
 	return mode&(os.ModeDevice|os.ModeCharDevice|os.ModeSocket|os.ModeNamedPipe|os.ModeIrregular) != 0
 }
+
+// isVanished reports whether err indicates that a directory entry existed
+// during ReadDir but was removed before it could be stat'd via Info() — a
+// race on live filesystems, not a real scan error.
+func isVanished(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// isDuplicateDirInode records ik as seen in inodeMap and reports whether it
+// had already been recorded by an earlier directory. Shared with the file
+// hardlink/symlink dedup map: inode numbers are unique per device regardless
+// of file type, so a directory and a file can never collide in it.
+func isDuplicateDirInode(inodeMap map[inodeKey]struct{}, inodeMu *sync.Mutex, ik inodeKey) bool {
+	inodeMu.Lock()
+	defer inodeMu.Unlock()
+	_, dup := inodeMap[ik]
+	if !dup {
+		inodeMap[ik] = struct{}{}
+	}
+	return dup
+}
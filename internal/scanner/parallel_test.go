@@ -1,18 +1,57 @@
 package scanner
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
 	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/sadopc/godu/internal/model"
 )
 
+func TestIsVanished(t *testing.T) {
+	if !isVanished(fs.ErrNotExist) {
+		t.Error("expected fs.ErrNotExist to be classified as vanished")
+	}
+	if !isVanished(fmt.Errorf("stat: %w", fs.ErrNotExist)) {
+		t.Error("expected a wrapped fs.ErrNotExist to be classified as vanished")
+	}
+	if isVanished(fs.ErrPermission) {
+		t.Error("expected fs.ErrPermission not to be classified as vanished")
+	}
+	if isVanished(nil) {
+		t.Error("expected nil error not to be classified as vanished")
+	}
+}
+
+func TestIsDuplicateDirInode(t *testing.T) {
+	var mu sync.Mutex
+	inodeMap := make(map[inodeKey]struct{})
+	ik := inodeKey{dev: 1, ino: 42}
+
+	if isDuplicateDirInode(inodeMap, &mu, ik) {
+		t.Error("expected first sighting not to be a duplicate")
+	}
+	if !isDuplicateDirInode(inodeMap, &mu, ik) {
+		t.Error("expected second sighting of the same (dev, ino) to be a duplicate")
+	}
+
+	other := inodeKey{dev: 1, ino: 43}
+	if isDuplicateDirInode(inodeMap, &mu, other) {
+		t.Error("expected a different inode not to be flagged as a duplicate")
+	}
+}
+
 func TestScan_CanceledContext_ReturnsError(t *testing.T) {
 	root := t.TempDir()
 	// Create some files to scan
@@ -40,6 +79,29 @@ func TestScan_CanceledContext_ReturnsError(t *testing.T) {
 	}
 }
 
+func TestScan_Timeout_ReturnsPartialTreeWithSentinelError(t *testing.T) {
+	root := t.TempDir()
+	for i := 0; i < 20; i++ {
+		sub := filepath.Join(root, "dir"+string(rune('a'+i)))
+		if err := os.Mkdir(sub, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(sub, "file.txt"), []byte("data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, Timeout: time.Nanosecond}, nil)
+
+	if !errors.Is(err, ErrScanTimedOut) {
+		t.Fatalf("expected ErrScanTimedOut, got %v", err)
+	}
+	if result == nil {
+		t.Fatal("expected non-nil partial root on timeout")
+	}
+}
+
 func TestScan_CanceledContext_WithProgressChannel_DoesNotPanic(t *testing.T) {
 	root := t.TempDir()
 
@@ -116,6 +178,53 @@ func TestScan_ShowHiddenFalse_SkipsHiddenEntries(t *testing.T) {
 	}
 }
 
+func TestScan_NoHiddenTop_HidesOnlyAtRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".hidden-top.txt"), []byte("h"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, ".hidden-nested.txt"), []byte("h"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, NoHiddenTop: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	rootNames := map[string]bool{}
+	var subNode model.TreeNode
+	for _, child := range result.GetChildren() {
+		rootNames[child.GetName()] = true
+		if child.GetName() == "sub" {
+			subNode = child
+		}
+	}
+	if rootNames[".hidden-top.txt"] {
+		t.Fatal("expected top-level hidden file to be skipped")
+	}
+	if subNode == nil {
+		t.Fatal("expected sub directory to be present")
+	}
+
+	subDir, ok := subNode.(*model.DirNode)
+	if !ok {
+		t.Fatal("expected sub to be a directory")
+	}
+	subNames := map[string]bool{}
+	for _, child := range subDir.GetChildren() {
+		subNames[child.GetName()] = true
+	}
+	if !subNames[".hidden-nested.txt"] {
+		t.Fatal("expected nested hidden file to still be shown")
+	}
+}
+
 func TestScan_FollowSymlinks_DedupsFileSymlinkAlias(t *testing.T) {
 	root := t.TempDir()
 	targetPath := filepath.Join(root, "target.txt")
@@ -171,6 +280,46 @@ func TestScan_FollowSymlinks_DedupsFileSymlinkAlias(t *testing.T) {
 	}
 }
 
+func TestScan_FollowSymlinks_CountSymlinkSizes_NoDedup(t *testing.T) {
+	root := t.TempDir()
+	targetPath := filepath.Join(root, "target.txt")
+	data := []byte("hello")
+	if err := os.WriteFile(targetPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	linkPath := filepath.Join(root, "alias.txt")
+	if err := os.Symlink("target.txt", linkPath); err != nil {
+		t.Skipf("symlink not available on this platform: %v", err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, FollowSymlinks: true, CountSymlinkSizes: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var targetNode, linkNode model.TreeNode
+	for _, child := range result.GetChildren() {
+		switch child.GetName() {
+		case "target.txt":
+			targetNode = child
+		case "alias.txt":
+			linkNode = child
+		}
+	}
+	if targetNode == nil || linkNode == nil {
+		t.Fatalf("expected both target and symlink nodes, got target=%v link=%v", targetNode != nil, linkNode != nil)
+	}
+
+	if targetNode.GetSize() != int64(len(data)) || linkNode.GetSize() != int64(len(data)) {
+		t.Fatalf("expected both nodes to report the full target size with CountSymlinkSizes, got target=%d link=%d", targetNode.GetSize(), linkNode.GetSize())
+	}
+	if linkNode.GetFlag()&model.FlagHardlink != 0 {
+		t.Fatal("expected symlink node not to be marked as a hardlink dedup alias with CountSymlinkSizes")
+	}
+}
+
 func TestScan_FollowSymlinks_BrokenSymlinkPlaceholder(t *testing.T) {
 	root := t.TempDir()
 	if err := os.Symlink("/definitely/missing/target", filepath.Join(root, "broken-link")); err != nil {
@@ -253,6 +402,43 @@ func TestScan_PermissionDeniedDir_FlagError(t *testing.T) {
 	}
 }
 
+func TestScan_ErrorCollector_RecordsPermissionDeniedPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("chmod 0o000 not effective on Windows")
+	}
+	if os.Getuid() == 0 {
+		t.Skip("running as root — permission checks are bypassed")
+	}
+
+	root := t.TempDir()
+	denied := filepath.Join(root, "noperm")
+	if err := os.Mkdir(denied, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(denied, 0o000); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chmod(denied, 0o755); err != nil {
+			t.Logf("cleanup chmod failed for %s: %v", denied, err)
+		}
+	})
+
+	collector := NewErrorCollector()
+	s := NewParallelScanner()
+	if _, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, ErrorCollector: collector}, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	errs := collector.Errors()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 collected error, got %d: %+v", len(errs), errs)
+	}
+	if errs[0].Path != denied {
+		t.Fatalf("expected error path %q, got %q", denied, errs[0].Path)
+	}
+}
+
 func TestScan_SkipsUnixSocket(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Unix sockets not available on Windows")
@@ -323,3 +509,537 @@ func TestScan_FollowSymlinks_SkipsSymlinkToUnixSocket(t *testing.T) {
 		}
 	}
 }
+
+func TestScan_MaxDirEntries_CollapsesOversizedDirectory(t *testing.T) {
+	root := t.TempDir()
+	big := filepath.Join(root, "big")
+	if err := os.Mkdir(big, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(filepath.Join(big, fmt.Sprintf("f%d.txt", i)), []byte("hello"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	small := filepath.Join(root, "small")
+	if err := os.Mkdir(small, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(small, "one.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, MaxDirEntries: 3}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var bigNode, smallNode model.TreeNode
+	for _, child := range result.GetChildren() {
+		switch child.GetName() {
+		case "big":
+			bigNode = child
+		case "small":
+			smallNode = child
+		}
+	}
+	if bigNode == nil || smallNode == nil {
+		t.Fatal("expected both big and small directories in results")
+	}
+
+	bigDir := bigNode.(*model.DirNode)
+	if bigDir.Flag&model.FlagCollapsed == 0 {
+		t.Fatal("expected big directory to be flagged collapsed")
+	}
+	children := bigDir.GetChildren()
+	if len(children) != 1 {
+		t.Fatalf("expected collapsed directory to have exactly 1 synthetic child, got %d", len(children))
+	}
+	if bigDir.GetSize() != 25 {
+		t.Fatalf("expected collapsed directory size 25 (5 files x 5 bytes), got %d", bigDir.GetSize())
+	}
+
+	smallDir := smallNode.(*model.DirNode)
+	if smallDir.Flag&model.FlagCollapsed != 0 {
+		t.Fatal("expected small directory to not be collapsed")
+	}
+	if len(smallDir.GetChildren()) != 1 {
+		t.Fatalf("expected small directory to keep its real child, got %d", len(smallDir.GetChildren()))
+	}
+}
+
+func TestScan_SkipsGoduTempExportFiles(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "real.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".godu-export-123456.tmp"), []byte("partial json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(result.GetChildren()) != 1 {
+		t.Fatalf("expected only the real file, got %d children", len(result.GetChildren()))
+	}
+	if result.GetChildren()[0].GetName() != "real.txt" {
+		t.Fatalf("expected real.txt to survive, got %q", result.GetChildren()[0].GetName())
+	}
+}
+
+func TestScan_SubdirectoryScan_NoFilesystemUsedBytes(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "hello.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	progressCh := make(chan Progress, 64)
+	s := NewParallelScanner()
+	if _, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true}, progressCh); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	close(progressCh)
+
+	var last Progress
+	for p := range progressCh {
+		last = p
+	}
+	if last.FilesystemUsedBytes != 0 {
+		t.Fatalf("expected no filesystem total for a subdirectory scan, got %d", last.FilesystemUsedBytes)
+	}
+	if _, ok := last.FilesystemPercent(); ok {
+		t.Fatal("expected FilesystemPercent to report unavailable for a subdirectory scan")
+	}
+}
+
+func TestScan_ReportsCurrentPath(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(nested, "hello.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	progressCh := make(chan Progress, 64)
+	s := NewParallelScanner()
+	if _, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true}, progressCh); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	close(progressCh)
+
+	var sawCurrentPath bool
+	for p := range progressCh {
+		if p.CurrentPath != "" {
+			sawCurrentPath = true
+		}
+	}
+	if !sawCurrentPath {
+		t.Fatal("expected at least one progress update with a non-empty CurrentPath")
+	}
+}
+
+func TestScan_Logger_RecordsExclusions(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "keep.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, "excluded-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	s := NewParallelScanner()
+	_, err := s.Scan(context.Background(), root, ScanOptions{
+		ShowHidden:      true,
+		ExcludePatterns: []string{"excluded-dir"},
+		Logger:          logger,
+	}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "excluded entry") {
+		t.Fatalf("expected logger to record the exclusion, got:\n%s", buf.String())
+	}
+}
+
+func TestScan_NoLogger_DoesNotPanic(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "keep.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	if _, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true}, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestScan_MinSize_ExcludesSmallFilesButKeepsDirectories(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "small.txt"), make([]byte, 1024), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "big.txt"), make([]byte, 4096), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "tiny.txt"), make([]byte, 1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, MinSize: 2 * 1024}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, child := range result.GetChildren() {
+		names[child.GetName()] = true
+	}
+	if names["small.txt"] {
+		t.Fatal("expected 1KiB file to be excluded by --min-size 2K")
+	}
+	if !names["big.txt"] {
+		t.Fatal("expected 4KiB file to survive --min-size 2K")
+	}
+	if !names["sub"] {
+		t.Fatal("expected directories to always be traversed regardless of --min-size")
+	}
+
+	sub, ok := findNodeByName(result, "sub").(*model.DirNode)
+	if !ok {
+		t.Fatal("expected sub to be a directory")
+	}
+	if len(sub.GetChildren()) != 0 {
+		t.Fatalf("expected tiny.txt inside sub to be excluded, got %v", sub.GetChildren())
+	}
+}
+
+func TestScan_OlderThan_FlagsStaleFilesOnly(t *testing.T) {
+	root := t.TempDir()
+	oldPath := filepath.Join(root, "old.txt")
+	newPath := filepath.Join(root, "new.txt")
+	if err := os.WriteFile(oldPath, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newPath, []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	oldMtime := now.Add(-60 * 24 * time.Hour)
+	if err := os.Chtimes(oldPath, oldMtime, oldMtime); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, OlderThan: now.Add(-30 * 24 * time.Hour)}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	oldNode := findNodeByName(result, "old.txt")
+	if oldNode == nil {
+		t.Fatal("expected old.txt to be present")
+	}
+	if oldNode.GetFlag()&model.FlagStale == 0 {
+		t.Fatal("expected old.txt to be flagged stale")
+	}
+
+	newNode := findNodeByName(result, "new.txt")
+	if newNode == nil {
+		t.Fatal("expected new.txt to be present")
+	}
+	if newNode.GetFlag()&model.FlagStale != 0 {
+		t.Fatal("expected new.txt not to be flagged stale")
+	}
+}
+
+func TestIsSparse_Threshold(t *testing.T) {
+	cases := []struct {
+		name       string
+		size       int64
+		usage      int64
+		wantSparse bool
+	}{
+		{"usage equals size", 1000, 1000, false},
+		{"usage just above half", 1000, 501, false},
+		{"usage exactly half", 1000, 500, false},
+		{"usage just below half", 1000, 499, true},
+		{"usage zero, size nonzero", 1000, 0, true},
+		{"empty file", 0, 0, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isSparse(c.size, c.usage); got != c.wantSparse {
+				t.Fatalf("isSparse(%d, %d) = %v, want %v", c.size, c.usage, got, c.wantSparse)
+			}
+		})
+	}
+}
+
+func TestScan_SparseFile_FlagsWhenUsageDramaticallyBelowSize(t *testing.T) {
+	root := t.TempDir()
+	sparsePath := filepath.Join(root, "sparse.img")
+
+	f, err := os.Create(sparsePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Seek past the end and write a single byte, leaving a hole: most
+	// filesystems won't allocate blocks for the untouched region.
+	if _, err := f.Seek(10*1024*1024, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	densePath := filepath.Join(root, "dense.txt")
+	if err := os.WriteFile(densePath, []byte("not sparse"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	denseNode := findNodeByName(result, "dense.txt")
+	if denseNode == nil {
+		t.Fatal("expected dense.txt to be present")
+	}
+	if denseNode.GetFlag()&model.FlagSparse != 0 {
+		t.Fatal("expected dense.txt not to be flagged sparse")
+	}
+
+	sparseNode := findNodeByName(result, "sparse.img")
+	if sparseNode == nil {
+		t.Fatal("expected sparse.img to be present")
+	}
+	if sparseNode.GetFlag()&model.FlagSparse == 0 {
+		t.Skip("filesystem does not support sparse files (no hole punching); skipping usage assertion")
+	}
+}
+
+func TestScan_MaxDepth_StopsDescendingPastLimit(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "sub", "nested"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "nested", "b.txt"), []byte("b"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, MaxDepth: 1}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	sub, ok := findNodeByName(result, "sub").(*model.DirNode)
+	if !ok {
+		t.Fatal("expected sub to be a directory")
+	}
+	if len(sub.GetChildren()) != 2 {
+		t.Fatalf("expected sub (depth 1) to have its own children populated, got %v", sub.GetChildren())
+	}
+
+	nested, ok := findNodeByName(sub, "nested").(*model.DirNode)
+	if !ok {
+		t.Fatal("expected nested to be a directory")
+	}
+	if len(nested.GetChildren()) != 0 {
+		t.Fatalf("expected nested (depth 2) to be left unscanned, got %v", nested.GetChildren())
+	}
+	if nested.GetFlag()&model.FlagTruncated == 0 {
+		t.Fatal("expected nested to be flagged as truncated")
+	}
+}
+
+func TestCrossesFilesystemBoundary_ComparesDeviceIDs(t *testing.T) {
+	tests := []struct {
+		name       string
+		rootDev    uint64
+		rootDevOK  bool
+		dirDev     uint64
+		dirDevOK   bool
+		wantResult bool
+	}{
+		{"same device", 1, true, 1, true, false},
+		{"different device", 1, true, 2, true, true},
+		{"root device unknown", 1, false, 2, true, false},
+		{"dir device unknown", 1, true, 2, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := crossesFilesystemBoundary(tt.rootDev, tt.rootDevOK, tt.dirDev, tt.dirDevOK); got != tt.wantResult {
+				t.Fatalf("crossesFilesystemBoundary(%d, %v, %d, %v) = %v, want %v", tt.rootDev, tt.rootDevOK, tt.dirDev, tt.dirDevOK, got, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestScan_OneFileSystem_DoesNotSkipDirectoriesOnSameDevice(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "sub", "nested"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "nested", "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, OneFileSystem: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	sub, ok := findNodeByName(result, "sub").(*model.DirNode)
+	if !ok {
+		t.Fatal("expected sub to be a directory")
+	}
+	nested, ok := findNodeByName(sub, "nested").(*model.DirNode)
+	if !ok {
+		t.Fatal("expected nested to be a directory")
+	}
+	if nested.GetFlag()&model.FlagMountSkipped != 0 {
+		t.Fatal("expected nested (same device as root) to not be flagged as mount-skipped")
+	}
+	if findNodeByName(nested, "a.txt") == nil {
+		t.Fatal("expected nested's contents to still be scanned")
+	}
+}
+
+func TestScan_RespectGitignore_SkipsMatchedEntriesIncludingNested(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "keep.txt"), []byte("k"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "debug.log"), []byte("d"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", ".gitignore"), []byte("build/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "other.log"), []byte("o"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "sub", "build"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "build", "artifact.bin"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "keep-sub.txt"), []byte("k"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, RespectGitignore: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if findNodeByName(result, "debug.log") != nil {
+		t.Fatal("expected debug.log to be skipped by the root .gitignore")
+	}
+	if findNodeByName(result, "keep.txt") == nil {
+		t.Fatal("expected keep.txt to remain")
+	}
+
+	sub, ok := findNodeByName(result, "sub").(*model.DirNode)
+	if !ok {
+		t.Fatal("expected sub to be a directory")
+	}
+	if findNodeByName(sub, "other.log") != nil {
+		t.Fatal("expected other.log to be skipped too: *.log in the root .gitignore applies recursively")
+	}
+	if findNodeByName(sub, "build") != nil {
+		t.Fatal("expected build/ to be skipped by sub's own .gitignore")
+	}
+	if findNodeByName(sub, "keep-sub.txt") == nil {
+		t.Fatal("expected keep-sub.txt to remain")
+	}
+}
+
+func TestScan_RespectGitignore_NegationReincludesEntry(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n!important.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "debug.log"), []byte("d"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "important.log"), []byte("i"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true, RespectGitignore: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if findNodeByName(result, "debug.log") != nil {
+		t.Fatal("expected debug.log to be skipped")
+	}
+	if findNodeByName(result, "important.log") == nil {
+		t.Fatal("expected important.log to be re-included by the negation pattern")
+	}
+}
+
+func TestScan_RespectGitignoreFalse_KeepsMatchingEntries(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "debug.log"), []byte("d"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewParallelScanner()
+	result, err := s.Scan(context.Background(), root, ScanOptions{ShowHidden: true}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if findNodeByName(result, "debug.log") == nil {
+		t.Fatal("expected debug.log to remain when RespectGitignore is disabled")
+	}
+}
+
+func findNodeByName(dir *model.DirNode, name string) model.TreeNode {
+	for _, c := range dir.GetChildren() {
+		if c.GetName() == name {
+			return c
+		}
+	}
+	return nil
+}
@@ -1,6 +1,18 @@
 package scanner
 
-import "time"
+import (
+	"os"
+	"time"
+)
+
+// DiskUsage returns the on-disk block usage reported by the platform's stat
+// call for info, falling back to the apparent size where that isn't
+// available. Exported so callers that need to stat a single file outside of
+// a full Scan (e.g. a streaming export processing entries one at a time)
+// get the same disk-usage accounting the scanner itself uses.
+func DiskUsage(info os.FileInfo) int64 {
+	return getStatInfo(info).diskUsage
+}
 
 // Progress reports scanning progress.
 type Progress struct {
@@ -12,12 +24,30 @@ type Progress struct {
 	BytesFound int64
 	// Errors is the count of errors encountered.
 	Errors int64
+	// Vanished is the count of entries that disappeared between being listed
+	// and being stat'd (a race on live filesystems, not a real error).
+	Vanished int64
 	// Done indicates scanning is complete.
 	Done bool
 	// StartTime is when the scan began.
 	StartTime time.Time
 	// Duration is elapsed time.
 	Duration time.Duration
+	// FilesystemUsedBytes is the used-space total reported by statfs for the
+	// scanned filesystem, captured once at scan start. A local scan only
+	// populates it when the scan root is a mount point; the SFTP scanner
+	// populates it for any remote path whose server answers StatVFS. 0 means
+	// unavailable (local subdirectory scan, or a platform/filesystem statfs
+	// couldn't report on).
+	FilesystemUsedBytes int64
+	// FilesystemTotalBytes is the filesystem's total capacity, captured
+	// alongside FilesystemUsedBytes under the same conditions; 0 means
+	// unavailable.
+	FilesystemTotalBytes int64
+	// CurrentPath is the directory most recently entered by the scanner, for
+	// showing where a slow scan is stuck. Empty until the first directory is
+	// opened.
+	CurrentPath string
 }
 
 // ItemsPerSecond returns the scan rate.
@@ -27,3 +57,25 @@ func (p Progress) ItemsPerSecond() float64 {
 	}
 	return float64(p.FilesScanned+p.DirsScanned) / p.Duration.Seconds()
 }
+
+// FilesystemPercent returns BytesFound as a percentage of
+// FilesystemUsedBytes, or false if that denominator isn't available (e.g. a
+// local subdirectory scan rather than a whole mount point).
+func (p Progress) FilesystemPercent() (float64, bool) {
+	if p.FilesystemUsedBytes <= 0 {
+		return 0, false
+	}
+	return float64(p.BytesFound) / float64(p.FilesystemUsedBytes) * 100, true
+}
+
+// FilesystemUsagePercent returns what percentage of a filesystem's total
+// capacity is used, given the total and free byte counts statfs reports.
+// ok is false when total isn't a usable denominator (zero/negative) or free
+// exceeds total.
+func FilesystemUsagePercent(total, free int64) (pct float64, ok bool) {
+	if total <= 0 || free < 0 || free > total {
+		return 0, false
+	}
+	used := total - free
+	return float64(used) / float64(total) * 100, true
+}
@@ -0,0 +1,33 @@
+package scanner
+
+import "testing"
+
+func TestFilesystemUsagePercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		free    int64
+		wantPct float64
+		wantOK  bool
+	}{
+		{"half used", 100, 50, 50, true},
+		{"all used", 100, 0, 100, true},
+		{"none used", 100, 100, 0, true},
+		{"zero total", 0, 0, 0, false},
+		{"negative total", -1, 0, 0, false},
+		{"negative free", 100, -1, 0, false},
+		{"free exceeds total", 100, 150, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pct, ok := FilesystemUsagePercent(tt.total, tt.free)
+			if ok != tt.wantOK {
+				t.Fatalf("FilesystemUsagePercent(%d, %d) ok = %v, want %v", tt.total, tt.free, ok, tt.wantOK)
+			}
+			if ok && pct != tt.wantPct {
+				t.Errorf("FilesystemUsagePercent(%d, %d) = %v, want %v", tt.total, tt.free, pct, tt.wantPct)
+			}
+		})
+	}
+}
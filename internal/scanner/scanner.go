@@ -2,22 +2,99 @@ package scanner
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"time"
 
 	"github.com/sadopc/godu/internal/model"
 )
 
+// ErrScanTimedOut is returned (wrapped) by a Scanner when a scan is stopped
+// early by ScanOptions.Timeout. The returned tree is a valid partial result
+// and callers may choose to use it instead of treating this as a fatal error.
+var ErrScanTimedOut = errors.New("scan timed out")
+
 // ScanOptions configures the scanner behavior.
 type ScanOptions struct {
 	// ShowHidden includes hidden files/directories (starting with .)
 	ShowHidden bool
+	// NoHiddenTop hides dotfiles/dotdirs only at the scan root, while still
+	// showing hidden entries nested inside non-hidden directories. Useful for
+	// home-directory scans where top-level clutter like .cache/.config is
+	// noise but a project's own .git deeper in the tree is still relevant.
+	// Has no effect when ShowHidden is already false.
+	NoHiddenTop bool
 	// FollowSymlinks follows symbolic links (default: false)
 	FollowSymlinks bool
-	// ExcludePatterns is a list of directory names to skip
+	// ExcludePatterns is a list of entry names to skip, for files and
+	// directories alike. Each pattern may be a plain name matched exactly
+	// or a shell glob understood by filepath.Match (e.g. "*.tmp").
 	ExcludePatterns []string
 	// DisableGC disables garbage collection during scan for speed
 	DisableGC bool
 	// Concurrency overrides the default semaphore count (0 = auto)
 	Concurrency int
+	// Timeout bounds the total scan duration. When it elapses, the scan
+	// stops early and returns the partial tree wrapped in ErrScanTimedOut
+	// instead of context.DeadlineExceeded. 0 means no limit.
+	Timeout time.Duration
+	// OneFileSystem stops the scan from descending into directories whose
+	// device id differs from the scan root's, the way `du -x` or `find
+	// -xdev` do. A directory skipped this way is still included in the
+	// tree (so it's visible that something is there) but is left
+	// childless and flagged with model.FlagMountSkipped. Has no effect on
+	// the SFTP scanner, which has no local device ids to compare.
+	OneFileSystem bool
+	// CrossMounts whitelists mount paths that should still be traversed
+	// even when OneFileSystem is set, for mounts the caller wants counted
+	// despite being on a different device (e.g. a bind mount of local
+	// disk under a different path).
+	CrossMounts []string
+	// CountSymlinkSizes makes followed symlinks (FollowSymlinks) contribute
+	// their target's full size to totals even if that target was already
+	// counted via another path, instead of being zeroed out as a dedup
+	// alias. This answers "how big would this be if dereferenced", which
+	// matters for backup sizing; it does not affect real hardlink dedup.
+	CountSymlinkSizes bool
+	// MaxDirEntries caps how many immediate entries a directory may have
+	// before it is collapsed: instead of a child node per entry, its whole
+	// subtree is summed into one synthetic node and model.FlagCollapsed is
+	// set, protecting scan time against pathologically large flat
+	// directories. 0 means unlimited.
+	MaxDirEntries int
+	// ErrorCollector, when set, records every path that failed to be read
+	// during the scan along with its error text, for callers that need more
+	// than the aggregate error count in Progress (e.g. --json-errors).
+	ErrorCollector *ErrorCollector
+	// Logger, when set, receives structured debug-level records for
+	// scan-time decisions (directories excluded, symlinks followed, special
+	// files skipped) — the detail needed to answer "why doesn't godu's total
+	// match du". Nil by default, in which case the scanner does no logging
+	// at all.
+	Logger *slog.Logger
+	// MinSize excludes regular files whose apparent size is below this many
+	// bytes from the scan results entirely; their bytes are not counted
+	// toward any ancestor directory's size either. Directories are always
+	// traversed regardless of this setting. 0 means unlimited (no filter).
+	MinSize int64
+	// MaxDepth stops descending into directories more than this many
+	// levels below the scan root, for a quick top-level overview of huge
+	// trees. A truncated directory still appears in the tree (so its name
+	// and place in the hierarchy are visible) but is left childless and
+	// flagged with model.FlagTruncated. 0 means unlimited (current
+	// behavior).
+	MaxDepth int
+	// RespectGitignore skips files and directories matched by .gitignore
+	// files encountered during traversal, honoring nested .gitignore files
+	// and "!" negation the way git itself would. Local scans only: the SFTP
+	// scanner has no efficient way to fetch and parse remote .gitignore
+	// files per directory, so it ignores this option.
+	RespectGitignore bool
+	// OlderThan, when non-zero, marks files whose mtime is before this
+	// instant with model.FlagStale. The tree is still scanned and built in
+	// full; nothing is excluded. Directories are never flagged stale
+	// themselves, only the files beneath them.
+	OlderThan time.Time
 }
 
 // DefaultOptions returns sensible defaults.
@@ -38,6 +115,33 @@ type Scanner interface {
 	Scan(ctx context.Context, path string, opts ScanOptions, progress chan<- Progress) (*model.DirNode, error)
 }
 
+// ScanTree is a convenience wrapper around ParallelScanner.Scan for callers
+// that just want the finished tree, with no progress reporting.
+func ScanTree(ctx context.Context, path string, opts ScanOptions) (*model.DirNode, error) {
+	return NewParallelScanner().Scan(ctx, path, opts, nil)
+}
+
+// ScanTreeWithProgress is a convenience wrapper around ParallelScanner.Scan
+// for callers that want progress updates without managing a channel and
+// reader goroutine themselves: onProgress is invoked from a separate
+// goroutine, one update at a time, including the final Done=true one, and
+// every call has returned before ScanTreeWithProgress returns.
+func ScanTreeWithProgress(ctx context.Context, path string, opts ScanOptions, onProgress func(Progress)) (*model.DirNode, error) {
+	progress := make(chan Progress, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for p := range progress {
+			onProgress(p)
+		}
+	}()
+
+	root, err := NewParallelScanner().Scan(ctx, path, opts, progress)
+	close(progress)
+	<-done
+	return root, err
+}
+
 // ScanResult wraps the result of a scan operation.
 type ScanResult struct {
 	Root *model.DirNode
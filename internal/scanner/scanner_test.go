@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanTree_ReturnsPopulatedTree(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := ScanTree(context.Background(), root, ScanOptions{ShowHidden: true})
+	if err != nil {
+		t.Fatalf("ScanTree: %v", err)
+	}
+	if len(tree.GetChildren()) != 1 {
+		t.Fatalf("expected 1 child, got %d", len(tree.GetChildren()))
+	}
+}
+
+func TestScanTreeWithProgress_CallbackFiresAndReportsDone(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var sawDone bool
+	var updates int
+	tree, err := ScanTreeWithProgress(context.Background(), root, ScanOptions{ShowHidden: true}, func(p Progress) {
+		updates++
+		if p.Done {
+			sawDone = true
+		}
+	})
+	if err != nil {
+		t.Fatalf("ScanTreeWithProgress: %v", err)
+	}
+	if len(tree.GetChildren()) != 1 {
+		t.Fatalf("expected 1 child, got %d", len(tree.GetChildren()))
+	}
+	if updates == 0 {
+		t.Fatal("expected onProgress to be called at least once")
+	}
+	if !sawDone {
+		t.Fatal("expected onProgress to be called with Done=true")
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package scanner
+
+import "golang.org/x/sys/unix"
+
+// getFilesystemStats reports the total and free byte capacity for the
+// filesystem containing path, via statfs. ok is false if the call fails or
+// reports numbers that can't be trusted (free exceeding total).
+func getFilesystemStats(path string) (total, free int64, ok bool) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, 0, false
+	}
+	t := st.Blocks * uint64(st.Bsize)
+	f := st.Bfree * uint64(st.Bsize)
+	if f > t {
+		return 0, 0, false
+	}
+	return int64(t), int64(f), true
+}
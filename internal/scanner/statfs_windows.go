@@ -0,0 +1,10 @@
+//go:build windows
+
+package scanner
+
+// getFilesystemStats is not implemented on Windows; percentage-of-disk
+// progress and the disk-usage header both fall back to the count-only
+// display.
+func getFilesystemStats(path string) (total, free int64, ok bool) {
+	return 0, 0, false
+}
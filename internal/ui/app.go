@@ -2,17 +2,24 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sadopc/godu/internal/clipboard"
+	"github.com/sadopc/godu/internal/launcher"
 	"github.com/sadopc/godu/internal/model"
 	"github.com/sadopc/godu/internal/ops"
 	"github.com/sadopc/godu/internal/scanner"
 	"github.com/sadopc/godu/internal/ui/components"
 	"github.com/sadopc/godu/internal/ui/style"
+	"github.com/sadopc/godu/internal/util"
 )
 
 // ViewMode represents the current view.
@@ -22,8 +29,19 @@ const (
 	ViewTree ViewMode = iota
 	ViewTreemap
 	ViewFileType
+	ViewLargest
+	ViewHardlinks
 )
 
+// largestFilesCount bounds how many files the largest-files view keeps and
+// displays, so the underlying heap walk stays O(log largestFilesCount) per
+// file regardless of tree size.
+const largestFilesCount = 100
+
+// sizeFloors are the thresholds cycled by the size-floor toggle. Files below
+// the active floor are hidden from sortedItems; 0 means no filtering.
+var sizeFloors = []int64{0, 1 << 20, 10 << 20, 100 << 20}
+
 // AppState represents the application state.
 type AppState int
 
@@ -31,8 +49,13 @@ const (
 	StateScanning AppState = iota
 	StateBrowsing
 	StateConfirmDelete
+	StateDeleting
 	StateHelp
 	StateExporting
+	StateJumpToPath
+	StateSearch
+	StateRename
+	StateUndoLog
 )
 
 // ScanDoneMsg is sent when scanning completes.
@@ -40,6 +63,18 @@ type ScanDoneMsg struct {
 	Root           *model.DirNode
 	Err            error
 	IncludedHidden bool
+
+	// FilesystemUsedBytes and FilesystemTotalBytes carry the scanned
+	// filesystem's capacity, when available (see scanner.Progress), for the
+	// disk-usage header. 0 means unavailable.
+	FilesystemUsedBytes  int64
+	FilesystemTotalBytes int64
+
+	// ImportTimestamp carries the exporting godu's scan time, read from the
+	// import file's ncdu header (see ops.ImportMeta), for the header to show
+	// "imported: <timestamp>". Zero when this wasn't an import, or the
+	// header had no timestamp.
+	ImportTimestamp time.Time
 }
 
 // ProgressMsg carries scanner progress updates.
@@ -49,6 +84,35 @@ type ProgressMsg scanner.Progress
 type DeleteDoneMsg struct {
 	Deleted []string
 	Errors  []error
+
+	// TrashedPaths maps a deleted item's name to where it landed in the
+	// trash, for items that were trashed rather than permanently deleted.
+	// Names with no entry here were permanently deleted.
+	TrashedPaths map[string]string
+}
+
+// RenameDoneMsg is sent when a rename completes.
+type RenameDoneMsg struct {
+	OldName string
+	NewName string
+	Err     error
+}
+
+// RestoreDoneMsg is sent when restoring the last trashed item completes.
+type RestoreDoneMsg struct {
+	Name        string
+	TrashedPath string
+	Err         error
+}
+
+// RefreshDirDoneMsg is sent when a single-directory refresh (RefreshDir)
+// completes. Dir is the tree node being refreshed, captured when the scan
+// started; NewNode is the freshly scanned replacement, whose children get
+// spliced under Dir.
+type RefreshDirDoneMsg struct {
+	Dir     *model.DirNode
+	NewNode *model.DirNode
+	Err     error
 }
 
 // ExportDoneMsg is sent when export completes.
@@ -57,16 +121,47 @@ type ExportDoneMsg struct {
 	Err  error
 }
 
+// SnapshotDoneMsg is sent when a text snapshot write completes.
+type SnapshotDoneMsg struct {
+	Path string
+	Err  error
+}
+
+// OpenFileManagerDoneMsg is sent when the OS file manager launch completes.
+type OpenFileManagerDoneMsg struct {
+	Err error
+}
+
 type tickMsg time.Time
 
 // App is the root Bubble Tea model.
 type App struct {
 	ScanPath    string
+	ScanPaths   []string
 	ScanOptions scanner.ScanOptions
-	ImportPath  string
+	ImportPaths []string
 	ExportPath  string
 	Version     string
 
+	// DangerThreshold, when > 0, colors an item's size bar with the theme's
+	// danger color instead of the usual gradient once it takes up this
+	// fraction of its parent's size (e.g. 0.5 = over half). 0 disables it,
+	// preserving the plain gradient look.
+	DangerThreshold float64
+
+	// MinDirSize, when > 0, prunes directories below this many bytes of disk
+	// usage from the tree as soon as a scan/import completes, via
+	// ops.PruneBelow. 0 disables pruning.
+	MinDirSize int64
+
+	// UseTrash routes deletions through ops.Trash (move to the platform
+	// trash/recycle bin) instead of ops.Delete (permanent removal).
+	UseTrash bool
+
+	// ReadOnly disables all deletion, independent of import mode, so a live
+	// scan can be browsed safely during demos or shared sessions.
+	ReadOnly bool
+
 	state    AppState
 	viewMode ViewMode
 	width    int
@@ -81,28 +176,154 @@ type App struct {
 	cursor int
 	offset int
 
+	// ftCursor indexes the selectable extension rows in ViewFileType.
+	// ftDrillExt is the extension currently drilled into (its files listed
+	// individually); empty means showing the category breakdown.
+	ftCursor   int
+	ftDrillExt string
+
 	marked      map[string]bool
 	markedItems []components.ConfirmItem
 
-	useApparent bool
-	showHidden  bool
-	imported    bool
+	sizeMode       model.SizeMode
+	showHidden     bool
+	showIcons      bool
+	showCounts     bool
+	treemapByCount bool
+	filesOnly      bool
+	staleOnly      bool
+	persistMarks   bool
+	imported       bool
+
+	// sizeFloorIdx cycles through sizeFloors to hide small files from
+	// sortedItems without rescanning. Directories are always shown.
+	sizeFloorIdx int
+
+	// percentOfRoot switches the tree view's percentage bar from
+	// parent-relative (the default) to root-relative, so items can be
+	// compared across different directories by their share of the whole scan.
+	percentOfRoot bool
+
+	// filterText narrows sortedItems to entries whose name contains it
+	// (case-insensitive). Built up a character at a time while in
+	// StateSearch; stays applied while browsing until cleared.
+	filterText string
 	// scanIncludedHidden tracks whether hidden entries were included in the
 	// currently loaded tree data (scan/import result), independent of UI filter.
 	scanIncludedHidden bool
 
+	// hiddenPeek overrides showHidden for individual directories, keyed by
+	// path, so a directory's dotfiles can be revealed temporarily without
+	// flipping the global ToggleHidden setting.
+	hiddenPeek map[string]bool
+
 	displayProgress  scanner.Progress
 	progressMu       sync.Mutex
 	incomingProgress scanner.Progress
 	scanCancel       context.CancelFunc
 	scanCancelMu     sync.Mutex
 
+	// deleteRemoved/deleteTotal track a permanent delete's progress, read by
+	// the tickMsg handler into displayDeleteRemoved the same way scan
+	// progress flows into displayProgress. deleteTotal is an estimate (the
+	// marked items' recursive ItemCount), since it's computed before the
+	// walk and the real filesystem can change underneath it.
+	displayDeleteRemoved int
+	deleteProgressMu     sync.Mutex
+	deleteRemoved        int
+	deleteTotal          int
+	deleteCancel         context.CancelFunc
+	deleteCancelMu       sync.Mutex
+
+	// expectedItems is the prior tree's total item count, used to draw a
+	// completion bar during a rescan. 0 for the initial scan, where no
+	// estimate is available yet.
+	expectedItems int64
+
 	theme  style.Theme
 	keys   KeyMap
 	layout style.Layout
 
 	statusMsg string
 	fatalErr  error
+
+	// deletedCount and freedBytes accumulate across every DeleteDoneMsg handled
+	// this session, for DeletionSummary.
+	deletedCount int
+	freedBytes   int64
+
+	// undoLog records every item deleted this session, most recent last, for
+	// the StateUndoLog panel and the Undo key's "restore last trashed item".
+	undoLog []components.UndoEntry
+
+	// fsUsedBytes and fsTotalBytes carry the scanned filesystem's capacity
+	// from the last ScanDoneMsg, for the disk-usage header. 0 means
+	// unavailable (subdirectory scan, import, or an unsupported platform).
+	fsUsedBytes  int64
+	fsTotalBytes int64
+
+	// importTimestamp carries the exporting godu's scan time for an imported
+	// tree, from the last ScanDoneMsg; zero when not imported or the import
+	// file had no timestamp. Shown in the header as "imported: <timestamp>".
+	importTimestamp time.Time
+
+	// pathInput backs the StateJumpToPath prompt opened by JumpToPath.
+	pathInput textinput.Model
+
+	// renameInput backs the StateRename prompt; renameTarget is the item
+	// being renamed, captured when the prompt opens so the submit handler
+	// still has it even though a.cursor or the tree could theoretically
+	// change first.
+	renameInput  textinput.Model
+	renameTarget model.TreeNode
+
+	// lastClickIdx and lastClickAt track the most recent left-click on a
+	// tree row, so a second click on the same row within doubleClickWindow
+	// is treated as a double-click (enter directory).
+	lastClickIdx int
+	lastClickAt  time.Time
+}
+
+// doubleClickWindow is the maximum gap between two clicks on the same row
+// for the second click to count as a double-click.
+const doubleClickWindow = 500 * time.Millisecond
+
+// treeContentRows is the number of fixed-height rows rendered above the
+// tree content area: header, breadcrumb, and tab bar (one line each).
+const treeContentRows = 3
+
+// rowIndexForY translates a mouse click's Y screen coordinate into a
+// sortedItems index, given the current scroll offset, content height, and
+// item count. It returns -1 if the click falls outside the tree rows.
+func rowIndexForY(y, offset, contentHeight, itemCount int) int {
+	row := y - treeContentRows
+	if row < 0 || row >= contentHeight {
+		return -1
+	}
+	idx := offset + row
+	if idx < 0 || idx >= itemCount {
+		return -1
+	}
+	return idx
+}
+
+// SetCapabilities overrides the theme's terminal capabilities (Unicode vs.
+// ASCII borders/bars), normally left at style.DefaultTheme's auto-detected
+// default. Exported because theme itself is unexported.
+func (a *App) SetCapabilities(caps style.Capabilities) {
+	a.theme.Caps = caps
+}
+
+// SetTheme overrides the color theme, normally left at style.DefaultTheme.
+// Exported because theme itself is unexported.
+func (a *App) SetTheme(theme style.Theme) {
+	a.theme = theme
+}
+
+// SetKeyMap overrides the key bindings, normally left at DefaultKeyMap.
+// Exported because keys itself is unexported.
+func (a *App) SetKeyMap(keys KeyMap) {
+	a.keys = keys
 }
 
 func (a *App) setScanCancel(cancel context.CancelFunc) {
@@ -119,6 +340,20 @@ func (a *App) callScanCancel() {
 	a.scanCancelMu.Unlock()
 }
 
+func (a *App) setDeleteCancel(cancel context.CancelFunc) {
+	a.deleteCancelMu.Lock()
+	a.deleteCancel = cancel
+	a.deleteCancelMu.Unlock()
+}
+
+func (a *App) callDeleteCancel() {
+	a.deleteCancelMu.Lock()
+	if a.deleteCancel != nil {
+		a.deleteCancel()
+	}
+	a.deleteCancelMu.Unlock()
+}
+
 // NewApp creates a new App model.
 func NewApp(scanPath string, opts scanner.ScanOptions) *App {
 	return &App{
@@ -128,33 +363,57 @@ func NewApp(scanPath string, opts scanner.ScanOptions) *App {
 		viewMode:           ViewTree,
 		sortConfig:         model.DefaultSort(),
 		marked:             make(map[string]bool),
-		useApparent:        false,
+		sizeMode:           model.SizeModeDisk,
 		showHidden:         opts.ShowHidden,
 		scanIncludedHidden: opts.ShowHidden,
+		hiddenPeek:         make(map[string]bool),
 		theme:              style.DefaultTheme(),
 		keys:               DefaultKeyMap(),
 	}
 }
 
-// NewAppFromImport creates an App that loads from a JSON file.
-func NewAppFromImport(importPath string) *App {
+// NewAppMulti creates an App that scans several local paths and presents
+// them as top-level entries under a synthetic root (see ops.MergeTrees), for
+// a combined view across multiple directories in one session.
+func NewAppMulti(scanPaths []string, opts scanner.ScanOptions) *App {
 	return &App{
-		ImportPath:         importPath,
+		ScanPaths:          scanPaths,
+		ScanOptions:        opts,
 		state:              StateScanning,
 		viewMode:           ViewTree,
 		sortConfig:         model.DefaultSort(),
 		marked:             make(map[string]bool),
-		useApparent:        false,
+		sizeMode:           model.SizeModeDisk,
+		showHidden:         opts.ShowHidden,
+		scanIncludedHidden: opts.ShowHidden,
+		hiddenPeek:         make(map[string]bool),
+		theme:              style.DefaultTheme(),
+		keys:               DefaultKeyMap(),
+	}
+}
+
+// NewAppFromImport creates an App that loads from one or more JSON files.
+// With more than one path, the imports are merged under a synthetic root
+// (see ops.MergeTrees) for fleet-wide comparison across hosts.
+func NewAppFromImport(importPaths ...string) *App {
+	return &App{
+		ImportPaths:        importPaths,
+		state:              StateScanning,
+		viewMode:           ViewTree,
+		sortConfig:         model.DefaultSort(),
+		marked:             make(map[string]bool),
+		sizeMode:           model.SizeModeDisk,
 		showHidden:         true,
 		imported:           true,
 		scanIncludedHidden: true,
+		hiddenPeek:         make(map[string]bool),
 		theme:              style.DefaultTheme(),
 		keys:               DefaultKeyMap(),
 	}
 }
 
 func (a *App) Init() tea.Cmd {
-	if a.ImportPath != "" {
+	if len(a.ImportPaths) > 0 {
 		return a.importCmd()
 	}
 	// Start both the scan AND the progress ticker simultaneously
@@ -167,22 +426,32 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.width = msg.Width
 		a.height = msg.Height
 		a.layout = style.NewLayout(msg.Width, msg.Height)
+		a.layout.ShowCounts = a.showCounts
 		return a, nil
 
 	case ScanDoneMsg:
-		if msg.Err != nil {
+		if msg.Err != nil && !errors.Is(msg.Err, scanner.ErrScanTimedOut) {
 			a.fatalErr = msg.Err
 			return a, tea.Quit
 		}
 		a.fatalErr = nil
 		a.scanIncludedHidden = msg.IncludedHidden
+		if a.MinDirSize > 0 {
+			ops.PruneBelow(msg.Root, a.MinDirSize)
+		}
 		a.root = msg.Root
 		a.currentDir = msg.Root
 		a.navStack = nil
 		a.cursor = 0
 		a.offset = 0
+		a.fsUsedBytes = msg.FilesystemUsedBytes
+		a.fsTotalBytes = msg.FilesystemTotalBytes
+		a.importTimestamp = msg.ImportTimestamp
 		a.state = StateBrowsing
 		a.refreshSorted()
+		if msg.Err != nil {
+			a.statusMsg = fmt.Sprintf("Scan stopped early: %v (showing partial results)", msg.Err)
+		}
 		return a, tea.ClearScreen
 
 	case tickMsg:
@@ -194,13 +463,35 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Keep ticking while scanning
 			return a, a.tickCmd()
 		}
+		if a.state == StateDeleting {
+			a.deleteProgressMu.Lock()
+			a.displayDeleteRemoved = a.deleteRemoved
+			a.deleteProgressMu.Unlock()
+			return a, a.tickCmd()
+		}
 		return a, nil
 
 	case DeleteDoneMsg:
+		sizeByName := make(map[string]int64, len(a.markedItems))
+		pathByName := make(map[string]string, len(a.markedItems))
+		for _, mi := range a.markedItems {
+			sizeByName[mi.Name] = mi.Size
+			pathByName[mi.Name] = mi.Path
+		}
 		for _, name := range msg.Deleted {
 			a.currentDir.RemoveChild(name)
+			a.freedBytes += sizeByName[name]
+			a.undoLog = append(a.undoLog, components.UndoEntry{
+				Name:        name,
+				Path:        pathByName[name],
+				Size:        sizeByName[name],
+				TrashedPath: msg.TrashedPaths[name],
+			})
 		}
+		a.deletedCount += len(msg.Deleted)
 		components.InvalidateFileTypeCache()
+		components.InvalidateLargestFilesCache()
+		components.InvalidateHardlinksCache()
 		a.state = StateBrowsing
 		a.clearMarks()
 		a.refreshSorted()
@@ -213,8 +504,76 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(msg.Errors) > 0 {
 			a.statusMsg = fmt.Sprintf("Delete: %d failed (%v)", len(msg.Errors), msg.Errors[0])
 		} else if len(msg.Deleted) > 0 {
-			a.statusMsg = fmt.Sprintf("Deleted %d item(s)", len(msg.Deleted))
+			if len(msg.TrashedPaths) > 0 {
+				a.statusMsg = fmt.Sprintf("Deleted %d item(s) (press u to undo)", len(msg.Deleted))
+			} else {
+				a.statusMsg = fmt.Sprintf("Deleted %d item(s)", len(msg.Deleted))
+			}
+		}
+		return a, tea.ClearScreen
+
+	case RestoreDoneMsg:
+		if msg.Err != nil {
+			a.statusMsg = fmt.Sprintf("Restore failed: %v", msg.Err)
+			return a, nil
+		}
+		for i, entry := range a.undoLog {
+			if entry.TrashedPath != "" && entry.TrashedPath == msg.TrashedPath {
+				a.undoLog = append(a.undoLog[:i], a.undoLog[i+1:]...)
+				break
+			}
+		}
+		a.statusMsg = fmt.Sprintf("Restored %s", msg.Name)
+		return a, a.refreshDirCmd()
+
+	case RenameDoneMsg:
+		if msg.Err != nil {
+			a.statusMsg = fmt.Sprintf("Rename failed: %v", msg.Err)
+			return a, nil
+		}
+		if a.renameTarget != nil {
+			switch n := a.renameTarget.(type) {
+			case *model.DirNode:
+				n.Name = msg.NewName
+			case *model.FileNode:
+				n.Name = msg.NewName
+			}
+		}
+		a.renameTarget = nil
+		components.InvalidateFileTypeCache()
+		components.InvalidateLargestFilesCache()
+		components.InvalidateHardlinksCache()
+		a.refreshSorted()
+		a.statusMsg = fmt.Sprintf("Renamed %q to %q", msg.OldName, msg.NewName)
+		return a, nil
+
+	case RefreshDirDoneMsg:
+		if msg.Err != nil {
+			a.statusMsg = fmt.Sprintf("Refresh failed: %v", msg.Err)
+			return a, nil
+		}
+		children := msg.NewNode.ReadChildren()
+		for _, c := range children {
+			switch n := c.(type) {
+			case *model.DirNode:
+				n.Parent = msg.Dir
+			case *model.FileNode:
+				n.Parent = msg.Dir
+			}
+		}
+		msg.Dir.SetChildren(children)
+		msg.Dir.PropagateSizeUpdate()
+		components.InvalidateFileTypeCache()
+		components.InvalidateLargestFilesCache()
+		components.InvalidateHardlinksCache()
+		a.refreshSorted()
+		if a.cursor >= len(a.sortedItems) {
+			a.cursor = len(a.sortedItems) - 1
+		}
+		if a.cursor < 0 {
+			a.cursor = 0
 		}
+		a.statusMsg = "Refreshed " + msg.Dir.Path()
 		return a, tea.ClearScreen
 
 	case ExportDoneMsg:
@@ -226,13 +585,57 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case SnapshotDoneMsg:
+		if msg.Err != nil {
+			a.statusMsg = fmt.Sprintf("Snapshot failed: %v", msg.Err)
+		} else {
+			a.statusMsg = fmt.Sprintf("Snapshot written to %s", msg.Path)
+		}
+		return a, nil
+
+	case OpenFileManagerDoneMsg:
+		if msg.Err != nil {
+			a.statusMsg = msg.Err.Error()
+		}
+		return a, nil
+
 	case tea.KeyMsg:
 		return a.handleKey(msg)
+
+	case tea.MouseMsg:
+		return a.handleMouse(tea.MouseEvent(msg))
 	}
 
 	return a, nil
 }
 
+// handleMouse translates a left click in the tree view into a cursor move,
+// or (on a double-click of the same row) an enter-directory action.
+func (a *App) handleMouse(msg tea.MouseEvent) (tea.Model, tea.Cmd) {
+	if a.state != StateBrowsing || a.viewMode != ViewTree {
+		return a, nil
+	}
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress {
+		return a, nil
+	}
+
+	idx := rowIndexForY(msg.Y, a.offset, a.layout.ContentHeight(), len(a.sortedItems))
+	if idx == -1 {
+		return a, nil
+	}
+
+	now := time.Now()
+	doubleClick := idx == a.lastClickIdx && now.Sub(a.lastClickAt) <= doubleClickWindow
+	a.lastClickIdx = idx
+	a.lastClickAt = now
+
+	a.cursor = idx
+	if doubleClick && a.sortedItems[idx].IsDir() {
+		a.enterDir()
+	}
+	return a, nil
+}
+
 func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if key.Matches(msg, a.keys.ForceQuit) {
 		a.callScanCancel()
@@ -264,12 +667,37 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case StateDeleting:
+		if key.Matches(msg, a.keys.Quit) || msg.String() == "esc" {
+			a.callDeleteCancel()
+		}
+		return a, nil
+
 	case StateExporting:
 		if key.Matches(msg, a.keys.Quit) {
 			return a, tea.Quit
 		}
 		return a, nil
 
+	case StateJumpToPath:
+		return a.handleJumpToPathKey(msg)
+
+	case StateSearch:
+		return a.handleSearchKey(msg)
+
+	case StateRename:
+		return a.handleRenameKey(msg)
+
+	case StateUndoLog:
+		if key.Matches(msg, a.keys.UndoLog) || msg.String() == "esc" {
+			a.state = StateBrowsing
+			return a, tea.ClearScreen
+		}
+		if key.Matches(msg, a.keys.Undo) {
+			return a, a.restoreLastTrashedCmd()
+		}
+		return a, nil
+
 	case StateBrowsing:
 		return a.handleBrowsingKey(msg)
 	}
@@ -277,6 +705,137 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+func (a *App) handleJumpToPathKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.state = StateBrowsing
+		a.pathInput.Blur()
+		return a, tea.ClearScreen
+	case "enter":
+		a.jumpToPath(a.pathInput.Value())
+		a.state = StateBrowsing
+		a.pathInput.Blur()
+		return a, tea.ClearScreen
+	}
+
+	var cmd tea.Cmd
+	a.pathInput, cmd = a.pathInput.Update(msg)
+	return a, cmd
+}
+
+// startRename opens the StateRename prompt for the item under the cursor,
+// pre-filled with its current name. It is a no-op in import mode, since an
+// imported tree's paths belong to whatever machine produced the export and
+// generally don't exist on this one.
+func (a *App) startRename() {
+	if a.imported {
+		a.statusMsg = "Rename is disabled in import mode"
+		return
+	}
+	if a.cursor >= len(a.sortedItems) {
+		return
+	}
+
+	a.renameTarget = a.sortedItems[a.cursor]
+	a.renameInput = textinput.New()
+	a.renameInput.Prompt = "Rename to: "
+	a.renameInput.SetValue(a.renameTarget.GetName())
+	a.renameInput.CursorEnd()
+	a.renameInput.Focus()
+	a.state = StateRename
+}
+
+func (a *App) handleRenameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.state = StateBrowsing
+		a.renameInput.Blur()
+		return a, tea.ClearScreen
+	case "enter":
+		cmd := a.renameCmd(a.renameTarget, a.renameInput.Value())
+		a.state = StateBrowsing
+		a.renameInput.Blur()
+		return a, tea.Batch(cmd, tea.ClearScreen)
+	}
+
+	var cmd tea.Cmd
+	a.renameInput, cmd = a.renameInput.Update(msg)
+	return a, cmd
+}
+
+// renameCmd runs ops.Rename for target off the UI goroutine and reports the
+// result as a RenameDoneMsg.
+func (a *App) renameCmd(target model.TreeNode, newName string) tea.Cmd {
+	if target == nil || newName == "" || newName == target.GetName() {
+		return nil
+	}
+	oldPath := target.Path()
+	rootPath := a.root.Path()
+
+	return func() tea.Msg {
+		_, err := ops.Rename(oldPath, newName, rootPath)
+		return RenameDoneMsg{OldName: target.GetName(), NewName: newName, Err: err}
+	}
+}
+
+// handleSearchKey builds up filterText one character at a time while in
+// StateSearch. Esc clears the filter and returns to browsing unfiltered;
+// Enter keeps it applied and returns to browsing.
+func (a *App) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		a.filterText = ""
+		a.state = StateBrowsing
+		a.refreshSorted()
+		return a, tea.ClearScreen
+	case tea.KeyEnter:
+		a.state = StateBrowsing
+		return a, tea.ClearScreen
+	case tea.KeyBackspace:
+		if len(a.filterText) > 0 {
+			a.filterText = a.filterText[:len(a.filterText)-1]
+			a.refreshSorted()
+		}
+		return a, nil
+	case tea.KeyRunes:
+		a.filterText += string(msg.Runes)
+		a.refreshSorted()
+		return a, nil
+	}
+	return a, nil
+}
+
+// jumpToPath resolves target against the scanned tree and, if found, makes
+// it the current directory, pushing the whole chain of intermediate
+// ancestors onto navStack so Back still walks out one level at a time.
+func (a *App) jumpToPath(target string) {
+	if target == "" {
+		return
+	}
+	dir, err := ops.FindByPath(a.root, target)
+	if err != nil {
+		a.statusMsg = err.Error()
+		return
+	}
+
+	var stack []*model.DirNode
+	for p := dir.Parent; p != nil; p = p.Parent {
+		stack = append(stack, p)
+	}
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+
+	a.navStack = stack
+	a.currentDir = dir
+	a.cursor = 0
+	a.offset = 0
+	if !a.persistMarks {
+		a.clearMarks()
+	}
+	a.refreshSorted()
+}
+
 func (a *App) handleBrowsingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	a.statusMsg = ""
 	switch {
@@ -288,13 +847,39 @@ func (a *App) handleBrowsingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, tea.ClearScreen
 
 	case key.Matches(msg, a.keys.Up):
-		a.moveCursor(-1)
+		if a.viewMode == ViewFileType {
+			a.moveFileTypeCursor(-1)
+		} else {
+			a.moveCursor(-1)
+		}
 	case key.Matches(msg, a.keys.Down):
-		a.moveCursor(1)
+		if a.viewMode == ViewFileType {
+			a.moveFileTypeCursor(1)
+		} else {
+			a.moveCursor(1)
+		}
+	case key.Matches(msg, a.keys.PageUp):
+		a.moveCursor(-(a.layout.ContentHeight() - 1))
+	case key.Matches(msg, a.keys.PageDown):
+		a.moveCursor(a.layout.ContentHeight() - 1)
+	case key.Matches(msg, a.keys.Home):
+		a.moveCursor(-len(a.sortedItems))
+	case key.Matches(msg, a.keys.End):
+		a.moveCursor(len(a.sortedItems))
 	case key.Matches(msg, a.keys.Enter), key.Matches(msg, a.keys.Right):
-		a.enterDir()
+		if a.viewMode == ViewFileType {
+			a.drillFileTypeExtension()
+		} else {
+			a.enterDir()
+		}
 	case key.Matches(msg, a.keys.Left), key.Matches(msg, a.keys.Back):
-		a.goBack()
+		if a.viewMode == ViewFileType && a.ftDrillExt != "" {
+			a.ftDrillExt = ""
+		} else {
+			a.goBack()
+		}
+	case key.Matches(msg, a.keys.JumpLargest):
+		a.enterLargestChild()
 
 	case key.Matches(msg, a.keys.ViewTree):
 		a.viewMode = ViewTree
@@ -304,6 +889,14 @@ func (a *App) handleBrowsingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, tea.ClearScreen
 	case key.Matches(msg, a.keys.ViewFileType):
 		a.viewMode = ViewFileType
+		a.ftCursor = 0
+		a.ftDrillExt = ""
+		return a, tea.ClearScreen
+	case key.Matches(msg, a.keys.ViewLargest):
+		a.viewMode = ViewLargest
+		return a, tea.ClearScreen
+	case key.Matches(msg, a.keys.ViewHardlinks):
+		a.viewMode = ViewHardlinks
 		return a, tea.ClearScreen
 
 	case key.Matches(msg, a.keys.SortSize):
@@ -315,9 +908,22 @@ func (a *App) handleBrowsingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, a.keys.SortMtime):
 		a.toggleSort(model.SortByMtime)
 
-	case key.Matches(msg, a.keys.ToggleApparent):
-		a.useApparent = !a.useApparent
+	case key.Matches(msg, a.keys.CycleSizeMode):
+		a.sizeMode = a.sizeMode.Next()
 		a.refreshSorted()
+		a.statusMsg = "Size: " + a.sizeMode.Label()
+	case key.Matches(msg, a.keys.ToggleIcons):
+		a.showIcons = !a.showIcons
+	case key.Matches(msg, a.keys.ToggleCounts):
+		a.showCounts = !a.showCounts
+		a.layout.ShowCounts = a.showCounts
+	case key.Matches(msg, a.keys.ToggleTreemapWeight):
+		a.treemapByCount = !a.treemapByCount
+		if a.treemapByCount {
+			a.statusMsg = "Treemap weighted by file count"
+		} else {
+			a.statusMsg = "Treemap weighted by size"
+		}
 	case key.Matches(msg, a.keys.ToggleHidden):
 		a.showHidden = !a.showHidden
 		a.clearMarks()
@@ -332,11 +938,71 @@ func (a *App) handleBrowsingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		a.refreshSorted()
 
+	case key.Matches(msg, a.keys.FilesOnly):
+		a.filesOnly = !a.filesOnly
+		a.refreshSorted()
+		if a.filesOnly {
+			a.statusMsg = "Showing files only"
+		} else {
+			a.statusMsg = "Showing files and directories"
+		}
+
+	case key.Matches(msg, a.keys.StaleOnly):
+		a.staleOnly = !a.staleOnly
+		a.refreshSorted()
+		if a.staleOnly {
+			a.statusMsg = "Showing stale files only"
+		} else {
+			a.statusMsg = "Showing all files"
+		}
+
+	case key.Matches(msg, a.keys.SizeFloor):
+		a.sizeFloorIdx = (a.sizeFloorIdx + 1) % len(sizeFloors)
+		a.refreshSorted()
+		if floor := sizeFloors[a.sizeFloorIdx]; floor > 0 {
+			a.statusMsg = "Hiding files smaller than " + util.FormatSize(floor)
+		} else {
+			a.statusMsg = "Showing files of all sizes"
+		}
+
+	case key.Matches(msg, a.keys.PersistMarks):
+		a.persistMarks = !a.persistMarks
+		if a.persistMarks {
+			a.statusMsg = "Marks persist across navigation"
+		} else {
+			a.statusMsg = "Marks clear on navigation"
+			a.clearMarks()
+		}
+
+	case key.Matches(msg, a.keys.TogglePercentOfRoot):
+		a.percentOfRoot = !a.percentOfRoot
+		if a.percentOfRoot {
+			a.statusMsg = "Percentages: of whole scan"
+		} else {
+			a.statusMsg = "Percentages: of current directory"
+		}
+
+	case key.Matches(msg, a.keys.PeekHidden):
+		if a.currentDir != nil {
+			if !a.scanIncludedHidden {
+				a.statusMsg = "Hidden files are not present in the current tree data"
+			} else {
+				path := a.currentDir.Path()
+				a.hiddenPeek[path] = !a.hiddenPeek[path]
+				a.refreshSorted()
+			}
+		}
+
 	case key.Matches(msg, a.keys.Mark):
 		if a.viewMode == ViewTree {
 			a.toggleMark()
 		}
 
+	case key.Matches(msg, a.keys.MarkTree):
+		if a.viewMode == ViewTree {
+			a.toggleMarkRecursive()
+		}
+
 	case key.Matches(msg, a.keys.Delete):
 		if a.viewMode == ViewTree {
 			cmd := a.prepareDelete()
@@ -349,6 +1015,9 @@ func (a *App) handleBrowsingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, a.keys.Export):
 		return a, a.exportCmd()
 
+	case key.Matches(msg, a.keys.Snapshot):
+		return a, a.snapshotCmd()
+
 	case key.Matches(msg, a.keys.Rescan):
 		if a.imported {
 			a.statusMsg = "Rescan is disabled in import mode"
@@ -356,6 +1025,55 @@ func (a *App) handleBrowsingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		a.clearMarks()
 		return a, a.startRescan()
+
+	case key.Matches(msg, a.keys.RefreshDir):
+		return a, a.refreshDirCmd()
+
+	case key.Matches(msg, a.keys.JumpToPath):
+		a.pathInput = textinput.New()
+		a.pathInput.Prompt = ":cd "
+		a.pathInput.SetValue(a.currentDir.Path())
+		a.pathInput.CursorEnd()
+		a.pathInput.Focus()
+		a.state = StateJumpToPath
+		return a, nil
+
+	case key.Matches(msg, a.keys.Search):
+		if a.viewMode == ViewTree {
+			a.state = StateSearch
+		}
+		return a, nil
+
+	case key.Matches(msg, a.keys.CopyPath):
+		if a.viewMode == ViewTree && a.cursor < len(a.sortedItems) {
+			p := a.sortedItems[a.cursor].Path()
+			if err := clipboard.Copy(p); err != nil {
+				a.statusMsg = err.Error()
+			} else {
+				a.statusMsg = "Copied " + p
+			}
+		}
+		return a, nil
+
+	case key.Matches(msg, a.keys.OpenInFileManager):
+		if a.viewMode == ViewTree {
+			return a, a.openInFileManager()
+		}
+		return a, nil
+
+	case key.Matches(msg, a.keys.Rename):
+		if a.viewMode == ViewTree {
+			a.startRename()
+			return a, tea.ClearScreen
+		}
+		return a, nil
+
+	case key.Matches(msg, a.keys.Undo):
+		return a, a.restoreLastTrashedCmd()
+
+	case key.Matches(msg, a.keys.UndoLog):
+		a.state = StateUndoLog
+		return a, tea.ClearScreen
 	}
 
 	return a, nil
@@ -368,15 +1086,21 @@ func (a *App) View() string {
 
 	switch a.state {
 	case StateScanning:
-		return components.RenderScanProgress(a.theme, a.displayProgress, a.width, a.height)
+		return components.RenderScanProgress(a.theme, a.displayProgress, a.expectedItems, a.width, a.height)
 
 	case StateHelp:
-		return components.RenderHelp(a.theme, a.width, a.height)
+		return components.RenderHelp(a.theme, a.width, a.height, a.ReadOnly)
 
 	case StateConfirmDelete:
 		return components.RenderConfirmDialog(a.theme, a.markedItems, a.width, a.height)
 
-	case StateBrowsing, StateExporting:
+	case StateDeleting:
+		return components.RenderDeleteProgress(a.theme, a.displayDeleteRemoved, a.deleteTotal, a.width, a.height)
+
+	case StateUndoLog:
+		return components.RenderUndoLog(a.theme, a.undoLog, a.width, a.height)
+
+	case StateBrowsing, StateExporting, StateJumpToPath, StateSearch, StateRename:
 		return a.renderBrowsing()
 	}
 
@@ -384,32 +1108,50 @@ func (a *App) View() string {
 }
 
 func (a *App) renderBrowsing() string {
-	header := components.RenderHeader(a.theme, a.root, a.useApparent, a.width)
-	breadcrumb := components.RenderBreadcrumb(a.theme, a.currentDir, a.width)
+	header := components.RenderHeader(a.theme, a.root, a.sizeMode, a.width, a.fsUsedBytes, a.fsTotalBytes)
+	importedAt := time.Time{}
+	if a.imported {
+		importedAt = a.importTimestamp
+	}
+	breadcrumb := components.RenderBreadcrumb(a.theme, a.currentDir, a.width, importedAt)
 	tabBar := components.RenderTabBar(a.theme, int(a.viewMode), a.sortConfig.Field, a.width)
 
 	var content string
 	switch a.viewMode {
 	case ViewTree:
 		tv := &components.TreeView{
-			Theme:       a.theme,
-			Layout:      a.layout,
-			Items:       a.sortedItems,
-			Cursor:      a.cursor,
-			Offset:      a.offset,
-			Marked:      a.marked,
-			UseApparent: a.useApparent,
-			ParentSize:  a.getParentSize(),
+			Theme:           a.theme,
+			Layout:          a.layout,
+			Items:           a.sortedItems,
+			Cursor:          a.cursor,
+			Offset:          a.offset,
+			Marked:          a.marked,
+			SizeMode:        a.sizeMode,
+			ParentSize:      a.getParentSize(),
+			ShowIcons:       a.showIcons,
+			ShowCounts:      a.showCounts,
+			DangerThreshold: a.DangerThreshold,
 		}
 		tv.EnsureVisible()
 		a.offset = tv.Offset
 		content = tv.Render()
 
 	case ViewTreemap:
-		content = components.RenderTreemap(a.theme, a.currentDir, a.useApparent, a.showHidden, a.layout.ContentWidth(), a.layout.ContentHeight())
+		content = components.RenderTreemap(a.theme, a.currentDir, a.sizeMode, a.showHidden, a.treemapByCount, a.layout.ContentWidth(), a.layout.ContentHeight())
 
 	case ViewFileType:
-		content = components.RenderFileTypes(a.theme, a.currentDir, a.useApparent, a.showHidden, a.layout.ContentWidth(), a.layout.ContentHeight())
+		if a.ftDrillExt != "" {
+			files := components.CollectFilesByExtension(a.currentDir, a.ftDrillExt, a.sizeMode, a.showHidden)
+			content = components.RenderExtensionFiles(a.theme, a.ftDrillExt, files, a.layout.ContentWidth(), a.layout.ContentHeight())
+		} else {
+			content = components.RenderFileTypes(a.theme, a.currentDir, a.sizeMode, a.showHidden, a.layout.ContentWidth(), a.layout.ContentHeight(), a.ftCursor)
+		}
+
+	case ViewLargest:
+		content = components.RenderLargestFiles(a.theme, a.root, a.sizeMode == model.SizeModeApparent, largestFilesCount, a.layout.ContentWidth(), a.layout.ContentHeight())
+
+	case ViewHardlinks:
+		content = components.RenderHardlinks(a.theme, a.root, a.sizeMode == model.SizeModeApparent, a.layout.ContentWidth(), a.layout.ContentHeight())
 	}
 
 	statusInfo := components.StatusInfo{
@@ -417,14 +1159,28 @@ func (a *App) renderBrowsing() string {
 		ItemCount:      len(a.sortedItems),
 		MarkedCount:    len(a.marked),
 		UsageEstimated: a.root != nil && (a.root.GetFlag()&model.FlagUsageEstimated != 0),
-		UseApparent:    a.useApparent,
+		SizeMode:       a.sizeMode,
 		ShowHidden:     a.showHidden,
+		FilesOnly:      a.filesOnly,
+		MinSizeFloor:   sizeFloors[a.sizeFloorIdx],
+		PersistMarks:   a.persistMarks,
+		ReadOnly:       a.ReadOnly,
+		FilterText:     a.filterText,
 		SortField:      a.sortConfig.Field,
 		ViewMode:       int(a.viewMode),
 		ErrorMsg:       a.statusMsg,
 	}
-	statusInfo.MarkedSize = a.markedSize(a.sortedItems)
+	statusInfo.MarkedSizeApparent, statusInfo.MarkedSizeDisk = a.markedSize()
 	statusBar := components.RenderStatusBar(a.theme, statusInfo, a.width)
+	if a.state == StateJumpToPath {
+		statusBar = a.theme.StatusBarStyle.Width(a.width).Render(" " + a.pathInput.View())
+	}
+	if a.state == StateSearch {
+		statusBar = a.theme.StatusBarStyle.Width(a.width).Render(" /" + a.filterText)
+	}
+	if a.state == StateRename {
+		statusBar = a.theme.StatusBarStyle.Width(a.width).Render(" " + a.renameInput.View())
+	}
 
 	return header + "\n" + breadcrumb + "\n" + tabBar + "\n" + content + "\n" + statusBar
 }
@@ -452,11 +1208,74 @@ func (a *App) enterDir() {
 		a.currentDir = dir
 		a.cursor = 0
 		a.offset = 0
-		a.clearMarks()
+		if !a.persistMarks {
+			a.clearMarks()
+		}
 		a.refreshSorted()
 	}
 }
 
+// moveFileTypeCursor moves the selectable-extension-row cursor in the
+// ViewFileType category breakdown by delta, clamped to the available rows.
+func (a *App) moveFileTypeCursor(delta int) {
+	if a.ftDrillExt != "" {
+		return
+	}
+	_, ok := components.FileTypeExtensionAt(a.currentDir, a.sizeMode, a.showHidden, a.ftCursor+delta)
+	if !ok && delta > 0 {
+		return
+	}
+	a.ftCursor += delta
+	if a.ftCursor < 0 {
+		a.ftCursor = 0
+	}
+}
+
+// drillFileTypeExtension enters the files-of-one-extension list for the
+// extension currently selected by ftCursor in the ViewFileType breakdown.
+func (a *App) drillFileTypeExtension() {
+	if a.ftDrillExt != "" {
+		return
+	}
+	ext, ok := components.FileTypeExtensionAt(a.currentDir, a.sizeMode, a.showHidden, a.ftCursor)
+	if !ok {
+		return
+	}
+	a.ftDrillExt = ext
+}
+
+// enterLargestChild enters the current directory's largest subdirectory by
+// disk usage, for the JumpLargest keybinding's dive-to-the-biggest-consumer
+// shortcut. Pressing it repeatedly dives one level further down the
+// largest-child chain each time, since it always operates on the new
+// a.currentDir.
+func (a *App) enterLargestChild() {
+	if a.currentDir == nil {
+		return
+	}
+	var largest *model.DirNode
+	for _, child := range a.currentDir.GetChildren() {
+		dir, ok := child.(*model.DirNode)
+		if !ok {
+			continue
+		}
+		if largest == nil || dir.GetUsage() > largest.GetUsage() {
+			largest = dir
+		}
+	}
+	if largest == nil {
+		return
+	}
+	a.navStack = append(a.navStack, a.currentDir)
+	a.currentDir = largest
+	a.cursor = 0
+	a.offset = 0
+	if !a.persistMarks {
+		a.clearMarks()
+	}
+	a.refreshSorted()
+}
+
 func (a *App) goBack() {
 	if len(a.navStack) == 0 {
 		return
@@ -466,7 +1285,9 @@ func (a *App) goBack() {
 
 	leavingName := a.currentDir.Name
 	a.currentDir = prev
-	a.clearMarks()
+	if !a.persistMarks {
+		a.clearMarks()
+	}
 	a.refreshSorted()
 
 	for i, item := range a.sortedItems {
@@ -505,6 +1326,49 @@ func (a *App) toggleMark() {
 	a.moveCursor(1)
 }
 
+// toggleMarkRecursive marks (or unmarks) every file under the directory
+// subtree at the cursor, rather than just the directory node itself.
+// Directories below it are left out of a.marked, since deletion and sizing
+// of a directory is already derived by resolving and summing its files. If
+// the cursor is on a file, it falls back to the plain single-item toggle.
+func (a *App) toggleMarkRecursive() {
+	if a.cursor >= len(a.sortedItems) {
+		return
+	}
+	item := a.sortedItems[a.cursor]
+	dir, ok := item.(*model.DirNode)
+	if !ok {
+		a.toggleMark()
+		return
+	}
+
+	var paths []string
+	allMarked := true
+	model.Walk(dir, func(n model.TreeNode) {
+		if n.IsDir() {
+			return
+		}
+		paths = append(paths, n.Path())
+		if !a.marked[n.Path()] {
+			allMarked = false
+		}
+	})
+
+	if len(paths) == 0 {
+		a.statusMsg = "No files to mark under " + dir.GetName()
+		return
+	}
+
+	for _, p := range paths {
+		if allMarked {
+			delete(a.marked, p)
+		} else {
+			a.marked[p] = true
+		}
+	}
+	a.moveCursor(1)
+}
+
 func (a *App) clearMarks() {
 	a.marked = make(map[string]bool)
 }
@@ -516,7 +1380,7 @@ func (a *App) refreshSorted() {
 	}
 	children := a.currentDir.GetChildren()
 
-	if !a.showHidden {
+	if !a.showHidden && !a.hiddenPeek[a.currentDir.Path()] {
 		var filtered []model.TreeNode
 		for _, c := range children {
 			if len(c.GetName()) > 0 && c.GetName()[0] != '.' {
@@ -526,23 +1390,75 @@ func (a *App) refreshSorted() {
 		children = filtered
 	}
 
-	model.SortChildren(children, a.sortConfig, a.useApparent)
+	if a.filesOnly {
+		var filtered []model.TreeNode
+		for _, c := range children {
+			if !c.IsDir() {
+				filtered = append(filtered, c)
+			}
+		}
+		children = filtered
+	}
+
+	if a.staleOnly {
+		var filtered []model.TreeNode
+		for _, c := range children {
+			if !c.IsDir() && c.GetFlag()&model.FlagStale != 0 {
+				filtered = append(filtered, c)
+			}
+		}
+		children = filtered
+	}
+
+	if a.filterText != "" {
+		needle := strings.ToLower(a.filterText)
+		var filtered []model.TreeNode
+		for _, c := range children {
+			if strings.Contains(strings.ToLower(c.GetName()), needle) {
+				filtered = append(filtered, c)
+			}
+		}
+		children = filtered
+	}
+
+	if floor := sizeFloors[a.sizeFloorIdx]; floor > 0 {
+		var filtered []model.TreeNode
+		for _, c := range children {
+			if c.IsDir() || a.sizeMode.Size(c) >= floor {
+				filtered = append(filtered, c)
+			}
+		}
+		children = filtered
+	}
+
+	model.SortChildren(children, a.sortConfig, a.sizeMode)
 	a.sortedItems = children
+
+	if a.cursor >= len(a.sortedItems) {
+		a.cursor = 0
+		a.offset = 0
+	}
 }
 
+// getParentSize returns the percentage-bar denominator for the tree view:
+// the current directory's size normally, or the scanned root's size when
+// percentOfRoot is enabled, so items can be compared across directories.
 func (a *App) getParentSize() int64 {
+	if a.percentOfRoot && a.root != nil {
+		return a.sizeMode.Size(a.root)
+	}
 	if a.currentDir == nil {
 		return 0
 	}
-	if a.useApparent {
-		return a.currentDir.GetSize()
-	}
-	return a.currentDir.GetUsage()
+	return a.sizeMode.Size(a.currentDir)
 }
 
 // scanCmd runs the directory scan in a background goroutine.
 // Progress is communicated via a.incomingProgress (mutex-protected).
 func (a *App) scanCmd() tea.Cmd {
+	if len(a.ScanPaths) > 1 {
+		return a.scanMultiCmd()
+	}
 	return func() tea.Msg {
 		opts := a.ScanOptions
 		ctx, cancel := context.WithCancel(context.Background())
@@ -551,8 +1467,13 @@ func (a *App) scanCmd() tea.Cmd {
 
 		progressCh := make(chan scanner.Progress, 10)
 
-		// Relay progress updates to shared state (read by tickMsg handler)
+		// Relay progress updates to shared state (read by tickMsg handler).
+		// relayDone signals once every message (including the final one) has
+		// been applied, so the scan's filesystem stats can be read back
+		// below without racing the relay goroutine.
+		relayDone := make(chan struct{})
 		go func() {
+			defer close(relayDone)
 			for p := range progressCh {
 				a.progressMu.Lock()
 				a.incomingProgress = p
@@ -563,15 +1484,107 @@ func (a *App) scanCmd() tea.Cmd {
 		s := scanner.NewParallelScanner()
 		root, err := s.Scan(ctx, a.ScanPath, opts, progressCh)
 		close(progressCh)
+		<-relayDone
+
+		a.progressMu.Lock()
+		finalProgress := a.incomingProgress
+		a.progressMu.Unlock()
+
+		return ScanDoneMsg{
+			Root:                 root,
+			Err:                  err,
+			IncludedHidden:       opts.ShowHidden,
+			FilesystemUsedBytes:  finalProgress.FilesystemUsedBytes,
+			FilesystemTotalBytes: finalProgress.FilesystemTotalBytes,
+		}
+	}
+}
+
+// scanMultiCmd scans each of a.ScanPaths in turn and merges the results
+// under a synthetic root (see ops.MergeTrees), so they can be browsed as
+// top-level entries in one session. Progress is reported cumulatively across
+// all paths so the bar doesn't appear to go backwards between them.
+func (a *App) scanMultiCmd() tea.Cmd {
+	return func() tea.Msg {
+		opts := a.ScanOptions
+		ctx, cancel := context.WithCancel(context.Background())
+		defer a.setScanCancel(nil)
+		a.setScanCancel(cancel)
+
+		s := scanner.NewParallelScanner()
+		roots := make([]*model.DirNode, 0, len(a.ScanPaths))
+		var filesBase, dirsBase, bytesBase, errBase int64
+		var fsUsed, fsTotal int64
+
+		for _, path := range a.ScanPaths {
+			subCh := make(chan scanner.Progress, 10)
+			base := scanner.Progress{FilesScanned: filesBase, DirsScanned: dirsBase, BytesFound: bytesBase, Errors: errBase}
+			relayDone := make(chan struct{})
+			go func(base scanner.Progress) {
+				defer close(relayDone)
+				for p := range subCh {
+					p.FilesScanned += base.FilesScanned
+					p.DirsScanned += base.DirsScanned
+					p.BytesFound += base.BytesFound
+					p.Errors += base.Errors
+					a.progressMu.Lock()
+					a.incomingProgress = p
+					a.progressMu.Unlock()
+				}
+			}(base)
+
+			root, err := s.Scan(ctx, path, opts, subCh)
+			close(subCh)
+			<-relayDone
+			if err != nil {
+				return ScanDoneMsg{Err: fmt.Errorf("scanning %s: %w", path, err)}
+			}
+			roots = append(roots, root)
+
+			a.progressMu.Lock()
+			p := a.incomingProgress
+			a.progressMu.Unlock()
+			filesBase, dirsBase, bytesBase, errBase = p.FilesScanned, p.DirsScanned, p.BytesFound, p.Errors
+			if p.FilesystemUsedBytes > fsUsed {
+				fsUsed = p.FilesystemUsedBytes
+			}
+			if p.FilesystemTotalBytes > fsTotal {
+				fsTotal = p.FilesystemTotalBytes
+			}
+		}
 
-		return ScanDoneMsg{Root: root, Err: err, IncludedHidden: opts.ShowHidden}
+		return ScanDoneMsg{
+			Root:                 ops.MergeTrees(roots...),
+			IncludedHidden:       opts.ShowHidden,
+			FilesystemUsedBytes:  fsUsed,
+			FilesystemTotalBytes: fsTotal,
+		}
 	}
 }
 
 func (a *App) importCmd() tea.Cmd {
 	return func() tea.Msg {
-		root, err := ops.ImportJSON(a.ImportPath)
-		return ScanDoneMsg{Root: root, Err: err, IncludedHidden: true}
+		if len(a.ImportPaths) == 1 {
+			root, meta, err := ops.ImportJSONWithMeta(a.ImportPaths[0])
+			return ScanDoneMsg{Root: root, Err: err, IncludedHidden: true, ImportTimestamp: meta.Timestamp}
+		}
+
+		// Merging multiple files has no single scan time; show the first
+		// file's, since that's the one the user named first on the command
+		// line.
+		var firstTimestamp time.Time
+		roots := make([]*model.DirNode, 0, len(a.ImportPaths))
+		for i, path := range a.ImportPaths {
+			root, meta, err := ops.ImportJSONWithMeta(path)
+			if err != nil {
+				return ScanDoneMsg{Err: fmt.Errorf("importing %s: %w", path, err)}
+			}
+			if i == 0 {
+				firstTimestamp = meta.Timestamp
+			}
+			roots = append(roots, root)
+		}
+		return ScanDoneMsg{Root: ops.MergeTrees(roots...), IncludedHidden: true, ImportTimestamp: firstTimestamp}
 	}
 }
 
@@ -582,6 +1595,10 @@ func (a *App) tickCmd() tea.Cmd {
 }
 
 func (a *App) prepareDelete() tea.Cmd {
+	if a.ReadOnly {
+		a.statusMsg = "Delete is disabled in read-only mode"
+		return nil
+	}
 	if a.imported {
 		a.statusMsg = "Delete is disabled in import mode"
 		return nil
@@ -594,25 +1611,14 @@ func (a *App) prepareDelete() tea.Cmd {
 
 	if len(a.marked) > 0 {
 		for markedPath := range a.marked {
-			for _, item := range a.sortedItems {
-				if item.Path() == markedPath {
-					items = append(items, components.ConfirmItem{
-						Name:  item.GetName(),
-						Path:  item.Path(),
-						Size:  item.GetSize(),
-						IsDir: item.IsDir(),
-					})
-				}
+			item, err := ops.FindNodeByPath(a.root, markedPath)
+			if err != nil {
+				continue
 			}
+			items = append(items, confirmItemFor(item))
 		}
 	} else if a.cursor < len(a.sortedItems) {
-		item := a.sortedItems[a.cursor]
-		items = append(items, components.ConfirmItem{
-			Name:  item.GetName(),
-			Path:  item.Path(),
-			Size:  item.GetSize(),
-			IsDir: item.IsDir(),
-		})
+		items = append(items, confirmItemFor(a.sortedItems[a.cursor]))
 	}
 
 	if len(items) == 0 {
@@ -624,42 +1630,182 @@ func (a *App) prepareDelete() tea.Cmd {
 	return nil
 }
 
+// confirmItemFor builds a ConfirmItem for item, filling in the recursive
+// item count and deepest nested path when it's a directory so the delete
+// confirmation shows the real blast radius, not just a top-level row.
+func confirmItemFor(item model.TreeNode) components.ConfirmItem {
+	ci := components.ConfirmItem{
+		Name:  item.GetName(),
+		Path:  item.Path(),
+		Size:  item.GetSize(),
+		IsDir: item.IsDir(),
+	}
+	if dir, ok := item.(*model.DirNode); ok {
+		ci.ItemCount = dir.ItemCount
+		ci.DeepestPath = ops.DeepestPath(dir)
+	}
+	return ci
+}
+
+// openInFileManager opens the directory under the cursor (or the parent
+// directory of a file) in the OS file manager. It is a no-op in import mode,
+// since an imported tree's paths belong to whatever machine produced the
+// export and generally don't exist on this one.
+func (a *App) openInFileManager() tea.Cmd {
+	if a.imported {
+		return func() tea.Msg {
+			return OpenFileManagerDoneMsg{Err: fmt.Errorf("cannot open a file manager on an imported scan's paths")}
+		}
+	}
+	if a.cursor >= len(a.sortedItems) {
+		return nil
+	}
+
+	item := a.sortedItems[a.cursor]
+	target := item.Path()
+	if !item.IsDir() {
+		target = a.currentDir.Path()
+	}
+
+	return func() tea.Msg {
+		return OpenFileManagerDoneMsg{Err: launcher.Open(target)}
+	}
+}
+
+// executeDelete starts deleting the marked items off the UI goroutine.
+// Trashing is a rename and finishes instantly even for huge trees, so it
+// runs the old synchronous way. Permanent deletion walks the filesystem and
+// can take a long time on a tree with millions of entries, so it switches to
+// StateDeleting and reports progress (entries removed so far) through
+// a.deleteRemoved, read by the tickMsg handler the same way scan progress
+// flows into a.displayProgress. Canceling (esc/q while StateDeleting) stops
+// the walk after its current entry but keeps everything removed so far.
 func (a *App) executeDelete() tea.Cmd {
+	if a.ReadOnly {
+		a.statusMsg = "Delete is disabled in read-only mode"
+		return nil
+	}
 	items := a.markedItems
 	rootPath := a.root.Path()
 
-	return func() tea.Msg {
+	if a.UseTrash {
+		return func() tea.Msg {
+			var deleted []string
+			var errs []error
+			trashedPaths := map[string]string{}
+
+			for _, item := range items {
+				trashedPath, err := ops.Trash(item.Path, rootPath)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				trashedPaths[item.Name] = trashedPath
+				deleted = append(deleted, item.Name)
+			}
+
+			return DeleteDoneMsg{Deleted: deleted, Errors: errs, TrashedPaths: trashedPaths}
+		}
+	}
+
+	total := 0
+	for _, item := range items {
+		if item.IsDir && item.ItemCount > 0 {
+			total += int(item.ItemCount)
+		} else {
+			total++
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.setDeleteCancel(cancel)
+	a.deleteProgressMu.Lock()
+	a.deleteRemoved = 0
+	a.deleteTotal = total
+	a.deleteProgressMu.Unlock()
+	a.displayDeleteRemoved = 0
+	a.state = StateDeleting
+
+	deleteCmd := func() tea.Msg {
+		defer a.setDeleteCancel(nil)
+
 		var deleted []string
-		var errors []error
+		var errs []error
+		completed := 0
 
 		for _, item := range items {
-			err := ops.Delete(item.Path, rootPath)
+			if ctx.Err() != nil {
+				break
+			}
+
+			itemRemoved := 0
+			err := ops.DeleteWithProgress(ctx, item.Path, rootPath, func(n int) {
+				itemRemoved = n
+				a.deleteProgressMu.Lock()
+				a.deleteRemoved = completed + n
+				a.deleteProgressMu.Unlock()
+			})
+			completed += itemRemoved
+
 			if err != nil {
-				errors = append(errors, err)
+				errs = append(errs, err)
 			} else {
 				deleted = append(deleted, item.Name)
 			}
 		}
 
-		return DeleteDoneMsg{Deleted: deleted, Errors: errors}
+		return DeleteDoneMsg{Deleted: deleted, Errors: errs}
+	}
+
+	return tea.Batch(deleteCmd, a.tickCmd())
+}
+
+// restoreLastTrashedCmd restores the most recently trashed item still
+// present in the undo log (skipping items that were permanently deleted,
+// which have no TrashedPath to restore from), off the UI goroutine.
+func (a *App) restoreLastTrashedCmd() tea.Cmd {
+	for i := len(a.undoLog) - 1; i >= 0; i-- {
+		entry := a.undoLog[i]
+		if entry.TrashedPath == "" {
+			continue
+		}
+		return func() tea.Msg {
+			_, err := ops.RestoreTrash(entry.TrashedPath)
+			return RestoreDoneMsg{Name: entry.Name, TrashedPath: entry.TrashedPath, Err: err}
+		}
+	}
+	return func() tea.Msg {
+		return RestoreDoneMsg{Err: fmt.Errorf("nothing to restore")}
 	}
 }
 
 // FatalError returns a fatal scan/import error, if any.
 func (a *App) FatalError() error { return a.fatalErr }
 
-func (a *App) markedSize(items []model.TreeNode) int64 {
-	var total int64
-	for _, item := range items {
-		if a.marked[item.Path()] {
-			if a.useApparent {
-				total += item.GetSize()
-			} else {
-				total += item.GetUsage()
-			}
+// DeletionSummary returns a one-line recap of everything deleted during this
+// session (count and space freed), or "" if nothing was deleted. Intended to
+// be printed after the TUI exits, since the deletion is otherwise invisible
+// once the screen clears.
+func (a *App) DeletionSummary() string {
+	if a.deletedCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Deleted %d item(s), freed %s", a.deletedCount, util.FormatSize(a.freedBytes))
+}
+
+// markedSize returns the apparent and disk-usage totals of every marked item,
+// resolved against the whole tree rather than just the current listing, so
+// the status bar stays correct once marks span more than one directory.
+func (a *App) markedSize() (apparent, disk int64) {
+	for markedPath := range a.marked {
+		item, err := ops.FindNodeByPath(a.root, markedPath)
+		if err != nil {
+			continue
 		}
+		apparent += item.GetSize()
+		disk += item.GetUsage()
 	}
-	return total
+	return apparent, disk
 }
 
 func (a *App) exportCmd() tea.Cmd {
@@ -677,13 +1823,57 @@ func (a *App) exportCmd() tea.Cmd {
 
 	version := a.Version
 	return func() tea.Msg {
-		err := ops.ExportJSON(root, exportPath, version)
+		err := ops.ExportJSON(root, exportPath, version, false)
 		return ExportDoneMsg{Path: exportPath, Err: err}
 	}
 }
 
+// snapshotCmd writes a clean text rendering of the current view (as shown,
+// respecting filters/sort) to a .txt file, for sharing in plain-text
+// environments where full JSON export isn't useful.
+func (a *App) snapshotCmd() tea.Cmd {
+	if a.root == nil {
+		return nil
+	}
+
+	rendered := ansi.Strip(a.renderBrowsing())
+	path := fmt.Sprintf("godu-snapshot-%s.txt", time.Now().Format("20060102-150405"))
+
+	return func() tea.Msg {
+		err := ops.WriteSnapshot(rendered, path)
+		return SnapshotDoneMsg{Path: path, Err: err}
+	}
+}
+
+// refreshDirCmd re-scans only a.currentDir's path, without touching the rest
+// of the tree, for a cheaper alternative to startRescan on big trees where
+// the user only cares that one directory's contents changed.
+func (a *App) refreshDirCmd() tea.Cmd {
+	if a.imported {
+		a.statusMsg = "Refresh is disabled in import mode"
+		return nil
+	}
+	if a.currentDir == nil {
+		return nil
+	}
+
+	dir := a.currentDir
+	path := dir.Path()
+	opts := a.ScanOptions
+
+	return func() tea.Msg {
+		newNode, err := scanner.ScanTree(context.Background(), path, opts)
+		return RefreshDirDoneMsg{Dir: dir, NewNode: newNode, Err: err}
+	}
+}
+
 func (a *App) startRescan() tea.Cmd {
 	components.InvalidateFileTypeCache()
+	components.InvalidateLargestFilesCache()
+	components.InvalidateHardlinksCache()
+	if a.root != nil {
+		a.expectedItems = a.root.GetItemCount()
+	}
 	a.navStack = nil
 	a.cursor = 0
 	a.offset = 0
@@ -1,13 +1,20 @@
 package ui
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/sadopc/godu/internal/model"
 	"github.com/sadopc/godu/internal/scanner"
+	"github.com/sadopc/godu/internal/ui/components"
+	"github.com/sadopc/godu/internal/ui/style"
 )
 
 func TestAppFatalError_SetOnScanDoneError(t *testing.T) {
@@ -39,37 +46,221 @@ func TestAppFatalError_NotSetByStatusMessages(t *testing.T) {
 	}
 }
 
-func TestAppMarkedSize_ComputesFromVisibleItems(t *testing.T) {
+func TestAppMarkedSize_ComputesAcrossWholeTree(t *testing.T) {
 	app := NewApp("/tmp", scanner.DefaultOptions())
 	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
-	fileA := &model.FileNode{
-		Name:   "a.txt",
-		Size:   10,
-		Usage:  20,
-		Parent: root,
-	}
-	fileB := &model.FileNode{
-		Name:   "b.txt",
-		Size:   4,
-		Usage:  8,
-		Parent: root,
-	}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	fileA := &model.FileNode{Name: "a.txt", Size: 10, Usage: 20, Parent: root}
+	fileB := &model.FileNode{Name: "b.txt", Size: 4, Usage: 8, Parent: sub}
+	root.AddChild(fileA)
+	root.AddChild(sub)
+	sub.AddChild(fileB)
+	app.root = root
 
 	app.marked = map[string]bool{
 		fileA.Path():            true,
-		"/tmp/root/missing.txt": true, // Marked but not visible in current items
+		fileB.Path():            true, // Marked in a different directory than fileA
+		"/tmp/root/missing.txt": true, // Marked but no longer present in the tree
+	}
+
+	apparent, disk := app.markedSize()
+	if apparent != 14 {
+		t.Fatalf("expected apparent marked size 14, got %d", apparent)
+	}
+	if disk != 28 {
+		t.Fatalf("expected disk marked size 28, got %d", disk)
+	}
+}
+
+func TestAppDeletionSummary_AccumulatesAcrossDeletes(t *testing.T) {
+	app := NewApp("/tmp", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	fileA := &model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: root}
+	fileB := &model.FileNode{Name: "b.txt", Size: 4, Usage: 4, Parent: root}
+	root.AddChild(fileA)
+	root.AddChild(fileB)
+	app.currentDir = root
+
+	if summary := app.DeletionSummary(); summary != "" {
+		t.Fatalf("expected no summary before any deletion, got %q", summary)
+	}
+
+	app.markedItems = []components.ConfirmItem{{Name: "a.txt", Path: fileA.Path(), Size: fileA.Size}}
+	app.Update(DeleteDoneMsg{Deleted: []string{"a.txt"}})
+
+	app.markedItems = []components.ConfirmItem{{Name: "b.txt", Path: fileB.Path(), Size: fileB.Size}}
+	app.Update(DeleteDoneMsg{Deleted: []string{"b.txt"}})
+
+	summary := app.DeletionSummary()
+	if !strings.Contains(summary, "Deleted 2 item") {
+		t.Fatalf("expected summary to mention 2 deleted items, got %q", summary)
+	}
+	if !strings.Contains(summary, "14") {
+		t.Fatalf("expected summary to mention 14 bytes freed, got %q", summary)
+	}
+}
+
+func TestAppDeleteDoneMsg_AppendsToUndoLog(t *testing.T) {
+	app := NewApp("/tmp", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	fileA := &model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: root}
+	fileB := &model.FileNode{Name: "b.txt", Size: 4, Usage: 4, Parent: root}
+	root.AddChild(fileA)
+	root.AddChild(fileB)
+	app.currentDir = root
+
+	app.markedItems = []components.ConfirmItem{
+		{Name: "a.txt", Path: fileA.Path(), Size: fileA.Size},
+		{Name: "b.txt", Path: fileB.Path(), Size: fileB.Size},
+	}
+	app.Update(DeleteDoneMsg{
+		Deleted:      []string{"a.txt", "b.txt"},
+		TrashedPaths: map[string]string{"a.txt": "/home/user/.local/share/Trash/files/a.txt"},
+	})
+
+	if len(app.undoLog) != 2 {
+		t.Fatalf("expected 2 undo log entries, got %d", len(app.undoLog))
+	}
+
+	entryA := app.undoLog[0]
+	if entryA.Name != "a.txt" || entryA.Path != fileA.Path() || entryA.Size != fileA.Size {
+		t.Fatalf("unexpected undo log entry for a.txt: %+v", entryA)
+	}
+	if entryA.TrashedPath != "/home/user/.local/share/Trash/files/a.txt" {
+		t.Fatalf("expected a.txt's undo entry to record its trashed path, got %q", entryA.TrashedPath)
+	}
+
+	entryB := app.undoLog[1]
+	if entryB.Name != "b.txt" || entryB.TrashedPath != "" {
+		t.Fatalf("expected b.txt to be recorded as permanently deleted, got %+v", entryB)
+	}
+}
+
+func TestJumpToPath_NavigatesToNestedDirectory(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	nested := &model.DirNode{FileNode: model.FileNode{Name: "nested", Parent: sub}}
+	sub.AddChild(nested)
+	root.AddChild(sub)
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	app.jumpToPath("/tmp/root/sub/nested")
+
+	if app.currentDir != nested {
+		t.Fatalf("expected current dir to be nested, got %v", app.currentDir)
+	}
+	if len(app.navStack) != 2 || app.navStack[0] != root || app.navStack[1] != sub {
+		t.Fatalf("expected navStack [root, sub], got %v", app.navStack)
+	}
+
+	app.goBack()
+	if app.currentDir != sub {
+		t.Fatalf("expected goBack to land on sub, got %v", app.currentDir)
+	}
+}
+
+func TestGetParentSize_UsesRootTotalWhenPercentOfRootEnabled(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+	sub.AddChild(&model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: sub})
+	root.AddChild(&model.FileNode{Name: "b.txt", Size: 90, Usage: 90, Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = sub
+	app.sizeMode = model.SizeModeApparent
+
+	if got := app.getParentSize(); got != sub.GetSize() {
+		t.Fatalf("expected parent-relative size %d, got %d", sub.GetSize(), got)
 	}
 
-	items := []model.TreeNode{fileA, fileB}
+	app.percentOfRoot = true
+	if got := app.getParentSize(); got != root.GetSize() {
+		t.Fatalf("expected root-relative size %d once percentOfRoot is enabled, got %d", root.GetSize(), got)
+	}
+}
+
+func TestTogglePercentOfRoot_KeyFlipsTheSetting(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	if app.percentOfRoot {
+		t.Fatal("expected percentOfRoot to start disabled")
+	}
 
-	app.useApparent = false
-	if got := app.markedSize(items); got != 20 {
-		t.Fatalf("expected disk marked size 20, got %d", got)
+	_, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'%'}})
+	if !app.percentOfRoot {
+		t.Fatal("expected '%' to enable percentOfRoot")
 	}
 
-	app.useApparent = true
-	if got := app.markedSize(items); got != 10 {
-		t.Fatalf("expected apparent marked size 10, got %d", got)
+	_, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'%'}})
+	if app.percentOfRoot {
+		t.Fatal("expected a second '%' press to disable percentOfRoot again")
+	}
+}
+
+func TestJumpLargest_DivesAlongLargestChildChain(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	small := &model.DirNode{FileNode: model.FileNode{Name: "small", Parent: root}}
+	big := &model.DirNode{FileNode: model.FileNode{Name: "big", Parent: root}}
+	biggest := &model.DirNode{FileNode: model.FileNode{Name: "biggest", Parent: big}}
+	tiny := &model.DirNode{FileNode: model.FileNode{Name: "tiny", Parent: big}}
+	root.AddChild(small)
+	root.AddChild(big)
+	big.AddChild(biggest)
+	big.AddChild(tiny)
+	small.AddChild(&model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: small})
+	biggest.AddChild(&model.FileNode{Name: "b.txt", Size: 1000, Usage: 1000, Parent: biggest})
+	tiny.AddChild(&model.FileNode{Name: "c.txt", Size: 1, Usage: 1, Parent: tiny})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	_, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if app.currentDir != big {
+		t.Fatalf("expected first jump to land on big, got %v", app.currentDir)
+	}
+
+	_, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if app.currentDir != biggest {
+		t.Fatalf("expected second jump to land on biggest, got %v", app.currentDir)
+	}
+
+	if len(app.navStack) != 2 || app.navStack[0] != root || app.navStack[1] != big {
+		t.Fatalf("expected navStack [root, big], got %v", app.navStack)
+	}
+}
+
+func TestJumpToPath_SetsStatusOnUnknownPath(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+
+	app.jumpToPath("/tmp/root/missing")
+
+	if app.currentDir != root {
+		t.Fatalf("expected current dir unchanged, got %v", app.currentDir)
+	}
+	if !strings.Contains(app.statusMsg, "not found") {
+		t.Fatalf("expected not-found status message, got %q", app.statusMsg)
 	}
 }
 
@@ -94,6 +285,328 @@ func TestRescan_DisabledInImportMode(t *testing.T) {
 	}
 }
 
+func TestDelete_DisabledInReadOnlyMode(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	app.ReadOnly = true
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if cmd != nil {
+		t.Fatal("expected no command when delete is disabled in read-only mode")
+	}
+	if app.state == StateConfirmDelete {
+		t.Fatal("expected read-only mode to prevent entering StateConfirmDelete")
+	}
+	if !strings.Contains(app.statusMsg, "read-only") {
+		t.Fatalf("expected read-only status message, got %q", app.statusMsg)
+	}
+}
+
+func TestExecuteDelete_EntersStateDeletingAndReportsProgress(t *testing.T) {
+	root := t.TempDir()
+	f := filepath.Join(root, "a.txt")
+	if err := os.WriteFile(f, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp(root, scanner.DefaultOptions())
+	dirNode := &model.DirNode{FileNode: model.FileNode{Name: root}}
+	dirNode.AddChild(&model.FileNode{Name: "a.txt", Size: 4, Usage: 4, Parent: dirNode})
+	dirNode.UpdateSizeRecursive()
+	app.root = dirNode
+	app.currentDir = dirNode
+	app.refreshSorted()
+	app.cursor = 0
+	app.state = StateBrowsing
+
+	if cmd := app.prepareDelete(); cmd != nil {
+		t.Fatalf("expected prepareDelete to return no command, got %v", cmd)
+	}
+	if app.state != StateConfirmDelete {
+		t.Fatalf("expected StateConfirmDelete, got %v", app.state)
+	}
+
+	cmd := app.executeDelete()
+	if app.state != StateDeleting {
+		t.Fatalf("expected executeDelete to switch to StateDeleting immediately, got %v", app.state)
+	}
+	if cmd == nil {
+		t.Fatal("expected a command from executeDelete")
+	}
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("expected a tea.BatchMsg, got %T", cmd())
+	}
+	if len(batch) != 2 {
+		t.Fatalf("expected two batched commands (delete + tick), got %d", len(batch))
+	}
+
+	doneMsg := batch[0]()
+	done, ok := doneMsg.(DeleteDoneMsg)
+	if !ok {
+		t.Fatalf("expected DeleteDoneMsg, got %T", doneMsg)
+	}
+	if len(done.Deleted) != 1 || done.Deleted[0] != "a.txt" {
+		t.Fatalf("expected a.txt to be deleted, got %v (errors: %v)", done.Deleted, done.Errors)
+	}
+	if _, err := os.Lstat(f); !os.IsNotExist(err) {
+		t.Fatal("expected a.txt to be removed from disk")
+	}
+
+	app.Update(tickMsg(time.Now()))
+	if app.displayDeleteRemoved != 1 {
+		t.Fatalf("expected displayDeleteRemoved to reflect the completed delete, got %d", app.displayDeleteRemoved)
+	}
+}
+
+func TestStateDeleting_EscKeyCancelsWithoutQuitting(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	app.state = StateDeleting
+
+	canceled := false
+	app.setDeleteCancel(func() { canceled = true })
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		t.Fatal("expected esc to cancel in place, not return a command")
+	}
+	if !canceled {
+		t.Fatal("expected esc to invoke the delete cancel func")
+	}
+	if app.state != StateDeleting {
+		t.Fatalf("expected esc to stay in StateDeleting while the goroutine winds down, got %v", app.state)
+	}
+}
+
+func TestExecuteDelete_CancelStopsTheWalkPartway(t *testing.T) {
+	root := t.TempDir()
+	big := filepath.Join(root, "big")
+	if err := os.Mkdir(big, 0755); err != nil {
+		t.Fatal(err)
+	}
+	const fileCount = 3000
+	for i := 0; i < fileCount; i++ {
+		if err := os.WriteFile(filepath.Join(big, fmt.Sprintf("f%d.txt", i)), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := NewApp(root, scanner.DefaultOptions())
+	dirNode := &model.DirNode{FileNode: model.FileNode{Name: root}}
+	bigNode := &model.DirNode{FileNode: model.FileNode{Name: "big", Parent: dirNode}}
+	dirNode.AddChild(bigNode)
+	dirNode.UpdateSizeRecursive()
+	app.root = dirNode
+	app.currentDir = dirNode
+	app.refreshSorted()
+	app.cursor = 0
+	app.state = StateBrowsing
+
+	app.prepareDelete()
+	cmd := app.executeDelete()
+	batch := cmd().(tea.BatchMsg)
+
+	done := make(chan tea.Msg, 1)
+	go func() {
+		done <- batch[0]()
+	}()
+
+	// Cancel once at least two entries have been removed, simulating the
+	// user pressing esc partway through a large directory delete.
+	for {
+		app.deleteProgressMu.Lock()
+		removed := app.deleteRemoved
+		app.deleteProgressMu.Unlock()
+		if removed >= 2 {
+			app.callDeleteCancel()
+			break
+		}
+	}
+
+	doneMsg := <-done
+	result := doneMsg.(DeleteDoneMsg)
+
+	entries, err := os.ReadDir(big)
+	if err != nil {
+		t.Fatalf("reading remaining entries: %v", err)
+	}
+	if len(entries) == fileCount {
+		t.Fatal("expected cancellation to stop the walk before all entries were removed")
+	}
+	if len(result.Deleted) != 0 {
+		t.Fatalf("expected the canceled directory delete to report as failed, not deleted, got %v", result.Deleted)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error for the canceled delete, got %v", result.Errors)
+	}
+}
+
+func TestImportedTree_ShowsTimestampInHeader(t *testing.T) {
+	app := NewAppFromImport("scan.json")
+	app.width = 120
+	app.height = 40
+
+	ts := time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/imported"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 5, Parent: root})
+	app.Update(ScanDoneMsg{Root: root, IncludedHidden: true, ImportTimestamp: ts})
+
+	view := app.View()
+	wantTimestamp := ts.Format("2006-01-02 15:04")
+	if !strings.Contains(view, wantTimestamp) {
+		t.Fatalf("expected view to contain import timestamp %q, got:\n%s", wantTimestamp, view)
+	}
+	if !strings.Contains(view, "imported") {
+		t.Fatalf("expected view to label the timestamp as an import, got:\n%s", view)
+	}
+}
+
+func TestScannedTree_HasNoImportTimestamp(t *testing.T) {
+	app := NewApp("/tmp", scanner.DefaultOptions())
+	app.width = 120
+	app.height = 40
+
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 5, Parent: root})
+	app.Update(ScanDoneMsg{Root: root, IncludedHidden: true, ImportTimestamp: time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)})
+
+	view := app.View()
+	if strings.Contains(view, "imported:") {
+		t.Fatalf("expected a live scan's view to never show an import timestamp, got:\n%s", view)
+	}
+}
+
+// childByName returns dir's immediate child named name, or nil if there is
+// none.
+func childByName(dir *model.DirNode, name string) model.TreeNode {
+	for _, c := range dir.GetChildren() {
+		if c.GetName() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestRefreshDir_UpdatesOnlyCurrentDirectorySize(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	sibling := filepath.Join(root, "sibling")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(sibling, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sibling, "b.txt"), []byte("bb"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := scanner.DefaultOptions()
+	app := NewApp(root, opts)
+	rootNode, err := scanner.ScanTree(context.Background(), root, opts)
+	if err != nil {
+		t.Fatalf("initial scan failed: %v", err)
+	}
+	app.root = rootNode
+	app.currentDir = rootNode
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	subNode := childByName(rootNode, "sub").(*model.DirNode)
+	siblingNode := childByName(rootNode, "sibling").(*model.DirNode)
+	siblingSizeBefore := siblingNode.GetSize()
+
+	app.currentDir = subNode
+	if err := os.WriteFile(filepath.Join(sub, "c.txt"), []byte("ccc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := app.refreshDirCmd()
+	if cmd == nil {
+		t.Fatal("expected a refresh command")
+	}
+	msg, ok := cmd().(RefreshDirDoneMsg)
+	if !ok {
+		t.Fatalf("expected RefreshDirDoneMsg, got %T", cmd())
+	}
+	if msg.Err != nil {
+		t.Fatalf("refresh scan failed: %v", msg.Err)
+	}
+
+	app.Update(msg)
+
+	if subNode.GetSize() != 4 {
+		t.Fatalf("expected sub's size to reflect the new file, got %d", subNode.GetSize())
+	}
+	if rootNode.GetSize() != subNode.GetSize()+siblingNode.GetSize() {
+		t.Fatalf("expected root size to reflect propagated update: root=%d sub=%d sibling=%d", rootNode.GetSize(), subNode.GetSize(), siblingNode.GetSize())
+	}
+	if siblingNode.GetSize() != siblingSizeBefore {
+		t.Fatalf("expected sibling size to be untouched, got %d want %d", siblingNode.GetSize(), siblingSizeBefore)
+	}
+}
+
+func TestOpenInFileManager_DisabledInImportMode(t *testing.T) {
+	app := NewAppFromImport("scan.json")
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/imported"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	cmd := app.openInFileManager()
+	if cmd == nil {
+		t.Fatal("expected a command reporting why open is disabled")
+	}
+	msg, ok := cmd().(OpenFileManagerDoneMsg)
+	if !ok {
+		t.Fatalf("expected OpenFileManagerDoneMsg, got %T", cmd())
+	}
+	if msg.Err == nil || !strings.Contains(msg.Err.Error(), "imported") {
+		t.Fatalf("expected error mentioning the import restriction, got %v", msg.Err)
+	}
+}
+
+func TestOpenInFileManager_UsesParentDirForFiles(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	file := &model.FileNode{Name: "a.txt", Parent: root}
+	root.AddChild(file)
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+	app.cursor = 0
+
+	cmd := app.openInFileManager()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	msg, ok := cmd().(OpenFileManagerDoneMsg)
+	if !ok {
+		t.Fatalf("expected OpenFileManagerDoneMsg, got %T", cmd())
+	}
+	// No file manager is installed in the test sandbox, so launching it
+	// fails; what matters is that the error names the parent directory
+	// rather than the file itself.
+	if msg.Err == nil || !strings.Contains(msg.Err.Error(), root.Path()) {
+		t.Fatalf("expected error referencing parent dir %q, got %v", root.Path(), msg.Err)
+	}
+}
+
 func TestToggleHidden_TriggersRescanWhenMissingFromData(t *testing.T) {
 	opts := scanner.DefaultOptions()
 	opts.ShowHidden = false
@@ -120,3 +633,406 @@ func TestToggleHidden_TriggersRescanWhenMissingFromData(t *testing.T) {
 		t.Fatal("expected scan options to include hidden files for rescan")
 	}
 }
+
+func TestPeekHidden_RevealsDotfilesInCurrentDirOnly(t *testing.T) {
+	opts := scanner.DefaultOptions()
+	opts.ShowHidden = true
+	app := NewApp("/tmp", opts)
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp"}}
+	root.AddChild(&model.FileNode{Name: "visible.txt", Parent: root})
+	root.AddChild(&model.FileNode{Name: ".hidden", Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.scanIncludedHidden = true
+	app.showHidden = false
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	if len(app.sortedItems) != 1 {
+		t.Fatalf("expected 1 visible item before peek, got %d", len(app.sortedItems))
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	if cmd != nil {
+		t.Fatal("expected no command from PeekHidden; it must not trigger a rescan")
+	}
+	if app.showHidden {
+		t.Fatal("expected global showHidden to remain unchanged")
+	}
+	if len(app.sortedItems) != 2 {
+		t.Fatalf("expected 2 items after peek reveals dotfile, got %d", len(app.sortedItems))
+	}
+
+	// Toggling again restores the filtered view.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	if len(app.sortedItems) != 1 {
+		t.Fatalf("expected peek toggle off to re-hide dotfile, got %d items", len(app.sortedItems))
+	}
+}
+
+func TestFilesOnly_HidesDirectoriesFromCurrentListing(t *testing.T) {
+	opts := scanner.DefaultOptions()
+	app := NewApp("/tmp", opts)
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp"}}
+	root.AddChild(&model.FileNode{Name: "big.log", Parent: root})
+	root.AddChild(&model.DirNode{FileNode: model.FileNode{Name: "subdir", Parent: root}})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	if len(app.sortedItems) != 2 {
+		t.Fatalf("expected 2 items before filter, got %d", len(app.sortedItems))
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if !app.filesOnly {
+		t.Fatal("expected filesOnly to be enabled")
+	}
+	if len(app.sortedItems) != 1 || app.sortedItems[0].IsDir() {
+		t.Fatalf("expected only the file to remain, got %v", app.sortedItems)
+	}
+
+	// Toggling again restores directories to the listing.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if len(app.sortedItems) != 2 {
+		t.Fatalf("expected filter toggle off to restore directories, got %d items", len(app.sortedItems))
+	}
+}
+
+func TestSizeFloor_CyclesAndFiltersSmallFilesButKeepsDirectories(t *testing.T) {
+	opts := scanner.DefaultOptions()
+	app := NewApp("/tmp", opts)
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp"}}
+	root.AddChild(&model.FileNode{Name: "tiny.txt", Size: 1 << 10, Usage: 1 << 10, Parent: root})
+	root.AddChild(&model.FileNode{Name: "small.txt", Size: 5 << 20, Usage: 5 << 20, Parent: root})
+	root.AddChild(&model.FileNode{Name: "big.txt", Size: 50 << 20, Usage: 50 << 20, Parent: root})
+	root.AddChild(&model.DirNode{FileNode: model.FileNode{Name: "subdir", Size: 1, Usage: 1, Parent: root}})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	if len(app.sortedItems) != 4 {
+		t.Fatalf("expected all 4 items before any floor is applied, got %d", len(app.sortedItems))
+	}
+
+	// 1 MiB floor: drops tiny.txt, keeps everything else including the directory.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if sizeFloors[app.sizeFloorIdx] != 1<<20 {
+		t.Fatalf("expected floor to cycle to 1 MiB, got %d", sizeFloors[app.sizeFloorIdx])
+	}
+	if len(app.sortedItems) != 3 {
+		t.Fatalf("expected 3 items at 1 MiB floor, got %v", app.sortedItems)
+	}
+
+	// 10 MiB floor: drops small.txt too, directory still shown.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if sizeFloors[app.sizeFloorIdx] != 10<<20 {
+		t.Fatalf("expected floor to cycle to 10 MiB, got %d", sizeFloors[app.sizeFloorIdx])
+	}
+	if len(app.sortedItems) != 2 {
+		t.Fatalf("expected 2 items at 10 MiB floor, got %v", app.sortedItems)
+	}
+	for _, item := range app.sortedItems {
+		if !item.IsDir() && item.GetName() != "big.txt" {
+			t.Fatalf("expected only big.txt and the directory to remain, got %v", app.sortedItems)
+		}
+	}
+
+	// 100 MiB floor: drops big.txt too, only the directory remains.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if sizeFloors[app.sizeFloorIdx] != 100<<20 {
+		t.Fatalf("expected floor to cycle to 100 MiB, got %d", sizeFloors[app.sizeFloorIdx])
+	}
+	if len(app.sortedItems) != 1 || !app.sortedItems[0].IsDir() {
+		t.Fatalf("expected only the directory to remain, got %v", app.sortedItems)
+	}
+
+	// Wraps back around to no filtering.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if sizeFloors[app.sizeFloorIdx] != 0 {
+		t.Fatalf("expected floor to wrap back to off, got %d", sizeFloors[app.sizeFloorIdx])
+	}
+	if len(app.sortedItems) != 4 {
+		t.Fatalf("expected all 4 items again once the floor is off, got %d", len(app.sortedItems))
+	}
+}
+
+func TestToggleMarkRecursive_MarksAllFilesUnderDirectory(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	nested := &model.DirNode{FileNode: model.FileNode{Name: "nested", Parent: sub}}
+	fileA := &model.FileNode{Name: "a.log", Size: 10, Usage: 10, Parent: sub}
+	fileB := &model.FileNode{Name: "b.log", Size: 4, Usage: 4, Parent: nested}
+	sub.AddChild(fileA)
+	sub.AddChild(nested)
+	nested.AddChild(fileB)
+	root.AddChild(sub)
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+
+	if len(app.marked) != 2 || !app.marked[fileA.Path()] || !app.marked[fileB.Path()] {
+		t.Fatalf("expected both nested files marked, got %v", app.marked)
+	}
+	if app.marked[sub.Path()] {
+		t.Fatal("expected the directory node itself not to be marked")
+	}
+
+	// Pressing it again on the same directory unmarks everything.
+	app.cursor = 0
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if len(app.marked) != 0 {
+		t.Fatalf("expected marks cleared on second press, got %v", app.marked)
+	}
+}
+
+func TestPersistMarks_SurviveNavigationWhenEnabled(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if !app.persistMarks {
+		t.Fatal("expected persistMarks to be enabled")
+	}
+
+	app.marked[sub.Path()] = true
+	app.enterDir()
+	if len(app.marked) != 1 || !app.marked[sub.Path()] {
+		t.Fatalf("expected mark to survive enterDir with persistMarks on, got %v", app.marked)
+	}
+
+	app.goBack()
+	if len(app.marked) != 1 || !app.marked[sub.Path()] {
+		t.Fatalf("expected mark to survive goBack with persistMarks on, got %v", app.marked)
+	}
+}
+
+func TestPersistMarks_ClearOnNavigationByDefault(t *testing.T) {
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	app.marked[sub.Path()] = true
+	app.enterDir()
+	if len(app.marked) != 0 {
+		t.Fatalf("expected marks to clear on enterDir by default, got %v", app.marked)
+	}
+}
+
+func TestSearch_FiltersByNameCaseInsensitively(t *testing.T) {
+	app := NewApp("/tmp", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp"}}
+	root.AddChild(&model.FileNode{Name: "Report.txt", Parent: root})
+	root.AddChild(&model.FileNode{Name: "notes.md", Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if app.state != StateSearch {
+		t.Fatalf("expected '/' to open StateSearch, got state %v", app.state)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r', 'e', 'p'}})
+	if app.filterText != "rep" {
+		t.Fatalf("expected filterText %q, got %q", "rep", app.filterText)
+	}
+	if len(app.sortedItems) != 1 || app.sortedItems[0].GetName() != "Report.txt" {
+		t.Fatalf("expected only Report.txt to match, got %v", app.sortedItems)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if app.filterText != "re" {
+		t.Fatalf("expected backspace to shrink filterText to %q, got %q", "re", app.filterText)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.state != StateBrowsing {
+		t.Fatalf("expected Enter to return to StateBrowsing, got %v", app.state)
+	}
+	if app.filterText != "re" {
+		t.Fatalf("expected filter to remain applied after Enter, got %q", app.filterText)
+	}
+}
+
+func TestSearch_EscClearsFilter(t *testing.T) {
+	app := NewApp("/tmp", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Parent: root})
+	root.AddChild(&model.FileNode{Name: "b.txt", Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if len(app.sortedItems) != 1 {
+		t.Fatalf("expected filter to narrow list, got %v", app.sortedItems)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.state != StateBrowsing {
+		t.Fatalf("expected Esc to return to StateBrowsing, got %v", app.state)
+	}
+	if app.filterText != "" {
+		t.Fatalf("expected Esc to clear filterText, got %q", app.filterText)
+	}
+	if len(app.sortedItems) != 2 {
+		t.Fatalf("expected full list restored after clearing filter, got %v", app.sortedItems)
+	}
+}
+
+func TestPageDown_AdvancesCursorByScreenHeight(t *testing.T) {
+	app := NewApp("/tmp", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp"}}
+	for i := 0; i < 100; i++ {
+		root.AddChild(&model.FileNode{Name: strings.Repeat("f", 1) + strings.Repeat("0", i+1) + ".txt", Parent: root})
+	}
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+	app.layout = style.NewLayout(80, 40)
+
+	if len(app.sortedItems) != 100 {
+		t.Fatalf("expected 100 items, got %d", len(app.sortedItems))
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	want := app.layout.ContentHeight() - 1
+	if app.cursor != want {
+		t.Fatalf("expected cursor to advance by %d, got %d", want, app.cursor)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if app.cursor != 0 {
+		t.Fatalf("expected Home to jump to 0, got %d", app.cursor)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if app.cursor != len(app.sortedItems)-1 {
+		t.Fatalf("expected End to jump to last item, got %d", app.cursor)
+	}
+}
+
+func TestRowIndexForY_MapsScreenRowsToItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		y             int
+		offset        int
+		contentHeight int
+		itemCount     int
+		want          int
+	}{
+		{"first content row maps to offset", 3, 0, 10, 20, 0},
+		{"mid content row adds to offset", 7, 2, 10, 20, 6},
+		{"above content rows is out of range", 2, 0, 10, 20, -1},
+		{"below content rows is out of range", 13, 0, 10, 20, -1},
+		{"row past end of item list is out of range", 8, 18, 10, 20, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rowIndexForY(tt.y, tt.offset, tt.contentHeight, tt.itemCount)
+			if got != tt.want {
+				t.Fatalf("rowIndexForY(%d, %d, %d, %d) = %d, want %d", tt.y, tt.offset, tt.contentHeight, tt.itemCount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMouseClick_MovesCursorAndDoubleClickEntersDirectory(t *testing.T) {
+	app := NewApp("/tmp", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+	root.AddChild(&model.FileNode{Name: "a.txt", Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+	app.layout = style.NewLayout(80, 40)
+
+	// sortedItems sorted by size desc by default; locate sub's index.
+	idx := -1
+	for i, item := range app.sortedItems {
+		if item.GetName() == "sub" {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		t.Fatal("expected sub directory in sortedItems")
+	}
+	y := treeContentRows + idx
+
+	app.Update(tea.MouseMsg{X: 5, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if app.cursor != idx {
+		t.Fatalf("expected single click to move cursor to %d, got %d", idx, app.cursor)
+	}
+	if app.currentDir != root {
+		t.Fatal("expected single click not to enter directory")
+	}
+
+	app.Update(tea.MouseMsg{X: 5, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if app.currentDir != sub {
+		t.Fatal("expected double click on a directory row to enter it")
+	}
+}
+
+func TestFileTypeView_EnterDrillsIntoSelectedExtension(t *testing.T) {
+	components.InvalidateFileTypeCache()
+	defer components.InvalidateFileTypeCache()
+
+	app := NewApp("/tmp/root", scanner.DefaultOptions())
+	root := &model.DirNode{FileNode: model.FileNode{Name: "/tmp/root"}}
+	root.AddChild(&model.FileNode{Name: "a.log", Size: 100, Usage: 100, Parent: root})
+	root.AddChild(&model.FileNode{Name: "b.log", Size: 10, Usage: 10, Parent: root})
+	root.UpdateSizeRecursive()
+	app.root = root
+	app.currentDir = root
+	app.refreshSorted()
+	app.state = StateBrowsing
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	if app.viewMode != ViewFileType {
+		t.Fatalf("expected '3' to switch to ViewFileType, got %v", app.viewMode)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.ftDrillExt != ".log" {
+		t.Fatalf("expected Enter to drill into .log, got %q", app.ftDrillExt)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if app.ftDrillExt != "" {
+		t.Fatal("expected Left to back out of the drill-down")
+	}
+}
@@ -0,0 +1,42 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderBar renders a flat, single-color progress bar of the given width,
+// filling ratio of it. This is the non-gradient counterpart to
+// Theme.BarGradient, shared by views (file types, and future histograms or
+// category drill-downs) that don't need a per-character gradient.
+// ratio is clamped to [0, 1] so callers don't need to guard against
+// over/under-full values.
+func RenderBar(width int, ratio float64, color, dimColor lipgloss.Color) string {
+	if width <= 0 {
+		return ""
+	}
+	if ratio < 0 {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+
+	var buf strings.Builder
+	filledStyle := lipgloss.NewStyle().Foreground(color)
+	dimStyle := lipgloss.NewStyle().Foreground(dimColor)
+
+	for i := 0; i < filled; i++ {
+		buf.WriteString(filledStyle.Render("="))
+	}
+	for i := filled; i < width; i++ {
+		buf.WriteString(dimStyle.Render("-"))
+	}
+	return buf.String()
+}
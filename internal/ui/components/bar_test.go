@@ -0,0 +1,43 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRenderBar_EdgeRatios(t *testing.T) {
+	const width = 10
+	color := lipgloss.Color("#ffffff")
+	dimColor := lipgloss.Color("#888888")
+
+	tests := []struct {
+		name       string
+		ratio      float64
+		wantFilled int
+	}{
+		{"zero", 0, 0},
+		{"full", 1, width},
+		{"over full clamps to width", 1.5, width},
+		{"negative clamps to zero", -0.5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar := RenderBar(width, tt.ratio, color, dimColor)
+			if got := strings.Count(bar, "="); got != tt.wantFilled {
+				t.Errorf("filled chars = %d, want %d", got, tt.wantFilled)
+			}
+			if got := strings.Count(bar, "-"); got != width-tt.wantFilled {
+				t.Errorf("dim chars = %d, want %d", got, width-tt.wantFilled)
+			}
+		})
+	}
+}
+
+func TestRenderBar_ZeroWidth(t *testing.T) {
+	if got := RenderBar(0, 0.5, lipgloss.Color("#fff"), lipgloss.Color("#888")); got != "" {
+		t.Errorf("expected empty bar for zero width, got %q", got)
+	}
+}
@@ -15,6 +15,14 @@ type ConfirmItem struct {
 	Path  string // Full path used for deletion
 	Size  int64
 	IsDir bool
+	// ItemCount is the recursive file+dir count for directory items (0 for
+	// files), so a delete confirmation shows the real blast radius instead
+	// of just one row per top-level item.
+	ItemCount int64
+	// DeepestPath is the full path of the most deeply nested item under a
+	// directory (empty for files), giving a concrete sense of how far a
+	// recursive delete actually reaches.
+	DeepestPath string
 }
 
 // RenderConfirmDialog renders the deletion confirmation modal.
@@ -53,6 +61,13 @@ func RenderConfirmDialog(theme style.Theme, items []ConfirmItem, width, height i
 		line := lipgloss.NewStyle().Foreground(theme.Error).Render(icon+name) +
 			lipgloss.NewStyle().Foreground(theme.TextMuted).Render("  "+size)
 		lines = append(lines, line)
+
+		if item.IsDir && item.ItemCount > 0 {
+			detail := fmt.Sprintf("      %s items, deepest: %s",
+				util.FormatCount(item.ItemCount),
+				util.TruncateString(item.DeepestPath, boxWidth-16))
+			lines = append(lines, lipgloss.NewStyle().Foreground(theme.TextMuted).Render(detail))
+		}
 	}
 
 	if len(items) > maxShow {
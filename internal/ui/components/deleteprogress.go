@@ -0,0 +1,53 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sadopc/godu/internal/ui/style"
+	"github.com/sadopc/godu/internal/util"
+)
+
+// RenderDeleteProgress renders the permanent-delete progress overlay. total
+// is an estimate of entries to remove (0 means no estimate, in which case
+// the overlay shows a plain counter with no bar).
+func RenderDeleteProgress(theme style.Theme, removed, total int, width, height int) string {
+	boxWidth := min(50, max(width-4, 1))
+
+	var lines []string
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Primary).
+		Render("  Deleting...")
+
+	lines = append(lines, title)
+
+	if total > 0 {
+		ratio := float64(removed) / float64(total)
+		if ratio > 1 {
+			ratio = 1
+		}
+		barWidth := max(boxWidth-8, 1)
+		bar := theme.BarGradient(barWidth, ratio)
+		lines = append(lines, fmt.Sprintf("  [%s] %3.0f%%", bar, ratio*100))
+	}
+
+	lines = append(lines, "")
+
+	removedLine := fmt.Sprintf("  Removed: %s", util.FormatCount(int64(removed)))
+	statStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	lines = append(lines, statStyle.Render(removedLine))
+
+	lines = append(lines, "")
+	lines = append(lines, lipgloss.NewStyle().Foreground(theme.TextMuted).Render("  Press esc to cancel (already-deleted items stay deleted)"))
+
+	content := strings.Join(lines, "\n")
+
+	box := theme.ModalStyle.
+		Width(boxWidth).
+		Render(content)
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -20,49 +20,93 @@ type CategoryStats struct {
 	TopExts   map[string]int64
 }
 
-// ftCache caches the result of aggregateFileTypes to avoid recomputing on every render.
-type ftCache struct {
-	dir         *model.DirNode
-	useApparent bool
-	showHidden  bool
-	stats       []CategoryStats
+// ftCacheEntry caches the result of aggregateFileTypes for one directory, so
+// switching between the file-type view and other views (or between sibling
+// directories) doesn't re-walk the whole subtree every render. Keyed per
+// DirNode rather than a single last-rendered slot, since aggregation cost
+// scales with subtree size and users commonly bounce between a handful of
+// directories while cleaning up.
+type ftCacheEntry struct {
+	sizeMode   model.SizeMode
+	showHidden bool
+	stats      []CategoryStats
 }
 
-var lastFTCache ftCache
-var lastFTCacheMu sync.Mutex
+var ftCacheByDir = make(map[*model.DirNode]ftCacheEntry)
+var ftCacheMu sync.Mutex
 
-// InvalidateFileTypeCache clears the cached file type aggregation,
-// forcing a recompute on the next render.
+// InvalidateFileTypeCache clears every cached file type aggregation, forcing
+// a recompute on next render. Call this whenever the tree mutates (e.g.
+// after a delete): a cached entry for any ancestor of the mutated subtree
+// would otherwise keep reporting stale totals.
 func InvalidateFileTypeCache() {
-	lastFTCacheMu.Lock()
-	defer lastFTCacheMu.Unlock()
-	lastFTCache = ftCache{}
+	ftCacheMu.Lock()
+	defer ftCacheMu.Unlock()
+	ftCacheByDir = make(map[*model.DirNode]ftCacheEntry)
 }
 
-// RenderFileTypes renders the file type breakdown view.
-func RenderFileTypes(theme style.Theme, dir *model.DirNode, useApparent bool, showHidden bool, width, height int) string {
-	if dir == nil {
-		return ""
-	}
-
+// sortedFileTypeStats returns the cached (or freshly aggregated) category
+// stats for dir, sorted by total size descending — the order both
+// RenderFileTypes and FileTypeExtensionAt must agree on so a cursor index
+// picked from one means the same extension in the other.
+func sortedFileTypeStats(dir *model.DirNode, sizeMode model.SizeMode, showHidden bool) []CategoryStats {
 	var stats []CategoryStats
-	lastFTCacheMu.Lock()
-	if lastFTCache.dir == dir && lastFTCache.useApparent == useApparent && lastFTCache.showHidden == showHidden {
-		stats = append([]CategoryStats(nil), lastFTCache.stats...)
+	ftCacheMu.Lock()
+	if entry, ok := ftCacheByDir[dir]; ok && entry.sizeMode == sizeMode && entry.showHidden == showHidden {
+		stats = append([]CategoryStats(nil), entry.stats...)
 	} else {
-		stats = aggregateFileTypes(dir, useApparent, showHidden)
-		lastFTCache = ftCache{
-			dir:         dir,
-			useApparent: useApparent,
-			showHidden:  showHidden,
-			stats:       append([]CategoryStats(nil), stats...),
+		stats = aggregateFileTypes(dir, sizeMode, showHidden)
+		ftCacheByDir[dir] = ftCacheEntry{
+			sizeMode:   sizeMode,
+			showHidden: showHidden,
+			stats:      append([]CategoryStats(nil), stats...),
 		}
 	}
-	lastFTCacheMu.Unlock()
+	ftCacheMu.Unlock()
 
 	sort.Slice(stats, func(i, j int) bool {
 		return stats[i].TotalSize > stats[j].TotalSize
 	})
+	return stats
+}
+
+// fileTypeExtensionOrder returns, in the order RenderFileTypes lists them,
+// the extension of every selectable row: each category's top 3 extensions,
+// category by category. Cursor position i selects fileTypeExtensionOrder[i].
+func fileTypeExtensionOrder(stats []CategoryStats) []string {
+	var order []string
+	for _, s := range stats {
+		for _, e := range topExtensionEntries(s.TopExts, 3) {
+			order = append(order, e.ext)
+		}
+	}
+	return order
+}
+
+// FileTypeExtensionAt returns the extension that cursor selects in the
+// current file type view, for the Enter-to-drill-down action. ok is false
+// if cursor is out of range (e.g. the tree changed since the cursor moved).
+func FileTypeExtensionAt(dir *model.DirNode, sizeMode model.SizeMode, showHidden bool, cursor int) (ext string, ok bool) {
+	if dir == nil {
+		return "", false
+	}
+	order := fileTypeExtensionOrder(sortedFileTypeStats(dir, sizeMode, showHidden))
+	if cursor < 0 || cursor >= len(order) {
+		return "", false
+	}
+	return order[cursor], true
+}
+
+// RenderFileTypes renders the file type breakdown view. Each category's top
+// extensions are listed as individually selectable rows; cursor indexes
+// into them in the order FileTypeExtensionAt agrees on, and the row it
+// selects is rendered with theme.SelectedRow.
+func RenderFileTypes(theme style.Theme, dir *model.DirNode, sizeMode model.SizeMode, showHidden bool, width, height, cursor int) string {
+	if dir == nil {
+		return ""
+	}
+
+	stats := sortedFileTypeStats(dir, sizeMode, showHidden)
 
 	var totalSize int64
 	for _, s := range stats {
@@ -100,6 +144,7 @@ func RenderFileTypes(theme style.Theme, dir *model.DirNode, useApparent bool, sh
 	sep := lipgloss.NewStyle().Foreground(theme.TextMuted).Render("  " + strings.Repeat("-", max(width-4, 0)))
 	lines = append(lines, sep)
 
+	row := 0
 	for _, s := range stats {
 		pct := util.Percent(s.TotalSize, totalSize)
 		ratio := pct / 100.0
@@ -109,17 +154,20 @@ func RenderFileTypes(theme style.Theme, dir *model.DirNode, useApparent bool, sh
 		count := lipgloss.NewStyle().Foreground(theme.TextSecondary).Width(countW).Align(lipgloss.Right).Render(util.FormatCount(s.FileCount))
 		size := lipgloss.NewStyle().Foreground(theme.TextSecondary).Width(sizeW).Align(lipgloss.Right).Render(util.FormatSize(s.TotalSize))
 
-		bar := renderCategoryBar(barW, ratio, catColor, theme.TextMuted)
+		bar := RenderBar(barW, ratio, catColor, theme.TextMuted)
 		pctStr := lipgloss.NewStyle().Foreground(theme.TextMuted).Render(fmt.Sprintf(" %5.1f%%", pct))
 
-		row := fmt.Sprintf("  %s %s %s  %s%s", catName, count, size, bar, pctStr)
-		lines = append(lines, row)
+		catLine := fmt.Sprintf("  %s %s %s  %s%s", catName, count, size, bar, pctStr)
+		lines = append(lines, catLine)
 
-		topExts := getTopExtensions(s.TopExts, 3)
-		if len(topExts) > 0 {
-			extStr := lipgloss.NewStyle().Foreground(theme.TextMuted).
-				Render("    " + strings.Join(topExts, ", "))
-			lines = append(lines, extStr)
+		for _, e := range topExtensionEntries(s.TopExts, 3) {
+			extLine := fmt.Sprintf("    %s (%s)", e.ext, util.FormatSize(e.size))
+			if row == cursor {
+				lines = append(lines, theme.SelectedRow.Width(max(width-2, 0)).Render(extLine))
+			} else {
+				lines = append(lines, lipgloss.NewStyle().Foreground(theme.TextMuted).Render(extLine))
+			}
+			row++
 		}
 	}
 
@@ -147,7 +195,7 @@ func RenderFileTypes(theme style.Theme, dir *model.DirNode, useApparent bool, sh
 	return strings.Join(lines[:height], "\n")
 }
 
-func aggregateFileTypes(dir *model.DirNode, useApparent bool, showHidden bool) []CategoryStats {
+func aggregateFileTypes(dir *model.DirNode, sizeMode model.SizeMode, showHidden bool) []CategoryStats {
 	catMap := make(map[model.FileCategory]*CategoryStats)
 
 	var walk func(d *model.DirNode)
@@ -163,12 +211,7 @@ func aggregateFileTypes(dir *model.DirNode, useApparent bool, showHidden bool) [
 				cat := model.ClassifyFile(child.GetName())
 				ext := model.GetExtension(child.GetName())
 
-				var sz int64
-				if useApparent {
-					sz = child.GetSize()
-				} else {
-					sz = child.GetUsage()
-				}
+				sz := sizeMode.Size(child)
 
 				st, ok := catMap[cat]
 				if !ok {
@@ -196,41 +239,109 @@ func aggregateFileTypes(dir *model.DirNode, useApparent bool, showHidden bool) [
 	return result
 }
 
-func getTopExtensions(exts map[string]int64, n int) []string {
-	type extEntry struct {
-		ext  string
-		size int64
-	}
+type extEntry struct {
+	ext  string
+	size int64
+}
+
+func topExtensionEntries(exts map[string]int64, n int) []extEntry {
 	var entries []extEntry
 	for ext, size := range exts {
 		entries = append(entries, extEntry{ext, size})
 	}
 	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].size > entries[j].size
+		if entries[i].size != entries[j].size {
+			return entries[i].size > entries[j].size
+		}
+		return entries[i].ext < entries[j].ext
 	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
 
-	var result []string
-	for i := 0; i < n && i < len(entries); i++ {
-		result = append(result, fmt.Sprintf("%s (%s)", entries[i].ext, util.FormatSize(entries[i].size)))
+// ExtensionFile is one file matched by CollectFilesByExtension.
+type ExtensionFile struct {
+	Path string
+	Size int64
+}
+
+// CollectFilesByExtension walks dir and returns every file whose extension
+// (per model.GetExtension) matches ext, sorted by size descending — the
+// drill-down behind RenderFileTypes' Enter-on-extension action.
+func CollectFilesByExtension(dir *model.DirNode, ext string, sizeMode model.SizeMode, showHidden bool) []ExtensionFile {
+	if dir == nil {
+		return nil
 	}
-	return result
+
+	var files []ExtensionFile
+	var walk func(d *model.DirNode)
+	walk = func(d *model.DirNode) {
+		for _, child := range d.ReadChildren() {
+			name := child.GetName()
+			if !showHidden && len(name) > 0 && name[0] == '.' {
+				continue
+			}
+			if cd, ok := child.(*model.DirNode); ok {
+				walk(cd)
+				continue
+			}
+			if model.GetExtension(name) != ext {
+				continue
+			}
+			files = append(files, ExtensionFile{Path: child.Path(), Size: sizeMode.Size(child)})
+		}
+	}
+	walk(dir)
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Size > files[j].Size
+	})
+	return files
 }
 
-func renderCategoryBar(width int, ratio float64, color, dimColor lipgloss.Color) string {
-	filled := int(ratio * float64(width))
-	if filled > width {
-		filled = width
+// RenderExtensionFiles renders the drill-down list of every file matching
+// ext, reached by pressing Enter on an extension row in RenderFileTypes.
+func RenderExtensionFiles(theme style.Theme, ext string, files []ExtensionFile, width, height int) string {
+	if len(files) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(theme.TextMuted).
+			Render(fmt.Sprintf("  (no files found for %s)", ext))
+	}
+
+	sizeW := 12
+	var lines []string
+
+	hdrStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.TextPrimary)
+	header := fmt.Sprintf("  %*s  %s (%s)", sizeW, "Size", "Path", ext)
+	lines = append(lines, hdrStyle.Render(header))
+
+	sep := lipgloss.NewStyle().Foreground(theme.TextMuted).Render("  " + strings.Repeat("-", max(width-4, 0)))
+	lines = append(lines, sep)
+
+	pathWidth := width - sizeW - 4
+	if pathWidth < 1 {
+		pathWidth = 1
 	}
 
-	var buf strings.Builder
-	filledStyle := lipgloss.NewStyle().Foreground(color)
-	dimStyle := lipgloss.NewStyle().Foreground(dimColor)
+	for _, f := range files {
+		sizeStr := lipgloss.NewStyle().Foreground(theme.TextSecondary).Width(sizeW).Align(lipgloss.Right).Render(util.FormatSize(f.Size))
+		path := util.TruncateString(f.Path, pathWidth)
+		pathStyled := theme.FileName.Render(path)
+		lines = append(lines, fmt.Sprintf("  %s  %s", sizeStr, pathStyled))
+	}
 
-	for i := 0; i < filled; i++ {
-		buf.WriteString(filledStyle.Render("="))
+	for len(lines) < height {
+		lines = append(lines, "")
 	}
-	for i := filled; i < width; i++ {
-		buf.WriteString(dimStyle.Render("-"))
+
+	bgStyle := lipgloss.NewStyle().
+		Background(theme.BgDark).
+		Width(width)
+	for i := range lines[:height] {
+		lines[i] = bgStyle.Render(lines[i])
 	}
-	return buf.String()
+
+	return strings.Join(lines[:height], "\n")
 }
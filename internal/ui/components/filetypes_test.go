@@ -0,0 +1,111 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/ui/style"
+)
+
+func TestRenderFileTypes_CachesPerDirectory(t *testing.T) {
+	InvalidateFileTypeCache()
+	defer InvalidateFileTypeCache()
+
+	theme := style.DefaultTheme()
+	dirA := &model.DirNode{FileNode: model.FileNode{Name: "a"}}
+	dirA.AddChild(&model.FileNode{Name: "a.txt", Size: 10, Usage: 10, Parent: dirA})
+	dirA.UpdateSize()
+
+	dirB := &model.DirNode{FileNode: model.FileNode{Name: "b"}}
+	dirB.AddChild(&model.FileNode{Name: "b.txt", Size: 20, Usage: 20, Parent: dirB})
+	dirB.UpdateSize()
+
+	RenderFileTypes(theme, dirA, model.SizeModeApparent, true, 60, 10, 0)
+	RenderFileTypes(theme, dirB, model.SizeModeApparent, true, 60, 10, 0)
+
+	ftCacheMu.Lock()
+	_, hasA := ftCacheByDir[dirA]
+	_, hasB := ftCacheByDir[dirB]
+	ftCacheMu.Unlock()
+
+	if !hasA || !hasB {
+		t.Fatalf("expected both directories to have cached entries, dirA=%v dirB=%v", hasA, hasB)
+	}
+}
+
+func TestInvalidateFileTypeCache_ClearsAllEntries(t *testing.T) {
+	theme := style.DefaultTheme()
+	dir := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	dir.AddChild(&model.FileNode{Name: "f.txt", Size: 5, Usage: 5, Parent: dir})
+	dir.UpdateSize()
+
+	RenderFileTypes(theme, dir, model.SizeModeApparent, true, 60, 10, 0)
+
+	ftCacheMu.Lock()
+	_, ok := ftCacheByDir[dir]
+	ftCacheMu.Unlock()
+	if !ok {
+		t.Fatal("expected cache entry before invalidation")
+	}
+
+	InvalidateFileTypeCache()
+
+	ftCacheMu.Lock()
+	_, ok = ftCacheByDir[dir]
+	ftCacheMu.Unlock()
+	if ok {
+		t.Fatal("expected cache entry to be cleared after InvalidateFileTypeCache")
+	}
+}
+
+func TestCollectFilesByExtension_FiltersAndSortsDescending(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	sub := &model.DirNode{FileNode: model.FileNode{Name: "sub", Parent: root}}
+	root.AddChild(sub)
+	root.AddChild(&model.FileNode{Name: "small.log", Size: 5, Usage: 5, Parent: root})
+	root.AddChild(&model.FileNode{Name: "readme.md", Size: 100, Usage: 100, Parent: root})
+	sub.AddChild(&model.FileNode{Name: "big.log", Size: 50, Usage: 50, Parent: sub})
+	root.UpdateSizeRecursive()
+
+	files := CollectFilesByExtension(root, ".log", model.SizeModeApparent, true)
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 .log files, got %d: %+v", len(files), files)
+	}
+	if files[0].Path != sub.Path()+"/big.log" || files[0].Size != 50 {
+		t.Fatalf("expected big.log first with size 50, got %+v", files[0])
+	}
+	if files[1].Path != root.Path()+"/small.log" || files[1].Size != 5 {
+		t.Fatalf("expected small.log second with size 5, got %+v", files[1])
+	}
+}
+
+func TestCollectFilesByExtension_SkipsHiddenWhenDisabled(t *testing.T) {
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.FileNode{Name: ".hidden.log", Size: 5, Usage: 5, Parent: root})
+	root.UpdateSizeRecursive()
+
+	files := CollectFilesByExtension(root, ".log", model.SizeModeApparent, false)
+	if len(files) != 0 {
+		t.Fatalf("expected hidden .log file to be excluded, got %+v", files)
+	}
+}
+
+func TestFileTypeExtensionAt_MatchesRenderOrder(t *testing.T) {
+	InvalidateFileTypeCache()
+	defer InvalidateFileTypeCache()
+
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.FileNode{Name: "a.log", Size: 100, Usage: 100, Parent: root})
+	root.AddChild(&model.FileNode{Name: "b.log", Size: 10, Usage: 10, Parent: root})
+	root.UpdateSizeRecursive()
+
+	ext, ok := FileTypeExtensionAt(root, model.SizeModeApparent, true, 0)
+	if !ok || ext != ".log" {
+		t.Fatalf("expected .log as the first selectable row, got %q ok=%v", ext, ok)
+	}
+
+	if _, ok := FileTypeExtensionAt(root, model.SizeModeApparent, true, 99); ok {
+		t.Fatal("expected out-of-range cursor to report ok=false")
+	}
+}
@@ -0,0 +1,92 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/ops"
+	"github.com/sadopc/godu/internal/ui/style"
+	"github.com/sadopc/godu/internal/util"
+)
+
+// hlCacheEntry caches the result of ops.HardlinkGroups for one root, so
+// switching between the hardlinks view and other views doesn't re-walk the
+// whole tree every render. Keyed per DirNode like the largest-files cache.
+type hlCacheEntry struct {
+	useApparent bool
+	groups      []ops.HardlinkGroup
+}
+
+var hlCacheByRoot = make(map[*model.DirNode]hlCacheEntry)
+var hlCacheMu sync.Mutex
+
+// InvalidateHardlinksCache clears every cached hardlink grouping, forcing a
+// recompute on next render. Call this whenever the tree mutates (e.g. after
+// a delete): a cached entry would otherwise keep reporting paths that no
+// longer exist.
+func InvalidateHardlinksCache() {
+	hlCacheMu.Lock()
+	defer hlCacheMu.Unlock()
+	hlCacheByRoot = make(map[*model.DirNode]hlCacheEntry)
+}
+
+// RenderHardlinks renders every hardlink set found under root, each group
+// showing its shared inode, the size counted once, and its member paths.
+func RenderHardlinks(theme style.Theme, root *model.DirNode, useApparent bool, width, height int) string {
+	if root == nil {
+		return ""
+	}
+
+	sizeOf := func(node model.TreeNode) int64 {
+		if useApparent {
+			return node.GetSize()
+		}
+		return node.GetUsage()
+	}
+
+	var groups []ops.HardlinkGroup
+	hlCacheMu.Lock()
+	if entry, ok := hlCacheByRoot[root]; ok && entry.useApparent == useApparent {
+		groups = append([]ops.HardlinkGroup(nil), entry.groups...)
+	} else {
+		groups = ops.HardlinkGroups(root, sizeOf)
+		hlCacheByRoot[root] = hlCacheEntry{useApparent: useApparent, groups: append([]ops.HardlinkGroup(nil), groups...)}
+	}
+	hlCacheMu.Unlock()
+
+	if len(groups) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(theme.TextMuted).
+			Render("  (no hardlink sets found)")
+	}
+
+	var lines []string
+	hdrStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.TextPrimary)
+	pathStyle := theme.FileName
+	sizeStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+
+	for _, g := range groups {
+		header := fmt.Sprintf("  %s shared, %d links (inode %d)", util.FormatSize(g.Size), len(g.Paths), g.Inode)
+		lines = append(lines, hdrStyle.Render(header))
+		for _, p := range g.Paths {
+			lines = append(lines, fmt.Sprintf("    %s %s", sizeStyle.Render("-"), pathStyle.Render(util.TruncateString(p, max(width-8, 1)))))
+		}
+		lines = append(lines, "")
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	bgStyle := lipgloss.NewStyle().
+		Background(theme.BgDark).
+		Width(width)
+	for i := range lines[:height] {
+		lines[i] = bgStyle.Render(lines[i])
+	}
+
+	return strings.Join(lines[:height], "\n")
+}
@@ -0,0 +1,79 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/ui/style"
+)
+
+func TestRenderHardlinks_ListsGroupedPathsAndSharedSize(t *testing.T) {
+	InvalidateHardlinksCache()
+	defer InvalidateHardlinksCache()
+
+	theme := style.DefaultTheme()
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	linkA := &model.FileNode{Name: "a.txt", Size: 100, Usage: 100, Inode: 42, Flag: model.FlagHardlink, Parent: root}
+	linkB := &model.FileNode{Name: "b.txt", Size: 100, Usage: 100, Inode: 42, Flag: model.FlagHardlink, Parent: root}
+	unique := &model.FileNode{Name: "c.txt", Size: 5, Usage: 5, Parent: root}
+	root.AddChild(linkA)
+	root.AddChild(linkB)
+	root.AddChild(unique)
+	root.UpdateSize()
+
+	out := RenderHardlinks(theme, root, true, 60, 10)
+	if !strings.Contains(out, "a.txt") || !strings.Contains(out, "b.txt") {
+		t.Fatalf("expected both linked paths in output, got %q", out)
+	}
+	if strings.Contains(out, "c.txt") {
+		t.Fatalf("expected the unlinked file to be excluded, got %q", out)
+	}
+	if !strings.Contains(out, "2 links") {
+		t.Fatalf("expected the group to report 2 links, got %q", out)
+	}
+}
+
+func TestRenderHardlinks_NoGroupsFound(t *testing.T) {
+	InvalidateHardlinksCache()
+	defer InvalidateHardlinksCache()
+
+	theme := style.DefaultTheme()
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.FileNode{Name: "solo.txt", Size: 5, Usage: 5, Parent: root})
+	root.UpdateSize()
+
+	out := RenderHardlinks(theme, root, true, 60, 10)
+	if !strings.Contains(out, "no hardlink sets found") {
+		t.Fatalf("expected empty-state message, got %q", out)
+	}
+}
+
+func TestRenderHardlinks_CachesPerRoot(t *testing.T) {
+	InvalidateHardlinksCache()
+	defer InvalidateHardlinksCache()
+
+	theme := style.DefaultTheme()
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 5, Usage: 5, Inode: 1, Flag: model.FlagHardlink, Parent: root})
+	root.AddChild(&model.FileNode{Name: "b.txt", Size: 5, Usage: 5, Inode: 1, Flag: model.FlagHardlink, Parent: root})
+	root.UpdateSize()
+
+	RenderHardlinks(theme, root, true, 60, 10)
+
+	hlCacheMu.Lock()
+	_, ok := hlCacheByRoot[root]
+	hlCacheMu.Unlock()
+	if !ok {
+		t.Fatal("expected cache entry before invalidation")
+	}
+
+	InvalidateHardlinksCache()
+
+	hlCacheMu.Lock()
+	_, ok = hlCacheByRoot[root]
+	hlCacheMu.Unlock()
+	if ok {
+		t.Fatal("expected cache entry to be cleared after InvalidateHardlinksCache")
+	}
+}
@@ -3,15 +3,19 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/scanner"
 	"github.com/sadopc/godu/internal/ui/style"
 	"github.com/sadopc/godu/internal/util"
 )
 
-// RenderHeader renders the top header bar.
-func RenderHeader(theme style.Theme, root *model.DirNode, useApparent bool, width int) string {
+// RenderHeader renders the top header bar. fsUsedBytes and fsTotalBytes are
+// the scanned filesystem's capacity (see scanner.Progress); fsTotalBytes <= 0
+// means unavailable and the disk-usage figure is omitted.
+func RenderHeader(theme style.Theme, root *model.DirNode, sizeMode model.SizeMode, width int, fsUsedBytes, fsTotalBytes int64) string {
 	if root == nil || width < 10 {
 		return ""
 	}
@@ -19,12 +23,9 @@ func RenderHeader(theme style.Theme, root *model.DirNode, useApparent bool, widt
 	titleStr := " godu"
 	titleStyled := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).Render(titleStr)
 
-	size := root.GetSize()
-	if !useApparent {
-		size = root.GetUsage()
-	}
+	size := sizeMode.Size(root)
 	sizeSuffix := ""
-	if !useApparent && root.GetFlag()&model.FlagUsageEstimated != 0 {
+	if sizeMode != model.SizeModeApparent && root.GetFlag()&model.FlagUsageEstimated != 0 {
 		sizeSuffix = "~"
 	}
 	stats := fmt.Sprintf("%s items  %s%s ",
@@ -32,6 +33,11 @@ func RenderHeader(theme style.Theme, root *model.DirNode, useApparent bool, widt
 		util.FormatSize(size),
 		sizeSuffix,
 	)
+	if fsTotalBytes > 0 {
+		pct, _ := scanner.FilesystemUsagePercent(fsTotalBytes, fsTotalBytes-fsUsedBytes)
+		stats = fmt.Sprintf("%s%s of %s used (%.0f%%)  ",
+			stats, util.FormatSize(fsUsedBytes), util.FormatSize(fsTotalBytes), pct)
+	}
 	statsStyled := lipgloss.NewStyle().Foreground(theme.TextMuted).Render(stats)
 
 	titleW := lipgloss.Width(titleStyled)
@@ -58,8 +64,11 @@ func RenderHeader(theme style.Theme, root *model.DirNode, useApparent bool, widt
 	return theme.HeaderStyle.Width(width).Render(line)
 }
 
-// RenderBreadcrumb renders the breadcrumb path navigation.
-func RenderBreadcrumb(theme style.Theme, current *model.DirNode, width int) string {
+// RenderBreadcrumb renders the breadcrumb path navigation. importedAt is the
+// exporting godu's scan time for an imported tree (see ops.ImportMeta); the
+// zero time means not imported or the import had no timestamp, and the
+// "imported: <timestamp>" suffix is omitted.
+func RenderBreadcrumb(theme style.Theme, current *model.DirNode, width int, importedAt time.Time) string {
 	if current == nil {
 		return ""
 	}
@@ -89,13 +98,30 @@ func RenderBreadcrumb(theme style.Theme, current *model.DirNode, width int) stri
 
 	breadcrumb := " " + strings.Join(parts, sep)
 
-	// Truncate if too wide
-	if lipgloss.Width(breadcrumb) > width {
+	var importedStyled string
+	if !importedAt.IsZero() {
+		importedStyled = lipgloss.NewStyle().Foreground(theme.TextMuted).Render("imported: " + importedAt.Format("2006-01-02 15:04") + " ")
+	}
+
+	// Truncate the path if too wide, then drop the imported suffix too if it
+	// still doesn't fit; the path always wins the remaining space.
+	if lipgloss.Width(breadcrumb)+lipgloss.Width(importedStyled) > width {
 		// Show just the last 2 segments
 		if len(parts) > 2 {
 			ellipsis := lipgloss.NewStyle().Foreground(theme.TextMuted).Render("...")
 			breadcrumb = " " + ellipsis + sep + strings.Join(parts[len(parts)-2:], sep)
 		}
+		if lipgloss.Width(breadcrumb)+lipgloss.Width(importedStyled) > width {
+			importedStyled = ""
+		}
+	}
+
+	if importedStyled != "" {
+		gap := width - lipgloss.Width(breadcrumb) - lipgloss.Width(importedStyled)
+		if gap < 1 {
+			gap = 1
+		}
+		breadcrumb += strings.Repeat(" ", gap) + importedStyled
 	}
 
 	return theme.BreadcrumbStyle.Width(width).Render(breadcrumb)
@@ -8,8 +8,9 @@ import (
 	"github.com/sadopc/godu/internal/ui/style"
 )
 
-// RenderHelp renders the help overlay.
-func RenderHelp(theme style.Theme, width, height int) string {
+// RenderHelp renders the help overlay. readOnly hides the delete hint, since
+// the action is disabled in that mode.
+func RenderHelp(theme style.Theme, width, height int, readOnly bool) string {
 	boxWidth := min(60, max(width-4, 1))
 
 	title := theme.ModalTitle.Render("  godu - Keyboard Shortcuts")
@@ -25,6 +26,9 @@ func RenderHelp(theme style.Theme, width, height int) string {
 				{"h/l", "Go to parent / enter directory"},
 				{"Enter", "Enter directory"},
 				{"Backspace", "Go back"},
+				{">", "Dive into largest subdirectory"},
+				{"PgUp/PgDn", "Page up/down"},
+				{"Home/End", "Jump to top/bottom"},
 			},
 		},
 		{
@@ -33,6 +37,8 @@ func RenderHelp(theme style.Theme, width, height int) string {
 				{"1", "Tree view"},
 				{"2", "Treemap view"},
 				{"3", "File type breakdown"},
+				{"4", "Largest files (whole tree)"},
+				{"5", "Hardlink sets (whole tree)"},
 			},
 		},
 		{
@@ -48,16 +54,35 @@ func RenderHelp(theme style.Theme, width, height int) string {
 			name: "Actions",
 			binds: []struct{ key, desc string }{
 				{"Space", "Mark/unmark item"},
+				{"m", "Mark/unmark all files under directory"},
 				{"d", "Delete marked/current"},
 				{"E", "Export to JSON"},
+				{"T", "Write text snapshot"},
 				{"r", "Rescan directory"},
+				{"R", "Refresh current directory only"},
+				{":", "Jump to path"},
+				{"/", "Search/filter by name"},
+				{"c", "Copy path to clipboard"},
+				{"o", "Open in file manager"},
+				{"f2", "Rename"},
+				{"u", "Restore last trashed item"},
+				{"U", "Show undo log"},
 			},
 		},
 		{
 			name: "Toggles & General",
 			binds: []struct{ key, desc string }{
-				{"a", "Apparent / disk size"},
+				{"a", "Cycle size: disk / apparent / max"},
+				{"i", "Toggle icons"},
+				{"I", "Toggle item counts"},
+				{"W", "Treemap weight: size / file count"},
 				{".", "Show/hide hidden files"},
+				{"H", "Peek hidden files (current dir only)"},
+				{"F", "Show files only (hide directories)"},
+				{"S", "Show stale files only (requires -older-than)"},
+				{"x", "Cycle min size filter (off/1MiB/10MiB/100MiB)"},
+				{"P", "Persist marks across navigation"},
+				{"%", "Toggle percentage bar: of parent / of whole scan"},
 				{"?", "Toggle help"},
 				{"q", "Quit"},
 			},
@@ -76,6 +101,9 @@ func RenderHelp(theme style.Theme, width, height int) string {
 		lines = append(lines, secTitle)
 
 		for _, b := range sec.binds {
+			if readOnly && b.key == "d" {
+				continue
+			}
 			key := lipgloss.NewStyle().
 				Foreground(theme.Primary).
 				Bold(true).
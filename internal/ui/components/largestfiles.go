@@ -0,0 +1,105 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/ops"
+	"github.com/sadopc/godu/internal/ui/style"
+	"github.com/sadopc/godu/internal/util"
+)
+
+// lfCacheEntry caches the result of ops.CollectFiles for one root, so
+// switching between the largest-files view and other views doesn't re-walk
+// the whole tree every render. Keyed per DirNode like the file-type cache.
+type lfCacheEntry struct {
+	useApparent bool
+	n           int
+	results     []ops.FileResult
+}
+
+var lfCacheByRoot = make(map[*model.DirNode]lfCacheEntry)
+var lfCacheMu sync.Mutex
+
+// InvalidateLargestFilesCache clears every cached largest-files result,
+// forcing a recompute on next render. Call this whenever the tree mutates
+// (e.g. after a delete): a cached entry would otherwise keep reporting
+// files that no longer exist.
+func InvalidateLargestFilesCache() {
+	lfCacheMu.Lock()
+	defer lfCacheMu.Unlock()
+	lfCacheByRoot = make(map[*model.DirNode]lfCacheEntry)
+}
+
+// RenderLargestFiles renders a flat "top N largest files" list across the
+// whole tree rooted at root, regardless of which directory is current.
+func RenderLargestFiles(theme style.Theme, root *model.DirNode, useApparent bool, n, width, height int) string {
+	if root == nil {
+		return ""
+	}
+
+	sizeOf := func(node model.TreeNode) int64 {
+		if useApparent {
+			return node.GetSize()
+		}
+		return node.GetUsage()
+	}
+
+	var results []ops.FileResult
+	lfCacheMu.Lock()
+	if entry, ok := lfCacheByRoot[root]; ok && entry.useApparent == useApparent && entry.n == n {
+		results = append([]ops.FileResult(nil), entry.results...)
+	} else {
+		results, _ = ops.CollectFiles(root, n, sizeOf)
+		lfCacheByRoot[root] = lfCacheEntry{
+			useApparent: useApparent,
+			n:           n,
+			results:     append([]ops.FileResult(nil), results...),
+		}
+	}
+	lfCacheMu.Unlock()
+
+	if len(results) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(theme.TextMuted).
+			Render("  (no files found)")
+	}
+
+	sizeW := 12
+	var lines []string
+
+	hdrStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.TextPrimary)
+	header := fmt.Sprintf("  %*s  %s", sizeW, "Size", "Path")
+	lines = append(lines, hdrStyle.Render(header))
+
+	sep := lipgloss.NewStyle().Foreground(theme.TextMuted).Render("  " + strings.Repeat("-", max(width-4, 0)))
+	lines = append(lines, sep)
+
+	pathWidth := width - sizeW - 4
+	if pathWidth < 1 {
+		pathWidth = 1
+	}
+
+	for _, r := range results {
+		sizeStr := lipgloss.NewStyle().Foreground(theme.TextSecondary).Width(sizeW).Align(lipgloss.Right).Render(util.FormatSize(r.Size))
+		path := util.TruncateString(r.Path, pathWidth)
+		pathStyled := theme.FileName.Render(path)
+		lines = append(lines, fmt.Sprintf("  %s  %s", sizeStr, pathStyled))
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	bgStyle := lipgloss.NewStyle().
+		Background(theme.BgDark).
+		Width(width)
+	for i := range lines[:height] {
+		lines[i] = bgStyle.Render(lines[i])
+	}
+
+	return strings.Join(lines[:height], "\n")
+}
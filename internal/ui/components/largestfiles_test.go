@@ -0,0 +1,60 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/ui/style"
+)
+
+func TestRenderLargestFiles_OrdersBySizeDescending(t *testing.T) {
+	InvalidateLargestFilesCache()
+	defer InvalidateLargestFilesCache()
+
+	theme := style.DefaultTheme()
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	small := &model.FileNode{Name: "small.txt", Size: 10, Usage: 10, Parent: root}
+	big := &model.FileNode{Name: "big.txt", Size: 100, Usage: 100, Parent: root}
+	root.AddChild(small)
+	root.AddChild(big)
+	root.UpdateSize()
+
+	out := RenderLargestFiles(theme, root, true, 10, 60, 10)
+	bigIdx := strings.Index(out, "big.txt")
+	smallIdx := strings.Index(out, "small.txt")
+	if bigIdx == -1 || smallIdx == -1 {
+		t.Fatalf("expected both files in output, got %q", out)
+	}
+	if bigIdx > smallIdx {
+		t.Fatalf("expected big.txt to be listed before small.txt, got %q", out)
+	}
+}
+
+func TestRenderLargestFiles_CachesPerRoot(t *testing.T) {
+	InvalidateLargestFilesCache()
+	defer InvalidateLargestFilesCache()
+
+	theme := style.DefaultTheme()
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.FileNode{Name: "f.txt", Size: 5, Usage: 5, Parent: root})
+	root.UpdateSize()
+
+	RenderLargestFiles(theme, root, true, 10, 60, 10)
+
+	lfCacheMu.Lock()
+	_, ok := lfCacheByRoot[root]
+	lfCacheMu.Unlock()
+	if !ok {
+		t.Fatal("expected cache entry before invalidation")
+	}
+
+	InvalidateLargestFilesCache()
+
+	lfCacheMu.Lock()
+	_, ok = lfCacheByRoot[root]
+	lfCacheMu.Unlock()
+	if ok {
+		t.Fatal("expected cache entry to be cleared after InvalidateLargestFilesCache")
+	}
+}
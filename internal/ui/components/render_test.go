@@ -1,6 +1,7 @@
 package components
 
 import (
+	"strings"
 	"sync"
 	"testing"
 
@@ -18,7 +19,7 @@ func TestRenderHelp_SmallWidth(t *testing.T) {
 					t.Fatalf("RenderHelp panicked at width=%d: %v", w, r)
 				}
 			}()
-			RenderHelp(theme, w, 10)
+			RenderHelp(theme, w, 10, false)
 		})
 	}
 }
@@ -38,6 +39,31 @@ func TestRenderConfirmDialog_SmallWidth(t *testing.T) {
 	}
 }
 
+func TestRenderConfirmDialog_ShowsItemCountAndDeepestPathForDirs(t *testing.T) {
+	theme := style.DefaultTheme()
+	items := []ConfirmItem{
+		{Name: "big-dir", Path: "/tmp/big-dir", Size: 1000, IsDir: true, ItemCount: 50000, DeepestPath: "/tmp/big-dir/a/b/c/deep.txt"},
+	}
+
+	out := RenderConfirmDialog(theme, items, 80, 24)
+	if !strings.Contains(out, "50.0K items") {
+		t.Fatalf("expected recursive item count in dialog, got:\n%s", out)
+	}
+	if !strings.Contains(out, "deep.txt") {
+		t.Fatalf("expected deepest path in dialog, got:\n%s", out)
+	}
+}
+
+func TestRenderConfirmDialog_FilesShowNoBlastRadiusDetail(t *testing.T) {
+	theme := style.DefaultTheme()
+	items := []ConfirmItem{{Name: "file.txt", Path: "/tmp/file.txt", Size: 100}}
+
+	out := RenderConfirmDialog(theme, items, 80, 24)
+	if strings.Contains(out, "items, deepest:") {
+		t.Fatalf("expected no blast-radius detail line for a plain file, got:\n%s", out)
+	}
+}
+
 func TestRenderScanProgress_SmallWidth(t *testing.T) {
 	theme := style.DefaultTheme()
 	p := scanner.Progress{}
@@ -48,7 +74,7 @@ func TestRenderScanProgress_SmallWidth(t *testing.T) {
 					t.Fatalf("RenderScanProgress panicked at width=%d: %v", w, r)
 				}
 			}()
-			RenderScanProgress(theme, p, w, 10)
+			RenderScanProgress(theme, p, 0, w, 10)
 		})
 	}
 }
@@ -65,7 +91,7 @@ func TestRenderFileTypes_SmallWidth(t *testing.T) {
 					t.Fatalf("RenderFileTypes panicked at width=%d: %v", w, r)
 				}
 			}()
-			RenderFileTypes(theme, dir, false, true, w, 10)
+			RenderFileTypes(theme, dir, model.SizeModeDisk, true, w, 10, 0)
 		})
 	}
 }
@@ -88,7 +114,11 @@ func TestRenderFileTypes_ConcurrentCacheAccess(t *testing.T) {
 				if j%5 == 0 {
 					InvalidateFileTypeCache()
 				}
-				RenderFileTypes(theme, root, j%2 == 0, true, 80, 20)
+				sizeMode := model.SizeModeDisk
+				if j%2 == 0 {
+					sizeMode = model.SizeModeApparent
+				}
+				RenderFileTypes(theme, root, sizeMode, true, 80, 20, 0)
 			}
 		}(i)
 	}
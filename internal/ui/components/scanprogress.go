@@ -10,8 +10,24 @@ import (
 	"github.com/sadopc/godu/internal/util"
 )
 
-// RenderScanProgress renders the scanning progress overlay.
-func RenderScanProgress(theme style.Theme, progress scanner.Progress, width, height int) string {
+// scanProgressRatio computes the fraction of expectedItems scanned so far.
+// It returns false if expectedItems isn't known (<= 0), in which case the
+// overlay falls back to plain counters with no bar.
+func scanProgressRatio(scanned, expectedItems int64) (float64, bool) {
+	if expectedItems <= 0 {
+		return 0, false
+	}
+	ratio := float64(scanned) / float64(expectedItems)
+	if ratio > 1 {
+		ratio = 1
+	}
+	return ratio, true
+}
+
+// RenderScanProgress renders the scanning progress overlay. expectedItems is
+// the prior total item count for a rescan, used to draw a completion bar; 0
+// means no estimate is available, and the overlay shows plain counters.
+func RenderScanProgress(theme style.Theme, progress scanner.Progress, expectedItems int64, width, height int) string {
 	boxWidth := min(50, max(width-4, 1))
 
 	var lines []string
@@ -22,11 +38,21 @@ func RenderScanProgress(theme style.Theme, progress scanner.Progress, width, hei
 		Render("  Scanning...")
 
 	lines = append(lines, title)
+
+	if ratio, ok := scanProgressRatio(progress.FilesScanned+progress.DirsScanned, expectedItems); ok {
+		barWidth := max(boxWidth-8, 1)
+		bar := theme.BarGradient(barWidth, ratio)
+		lines = append(lines, fmt.Sprintf("  [%s] %3.0f%%", bar, ratio*100))
+	}
+
 	lines = append(lines, "")
 
 	filesLine := fmt.Sprintf("  Files:  %s", util.FormatCount(progress.FilesScanned))
 	dirsLine := fmt.Sprintf("  Dirs:   %s", util.FormatCount(progress.DirsScanned))
 	sizeLine := fmt.Sprintf("  Size:   %s", util.FormatSize(progress.BytesFound))
+	if pct, ok := progress.FilesystemPercent(); ok {
+		sizeLine = fmt.Sprintf("  Size:   %s (~%.0f%% of filesystem)", util.FormatSize(progress.BytesFound), pct)
+	}
 	speedLine := fmt.Sprintf("  Speed:  %s items/s", util.FormatCount(int64(progress.ItemsPerSecond())))
 
 	statStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
@@ -40,6 +66,16 @@ func RenderScanProgress(theme style.Theme, progress scanner.Progress, width, hei
 		lines = append(lines, theme.ErrorText.Render(errLine))
 	}
 
+	if progress.Vanished > 0 {
+		vanishedLine := fmt.Sprintf("  Vanished: %d", progress.Vanished)
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.TextMuted).Render(vanishedLine))
+	}
+
+	if progress.CurrentPath != "" {
+		pathLine := fmt.Sprintf("  %s", util.TruncateString(progress.CurrentPath, boxWidth-4))
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.TextMuted).Render(pathLine))
+	}
+
 	lines = append(lines, "")
 
 	elapsed := fmt.Sprintf("  Elapsed: %.1fs", progress.Duration.Seconds())
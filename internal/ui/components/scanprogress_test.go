@@ -0,0 +1,30 @@
+package components
+
+import "testing"
+
+func TestScanProgressRatio_ComputesFractionOfExpectation(t *testing.T) {
+	tests := []struct {
+		name          string
+		scanned       int64
+		expectedItems int64
+		wantRatio     float64
+		wantOK        bool
+	}{
+		{"no estimate available", 50, 0, 0, false},
+		{"negative estimate treated as unavailable", 50, -1, 0, false},
+		{"partial progress", 25, 100, 0.25, true},
+		{"complete", 100, 100, 1, true},
+		{"clamped when scanned exceeds estimate", 150, 100, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRatio, gotOK := scanProgressRatio(tt.scanned, tt.expectedItems)
+			if gotOK != tt.wantOK {
+				t.Fatalf("scanProgressRatio(%d, %d) ok = %v, want %v", tt.scanned, tt.expectedItems, gotOK, tt.wantOK)
+			}
+			if gotOK && gotRatio != tt.wantRatio {
+				t.Fatalf("scanProgressRatio(%d, %d) = %v, want %v", tt.scanned, tt.expectedItems, gotRatio, tt.wantRatio)
+			}
+		})
+	}
+}
@@ -12,16 +12,22 @@ import (
 
 // StatusInfo holds the current state for the status bar.
 type StatusInfo struct {
-	CurrentDir     *model.DirNode
-	ItemCount      int
-	MarkedCount    int
-	MarkedSize     int64
-	UsageEstimated bool
-	UseApparent    bool
-	ShowHidden     bool
-	SortField      model.SortField
-	ViewMode       int
-	ErrorMsg       string
+	CurrentDir         *model.DirNode
+	ItemCount          int
+	MarkedCount        int
+	MarkedSizeApparent int64
+	MarkedSizeDisk     int64
+	UsageEstimated     bool
+	SizeMode           model.SizeMode
+	ShowHidden         bool
+	FilesOnly          bool
+	MinSizeFloor       int64
+	PersistMarks       bool
+	ReadOnly           bool
+	FilterText         string
+	SortField          model.SortField
+	ViewMode           int
+	ErrorMsg           string
 }
 
 // RenderStatusBar renders the bottom status bar.
@@ -36,26 +42,42 @@ func RenderStatusBar(theme style.Theme, info StatusInfo, width int) string {
 	if info.CurrentDir != nil {
 		parts = append(parts, fmt.Sprintf("%d items", info.ItemCount))
 
-		var size int64
-		if info.UseApparent {
-			size = info.CurrentDir.GetSize()
-		} else {
-			size = info.CurrentDir.GetUsage()
-		}
-		sizeLabel := "disk"
-		if info.UseApparent {
-			sizeLabel = "apparent"
-		} else if info.UsageEstimated {
-			sizeLabel = "disk~"
+		size := info.SizeMode.Size(info.CurrentDir)
+		sizeLabel := info.SizeMode.Label()
+		if info.SizeMode != model.SizeModeApparent && info.UsageEstimated {
+			sizeLabel += "~"
 		}
 		parts = append(parts, fmt.Sprintf("%s %s", util.FormatSize(size), sizeLabel))
+
+		if fileCount := info.CurrentDir.FileCount; fileCount > 0 {
+			dirCount := info.CurrentDir.ItemCount - fileCount
+			avg := info.CurrentDir.GetSize() / fileCount
+			parts = append(parts, fmt.Sprintf("%d files, %d dirs, avg %s", fileCount, dirCount, util.FormatSize(avg)))
+		}
+	}
+
+	if info.FilesOnly {
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Warning).Render("files only"))
+	}
+
+	if info.MinSizeFloor > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Warning).Render(fmt.Sprintf("min size: %s", util.FormatSize(info.MinSizeFloor))))
+	}
+
+	if info.PersistMarks {
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Warning).Render("marks persist"))
+	}
+
+	if info.FilterText != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Warning).Render(fmt.Sprintf("filter: %q", info.FilterText)))
 	}
 
 	if info.MarkedCount > 0 {
 		marked := lipgloss.NewStyle().
 			Foreground(theme.Error).
 			Bold(true).
-			Render(fmt.Sprintf("* %d marked (%s)", info.MarkedCount, util.FormatSize(info.MarkedSize)))
+			Render(fmt.Sprintf("* %d marked (%s apparent / %s disk)", info.MarkedCount,
+				util.FormatSize(info.MarkedSizeApparent), util.FormatSize(info.MarkedSizeDisk)))
 		parts = append(parts, marked)
 	}
 
@@ -63,9 +85,11 @@ func RenderStatusBar(theme style.Theme, info StatusInfo, width int) string {
 
 	hints := []struct{ key, desc string }{
 		{"?", "help"},
-		{"d", "delete"},
-		{"q", "quit"},
 	}
+	if !info.ReadOnly {
+		hints = append(hints, struct{ key, desc string }{"d", "delete"})
+	}
+	hints = append(hints, struct{ key, desc string }{"q", "quit"})
 
 	var rightParts []string
 	for _, h := range hints {
@@ -88,7 +112,7 @@ func RenderStatusBar(theme style.Theme, info StatusInfo, width int) string {
 
 // RenderTabBar renders the view mode tab bar.
 func RenderTabBar(theme style.Theme, activeView int, sortField model.SortField, width int) string {
-	tabs := []string{"Tree View", "Treemap", "File Types"}
+	tabs := []string{"Tree View", "Treemap", "File Types", "Largest Files", "Hardlinks"}
 
 	var tabLine []string
 	for i, tab := range tabs {
@@ -61,8 +61,11 @@ var treemapPalette = []lipgloss.Color{
 	"#6BA68B", // seafoam
 }
 
-// RenderTreemap renders a squarified treemap visualization.
-func RenderTreemap(theme style.Theme, dir *model.DirNode, useApparent bool, showHidden bool, width, height int) string {
+// RenderTreemap renders a squarified treemap visualization. When byCount is
+// true, rectangles are weighted by recursive file count instead of bytes, so
+// directories holding many small files stand out regardless of their size —
+// useful for spotting inode-heavy areas that a byte-weighted view hides.
+func RenderTreemap(theme style.Theme, dir *model.DirNode, sizeMode model.SizeMode, showHidden bool, byCount bool, width, height int) string {
 	if dir == nil || height <= 0 || width <= 0 {
 		return ""
 	}
@@ -87,10 +90,14 @@ func RenderTreemap(theme style.Theme, dir *model.DirNode, useApparent bool, show
 	var totalSize int64
 	for _, c := range children {
 		var sz int64
-		if useApparent {
-			sz = c.GetSize()
+		if byCount {
+			if cd, ok := c.(*model.DirNode); ok {
+				sz = cd.FileCount
+			} else {
+				sz = 1
+			}
 		} else {
-			sz = c.GetUsage()
+			sz = sizeMode.Size(c)
 		}
 		if sz > 0 {
 			items = append(items, treemapItem{node: c, size: sz})
@@ -147,19 +154,22 @@ func RenderTreemap(theme style.Theme, dir *model.DirNode, useApparent bool, show
 		}
 
 		fillRect(grid, colorGrid, r, color)
-		drawBorder(grid, r)
+		drawBorder(grid, r, theme.Caps.ASCII)
 
 		if i < len(items) {
 			var label string
+			formatWeight := util.FormatSize
+			if byCount {
+				formatWeight = func(n int64) string { return util.FormatCount(n) + " files" }
+			}
 			if items[i].node != nil {
 				name := items[i].node.GetName()
 				if items[i].node.IsDir() {
 					name += "/"
 				}
-				sz := util.FormatSize(items[i].size)
-				label = fmt.Sprintf("%s %s", name, sz)
+				label = fmt.Sprintf("%s %s", name, formatWeight(items[i].size))
 			} else {
-				label = fmt.Sprintf("other (%s)", util.FormatSize(items[i].size))
+				label = fmt.Sprintf("other (%s)", formatWeight(items[i].size))
 			}
 			placeLabel(grid, r, label)
 		}
@@ -277,42 +287,49 @@ func fillRect(grid [][]rune, colorGrid [][]lipgloss.Color, r rect, color lipglos
 	}
 }
 
-func drawBorder(grid [][]rune, r rect) {
+func drawBorder(grid [][]rune, r rect, ascii bool) {
 	if r.w < 2 || r.h < 2 {
 		return
 	}
 	h := len(grid)
 	w := len(grid[0])
 
+	corner, horiz, vert := '┌', '─', '│'
+	topRight, bottomLeft, bottomRight := '┐', '└', '┘'
+	if ascii {
+		corner, horiz, vert = '+', '-', '|'
+		topRight, bottomLeft, bottomRight = '+', '+', '+'
+	}
+
 	for x := r.x; x < r.x+r.w && x < w; x++ {
 		if r.y < h {
 			if x == r.x {
-				grid[r.y][x] = '┌'
+				grid[r.y][x] = corner
 			} else if x == r.x+r.w-1 {
-				grid[r.y][x] = '┐'
+				grid[r.y][x] = topRight
 			} else {
-				grid[r.y][x] = '─'
+				grid[r.y][x] = horiz
 			}
 		}
 		by := r.y + r.h - 1
 		if by < h {
 			if x == r.x {
-				grid[by][x] = '└'
+				grid[by][x] = bottomLeft
 			} else if x == r.x+r.w-1 {
-				grid[by][x] = '┘'
+				grid[by][x] = bottomRight
 			} else {
-				grid[by][x] = '─'
+				grid[by][x] = horiz
 			}
 		}
 	}
 
 	for y := r.y + 1; y < r.y+r.h-1 && y < h; y++ {
 		if r.x < w {
-			grid[y][r.x] = '│'
+			grid[y][r.x] = vert
 		}
 		rx := r.x + r.w - 1
 		if rx < w {
-			grid[y][rx] = '│'
+			grid[y][rx] = vert
 		}
 	}
 }
@@ -324,14 +341,7 @@ func placeLabel(grid [][]rune, r rect, label string) {
 		return
 	}
 
-	runes := []rune(label)
-	if len(runes) > innerW {
-		if innerW > 3 {
-			runes = append(runes[:innerW-3], '.', '.', '.')
-		} else {
-			runes = runes[:innerW]
-		}
-	}
+	runes := []rune(util.TruncateString(label, innerW))
 
 	y := r.y + 1
 	x := r.x + 1
@@ -0,0 +1,80 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/ui/style"
+)
+
+func TestRenderTreemap_ByCountWeightsByFileCount(t *testing.T) {
+	theme := style.DefaultTheme()
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+
+	heavy := &model.DirNode{FileNode: model.FileNode{Name: "many-small-files", Parent: root}}
+	for i := 0; i < 50; i++ {
+		heavy.AddChild(&model.FileNode{Name: "f", Size: 1, Usage: 1, Parent: heavy})
+	}
+	heavy.UpdateSize()
+
+	light := &model.DirNode{FileNode: model.FileNode{Name: "one-big-file", Parent: root}}
+	light.AddChild(&model.FileNode{Name: "big", Size: 1_000_000, Usage: 1_000_000, Parent: light})
+	light.UpdateSize()
+
+	root.AddChild(heavy)
+	root.AddChild(light)
+	root.UpdateSize()
+
+	out := RenderTreemap(theme, root, model.SizeModeApparent, true, true, 60, 20)
+	if !strings.Contains(out, "50 files") {
+		t.Fatalf("expected by-count label mentioning 50 files, got:\n%s", out)
+	}
+}
+
+func TestRenderTreemap_LongCJKAndEmojiNamesDoNotPanic(t *testing.T) {
+	theme := style.DefaultTheme()
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.FileNode{Name: "这是一个非常长的文件名字.txt", Size: 100, Usage: 100, Parent: root})
+	root.AddChild(&model.FileNode{Name: "😀😀😀😀😀😀-emoji-file.log", Size: 200, Usage: 200, Parent: root})
+	root.UpdateSize()
+
+	out := RenderTreemap(theme, root, model.SizeModeApparent, true, false, 30, 10)
+	if out == "" {
+		t.Fatal("expected non-empty treemap output")
+	}
+}
+
+func TestPlaceLabel_TruncatesByDisplayWidthNotRuneCount(t *testing.T) {
+	grid := make([][]rune, 3)
+	for i := range grid {
+		grid[i] = make([]rune, 10)
+		for j := range grid[i] {
+			grid[i][j] = ' '
+		}
+	}
+
+	placeLabel(grid, rect{x: 0, y: 0, w: 10, h: 3}, "这是一个非常长的名字")
+
+	line := strings.TrimRight(string(grid[1]), " ")
+	if lipgloss.Width(line) > 8 {
+		t.Fatalf("expected label to fit within the 8-column inner width, got %q (width %d)", line, lipgloss.Width(line))
+	}
+}
+
+func TestRenderTreemap_ASCIICapabilityUsesPlainBorders(t *testing.T) {
+	theme := style.DefaultTheme()
+	theme.Caps = style.Capabilities{ASCII: true}
+
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	root.AddChild(&model.FileNode{Name: "a.txt", Size: 100, Usage: 100, Parent: root})
+	root.UpdateSize()
+
+	out := RenderTreemap(theme, root, model.SizeModeApparent, true, false, 40, 10)
+	for _, r := range out {
+		if r == '┌' || r == '┐' || r == '└' || r == '┘' || r == '─' || r == '│' {
+			t.Fatalf("expected no Unicode box-drawing characters in ASCII mode, got:\n%s", out)
+		}
+	}
+}
@@ -12,14 +12,21 @@ import (
 
 // TreeView renders the main tree list view.
 type TreeView struct {
-	Theme       style.Theme
-	Layout      style.Layout
-	Items       []model.TreeNode
-	Cursor      int
-	Offset      int
-	Marked      map[string]bool
-	UseApparent bool
-	ParentSize  int64
+	Theme      style.Theme
+	Layout     style.Layout
+	Items      []model.TreeNode
+	Cursor     int
+	Offset     int
+	Marked     map[string]bool
+	SizeMode   model.SizeMode
+	ParentSize int64
+	ShowIcons  bool
+	ShowCounts bool
+
+	// DangerThreshold, when > 0, switches the size bar to a solid danger
+	// color once an item's ratio of its parent exceeds it. 0
+	// disables it, leaving the ordinary gradient bar in place.
+	DangerThreshold float64
 }
 
 // Render renders the tree view.
@@ -59,22 +66,23 @@ func (tv *TreeView) Render() string {
 }
 
 func (tv *TreeView) renderRow(item model.TreeNode, selected, marked bool, barWidth, nameWidth, totalWidth int) string {
-	var size int64
-	if tv.UseApparent {
-		size = item.GetSize()
-	} else {
-		size = item.GetUsage()
-	}
+	size := tv.SizeMode.Size(item)
 
 	// Percentage
 	pct := util.Percent(size, tv.ParentSize)
 	pctStr := fmt.Sprintf("%5.1f%%", pct)
 
-	// Gradient bar
+	// Gradient bar, overridden with a solid danger color once the item
+	// dominates its parent by more than DangerThreshold.
 	ratio := pct / 100.0
-	bar := tv.Theme.BarGradient(barWidth, ratio)
+	var bar string
+	if tv.DangerThreshold > 0 && ratio > tv.DangerThreshold {
+		bar = tv.Theme.BarDanger(barWidth, ratio)
+	} else {
+		bar = tv.Theme.BarGradient(barWidth, ratio)
+	}
 
-	// Name (truncated to fit, reserving space for flag suffixes)
+	// Name (truncated to fit, reserving space for flag suffixes and icon)
 	name := item.GetName()
 	if item.IsDir() {
 		name += "/"
@@ -87,6 +95,17 @@ func (tv *TreeView) renderRow(item model.TreeNode, selected, marked bool, barWid
 	if flag&model.FlagSymlink != 0 {
 		flagWidth += 3 // " ->"
 	}
+	if flag&model.FlagStale != 0 {
+		flagWidth += 2 // " ~"
+	}
+	if flag&model.FlagSparse != 0 {
+		flagWidth += 2 // " %"
+	}
+	iconPrefix := ""
+	if tv.ShowIcons {
+		iconPrefix = util.Icon(item.GetName(), item.IsDir()) + " "
+		flagWidth += lipgloss.Width(iconPrefix) // icon glyphs are often double-width
+	}
 	effectiveNameWidth := nameWidth - flagWidth
 	if effectiveNameWidth < 1 {
 		effectiveNameWidth = 1
@@ -103,16 +122,23 @@ func (tv *TreeView) renderRow(item model.TreeNode, selected, marked bool, barWid
 		indicator = tv.Theme.MarkedIndicator.Render("* ")
 	}
 
-	// Size string
-	sizeStr := util.FormatSize(size)
+	// Size string, right-aligned to a fixed width so columns line up
+	sizeStr := util.FormatSizeAligned(size, 9)
 
 	// Style the name
 	var nameStyled string
 	if item.IsDir() {
-		nameStyled = tv.Theme.DirName.Render(name)
+		dirStyle := tv.Theme.DirName
+		if color, ok := util.DirColor(item.GetName()); ok {
+			dirStyle = dirStyle.Foreground(lipgloss.Color(color))
+		}
+		nameStyled = dirStyle.Render(name)
 	} else {
 		nameStyled = tv.Theme.FileName.Render(name)
 	}
+	if iconPrefix != "" {
+		nameStyled = iconPrefix + nameStyled
+	}
 
 	// Flag indicators (width already reserved above)
 	if flag&model.FlagError != 0 {
@@ -121,6 +147,12 @@ func (tv *TreeView) renderRow(item model.TreeNode, selected, marked bool, barWid
 	if flag&model.FlagSymlink != 0 {
 		nameStyled += lipgloss.NewStyle().Foreground(tv.Theme.TextMuted).Render(" ->")
 	}
+	if flag&model.FlagStale != 0 {
+		nameStyled += lipgloss.NewStyle().Foreground(tv.Theme.TextMuted).Render(" ~")
+	}
+	if flag&model.FlagSparse != 0 {
+		nameStyled += lipgloss.NewStyle().Foreground(tv.Theme.TextMuted).Render(" %")
+	}
 
 	// Styled components
 	pctStyled := tv.Theme.PercentText.Render(pctStr)
@@ -131,6 +163,18 @@ func (tv *TreeView) renderRow(item model.TreeNode, selected, marked bool, barWid
 		indicator, pctStyled, bar, nameStyled, sizeStyled,
 	)
 
+	// Item count column, shown only for directories (files have none).
+	if tv.ShowCounts {
+		countStr := ""
+		if item.IsDir() {
+			countStr = util.FormatCount(item.GetItemCount())
+		}
+		if len(countStr) < 6 {
+			countStr = strings.Repeat(" ", 6-len(countStr)) + countStr
+		}
+		row += " " + tv.Theme.SizeText.Render(countStr)
+	}
+
 	// Ensure exactly totalWidth visual chars (pad or don't exceed)
 	row = style.FullWidth(row, totalWidth)
 
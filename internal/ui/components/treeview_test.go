@@ -0,0 +1,114 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sadopc/godu/internal/model"
+	"github.com/sadopc/godu/internal/ui/style"
+)
+
+func TestTreeView_RowWidthIsPreservedWithIconsEnabled(t *testing.T) {
+	theme := style.DefaultTheme()
+	layout := style.NewLayout(80, 24)
+
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	child := &model.DirNode{FileNode: model.FileNode{Name: "src", Parent: root}}
+	root.AddChild(child)
+	root.UpdateSize()
+
+	tv := &TreeView{
+		Theme:      theme,
+		Layout:     layout,
+		Items:      []model.TreeNode{child},
+		ParentSize: root.GetSize(),
+		ShowIcons:  true,
+	}
+
+	out := tv.Render()
+	width := layout.ContentWidth()
+	for _, line := range splitLines(out) {
+		if got := lipgloss.Width(line); got != width {
+			t.Fatalf("expected row width %d, got %d for line %q", width, got, line)
+		}
+	}
+}
+
+func TestTreeView_RenderRow_ShowCountsRendersItemCount(t *testing.T) {
+	theme := style.DefaultTheme()
+	layout := style.NewLayout(80, 24)
+	layout.ShowCounts = true
+
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	child := &model.DirNode{FileNode: model.FileNode{Name: "src", Parent: root}, ItemCount: 1500}
+	root.AddChild(child)
+	root.UpdateSize()
+
+	tv := &TreeView{
+		Theme:      theme,
+		Layout:     layout,
+		ParentSize: root.GetSize(),
+		ShowCounts: true,
+	}
+
+	got := tv.renderRow(child, false, false, layout.BarWidth(), layout.NameWidth(), layout.ContentWidth())
+	if !strings.Contains(got, "1.5K") {
+		t.Fatalf("expected rendered row to contain item count %q, got %q", "1.5K", got)
+	}
+}
+
+func TestTreeView_DangerThresholdSwitchesToSolidBar(t *testing.T) {
+	theme := style.DefaultTheme()
+	layout := style.NewLayout(80, 24)
+
+	root := &model.DirNode{FileNode: model.FileNode{Name: "root"}}
+	big := &model.FileNode{Name: "big.bin", Parent: root}
+	big.Size = 90
+	small := &model.FileNode{Name: "small.bin", Parent: root}
+	small.Size = 10
+	root.AddChild(big)
+	root.AddChild(small)
+	root.UpdateSize()
+
+	barWidth := layout.BarWidth()
+
+	withoutThreshold := &TreeView{Theme: theme, Layout: layout, ParentSize: root.GetSize(), SizeMode: model.SizeModeApparent}
+	gradientBar := theme.BarGradient(barWidth, 0.9)
+	if got := withoutThreshold.renderRow(big, false, false, barWidth, layout.NameWidth(), layout.ContentWidth()); !containsBar(got, gradientBar) {
+		t.Fatalf("expected gradient bar when DangerThreshold is disabled, got %q", got)
+	}
+
+	withThreshold := &TreeView{Theme: theme, Layout: layout, ParentSize: root.GetSize(), SizeMode: model.SizeModeApparent, DangerThreshold: 0.5}
+	dangerBar := theme.BarDanger(barWidth, 0.9)
+	if got := withThreshold.renderRow(big, false, false, barWidth, layout.NameWidth(), layout.ContentWidth()); !containsBar(got, dangerBar) {
+		t.Fatalf("expected danger bar for item exceeding threshold, got %q", got)
+	}
+	if got := withThreshold.renderRow(small, false, false, barWidth, layout.NameWidth(), layout.ContentWidth()); !containsBar(got, theme.BarGradient(barWidth, 0.1)) {
+		t.Fatalf("expected gradient bar for item below threshold, got %q", got)
+	}
+}
+
+func containsBar(row, bar string) bool {
+	return bar != "" && len(row) >= len(bar) && (func() bool {
+		for i := 0; i+len(bar) <= len(row); i++ {
+			if row[i:i+len(bar)] == bar {
+				return true
+			}
+		}
+		return false
+	})()
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i, r := range s {
+		if r == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	lines = append(lines, s[start:])
+	return lines
+}
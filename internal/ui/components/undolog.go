@@ -0,0 +1,64 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sadopc/godu/internal/ui/style"
+	"github.com/sadopc/godu/internal/util"
+)
+
+// UndoEntry is one deleted item recorded in the session's undo/audit log.
+type UndoEntry struct {
+	Name        string
+	Path        string
+	Size        int64
+	TrashedPath string // non-empty when the item was moved to trash rather than deleted permanently
+}
+
+// RenderUndoLog renders the undo/audit log overlay, most recent item first.
+func RenderUndoLog(theme style.Theme, entries []UndoEntry, width, height int) string {
+	boxWidth := min(70, max(width-4, 1))
+
+	title := theme.ModalTitle.Render("  Undo Log")
+
+	var lines []string
+	lines = append(lines, title)
+
+	if len(entries) == 0 {
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.TextSecondary).Render("  Nothing deleted this session."))
+	} else {
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.TextSecondary).Render(
+			fmt.Sprintf("  %d item(s) deleted this session, most recent first:", len(entries))))
+		lines = append(lines, "")
+
+		for i := len(entries) - 1; i >= 0; i-- {
+			e := entries[i]
+			status := "permanently deleted"
+			if e.TrashedPath != "" {
+				status = "trashed"
+			}
+			name := util.TruncateString(e.Name, boxWidth-24)
+			size := util.FormatSize(e.Size)
+			line := lipgloss.NewStyle().Foreground(theme.Primary).Render("  "+name) +
+				lipgloss.NewStyle().Foreground(theme.TextMuted).Render(fmt.Sprintf("  %s, %s", size, status))
+			lines = append(lines, line)
+		}
+	}
+
+	lines = append(lines, "")
+	close := lipgloss.NewStyle().
+		Foreground(theme.TextMuted).
+		Render("  Press U or Esc to close, u to restore the last trashed item")
+	lines = append(lines, close)
+
+	content := strings.Join(lines, "\n")
+
+	box := theme.ModalStyle.
+		Width(boxWidth).
+		Render(content)
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
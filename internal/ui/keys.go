@@ -1,27 +1,49 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap holds all key bindings for the application.
 type KeyMap struct {
-	Up        key.Binding
-	Down      key.Binding
-	Left      key.Binding
-	Right     key.Binding
-	Enter     key.Binding
-	Back      key.Binding
-	Mark      key.Binding
-	Delete    key.Binding
-	Export    key.Binding
-	Rescan   key.Binding
-	Quit      key.Binding
-	ForceQuit key.Binding
-	Help      key.Binding
+	Up                key.Binding
+	Down              key.Binding
+	Left              key.Binding
+	Right             key.Binding
+	PageUp            key.Binding
+	PageDown          key.Binding
+	Home              key.Binding
+	End               key.Binding
+	Enter             key.Binding
+	Back              key.Binding
+	JumpLargest       key.Binding
+	Mark              key.Binding
+	MarkTree          key.Binding
+	Delete            key.Binding
+	Export            key.Binding
+	Snapshot          key.Binding
+	Rescan            key.Binding
+	RefreshDir        key.Binding
+	Quit              key.Binding
+	ForceQuit         key.Binding
+	Help              key.Binding
+	JumpToPath        key.Binding
+	Search            key.Binding
+	CopyPath          key.Binding
+	OpenInFileManager key.Binding
+	Rename            key.Binding
+	Undo              key.Binding
+	UndoLog           key.Binding
 
 	// View switching
-	ViewTree     key.Binding
-	ViewTreemap  key.Binding
-	ViewFileType key.Binding
+	ViewTree      key.Binding
+	ViewTreemap   key.Binding
+	ViewFileType  key.Binding
+	ViewLargest   key.Binding
+	ViewHardlinks key.Binding
 
 	// Sort
 	SortSize  key.Binding
@@ -30,8 +52,17 @@ type KeyMap struct {
 	SortMtime key.Binding
 
 	// Toggles
-	ToggleApparent key.Binding
-	ToggleHidden   key.Binding
+	CycleSizeMode       key.Binding
+	ToggleIcons         key.Binding
+	ToggleCounts        key.Binding
+	ToggleHidden        key.Binding
+	PeekHidden          key.Binding
+	ToggleTreemapWeight key.Binding
+	FilesOnly           key.Binding
+	StaleOnly           key.Binding
+	SizeFloor           key.Binding
+	PersistMarks        key.Binding
+	TogglePercentOfRoot key.Binding
 
 	// Confirm dialog
 	ConfirmYes key.Binding
@@ -57,6 +88,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("right", "l"),
 			key.WithHelp("→/l", "enter"),
 		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown"),
+			key.WithHelp("pgdown", "page down"),
+		),
+		Home: key.NewBinding(
+			key.WithKeys("home"),
+			key.WithHelp("home", "jump to top"),
+		),
+		End: key.NewBinding(
+			key.WithKeys("end"),
+			key.WithHelp("end", "jump to bottom"),
+		),
 		Enter: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "enter dir"),
@@ -65,10 +112,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("backspace"),
 			key.WithHelp("backspace", "go back"),
 		),
+		JumpLargest: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "dive into largest subdirectory"),
+		),
 		Mark: key.NewBinding(
 			key.WithKeys(" "),
 			key.WithHelp("space", "mark"),
 		),
+		MarkTree: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "mark directory's files recursively"),
+		),
 		Delete: key.NewBinding(
 			key.WithKeys("d"),
 			key.WithHelp("d", "delete"),
@@ -77,10 +132,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("E"),
 			key.WithHelp("E", "export"),
 		),
+		Snapshot: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "text snapshot"),
+		),
 		Rescan: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "rescan"),
 		),
+		RefreshDir: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "refresh current directory"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q"),
 			key.WithHelp("q", "quit"),
@@ -93,6 +156,34 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
 		),
+		JumpToPath: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "jump to path"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search/filter"),
+		),
+		CopyPath: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy path to clipboard"),
+		),
+		OpenInFileManager: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in file manager"),
+		),
+		Rename: key.NewBinding(
+			key.WithKeys("f2"),
+			key.WithHelp("f2", "rename"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "restore last trashed item"),
+		),
+		UndoLog: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "show undo log"),
+		),
 		ViewTree: key.NewBinding(
 			key.WithKeys("1"),
 			key.WithHelp("1", "tree view"),
@@ -105,6 +196,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("3"),
 			key.WithHelp("3", "file types"),
 		),
+		ViewLargest: key.NewBinding(
+			key.WithKeys("4"),
+			key.WithHelp("4", "largest files"),
+		),
+		ViewHardlinks: key.NewBinding(
+			key.WithKeys("5"),
+			key.WithHelp("5", "hardlink sets"),
+		),
 		SortSize: key.NewBinding(
 			key.WithKeys("s"),
 			key.WithHelp("s", "sort: size"),
@@ -121,14 +220,50 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("M"),
 			key.WithHelp("M", "sort: mtime"),
 		),
-		ToggleApparent: key.NewBinding(
+		CycleSizeMode: key.NewBinding(
 			key.WithKeys("a"),
-			key.WithHelp("a", "apparent/disk"),
+			key.WithHelp("a", "cycle size: disk/apparent/max"),
+		),
+		ToggleIcons: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "toggle icons"),
+		),
+		ToggleCounts: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "toggle item counts"),
 		),
 		ToggleHidden: key.NewBinding(
 			key.WithKeys("."),
 			key.WithHelp(".", "hidden files"),
 		),
+		PeekHidden: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "peek hidden (here only)"),
+		),
+		ToggleTreemapWeight: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "treemap weight"),
+		),
+		FilesOnly: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "files only"),
+		),
+		StaleOnly: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "stale files only (-older-than)"),
+		),
+		SizeFloor: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "cycle min size filter"),
+		),
+		PersistMarks: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "persist marks across navigation"),
+		),
+		TogglePercentOfRoot: key.NewBinding(
+			key.WithKeys("%"),
+			key.WithHelp("%", "percent of root vs. parent"),
+		),
 		ConfirmYes: key.NewBinding(
 			key.WithKeys("y", "Y"),
 			key.WithHelp("y", "yes"),
@@ -139,3 +274,80 @@ func DefaultKeyMap() KeyMap {
 		),
 	}
 }
+
+// keyRemapActions maps the action names recognized by a key remap config
+// file (lowercase KeyMap field names) to the binding they control.
+var keyRemapActions = map[string]func(*KeyMap) *key.Binding{
+	"up":                func(k *KeyMap) *key.Binding { return &k.Up },
+	"down":              func(k *KeyMap) *key.Binding { return &k.Down },
+	"left":              func(k *KeyMap) *key.Binding { return &k.Left },
+	"right":             func(k *KeyMap) *key.Binding { return &k.Right },
+	"pageup":            func(k *KeyMap) *key.Binding { return &k.PageUp },
+	"pagedown":          func(k *KeyMap) *key.Binding { return &k.PageDown },
+	"home":              func(k *KeyMap) *key.Binding { return &k.Home },
+	"end":               func(k *KeyMap) *key.Binding { return &k.End },
+	"enter":             func(k *KeyMap) *key.Binding { return &k.Enter },
+	"back":              func(k *KeyMap) *key.Binding { return &k.Back },
+	"jumplargest":       func(k *KeyMap) *key.Binding { return &k.JumpLargest },
+	"mark":              func(k *KeyMap) *key.Binding { return &k.Mark },
+	"marktree":          func(k *KeyMap) *key.Binding { return &k.MarkTree },
+	"delete":            func(k *KeyMap) *key.Binding { return &k.Delete },
+	"export":            func(k *KeyMap) *key.Binding { return &k.Export },
+	"snapshot":          func(k *KeyMap) *key.Binding { return &k.Snapshot },
+	"rescan":            func(k *KeyMap) *key.Binding { return &k.Rescan },
+	"refreshdir":        func(k *KeyMap) *key.Binding { return &k.RefreshDir },
+	"quit":              func(k *KeyMap) *key.Binding { return &k.Quit },
+	"forcequit":         func(k *KeyMap) *key.Binding { return &k.ForceQuit },
+	"help":              func(k *KeyMap) *key.Binding { return &k.Help },
+	"jumptopath":        func(k *KeyMap) *key.Binding { return &k.JumpToPath },
+	"search":            func(k *KeyMap) *key.Binding { return &k.Search },
+	"copypath":          func(k *KeyMap) *key.Binding { return &k.CopyPath },
+	"openinfilemanager": func(k *KeyMap) *key.Binding { return &k.OpenInFileManager },
+	"rename":            func(k *KeyMap) *key.Binding { return &k.Rename },
+	"undo":              func(k *KeyMap) *key.Binding { return &k.Undo },
+	"undolog":           func(k *KeyMap) *key.Binding { return &k.UndoLog },
+	"viewtree":          func(k *KeyMap) *key.Binding { return &k.ViewTree },
+	"viewtreemap":       func(k *KeyMap) *key.Binding { return &k.ViewTreemap },
+	"viewfiletype":      func(k *KeyMap) *key.Binding { return &k.ViewFileType },
+	"viewlargest":       func(k *KeyMap) *key.Binding { return &k.ViewLargest },
+	"viewhardlinks":     func(k *KeyMap) *key.Binding { return &k.ViewHardlinks },
+	"sortsize":          func(k *KeyMap) *key.Binding { return &k.SortSize },
+	"sortname":          func(k *KeyMap) *key.Binding { return &k.SortName },
+	"sortcount":         func(k *KeyMap) *key.Binding { return &k.SortCount },
+	"sortmtime":         func(k *KeyMap) *key.Binding { return &k.SortMtime },
+	"cyclesizemode":     func(k *KeyMap) *key.Binding { return &k.CycleSizeMode },
+	"toggleicons":       func(k *KeyMap) *key.Binding { return &k.ToggleIcons },
+	"togglecounts":      func(k *KeyMap) *key.Binding { return &k.ToggleCounts },
+	"togglehidden":      func(k *KeyMap) *key.Binding { return &k.ToggleHidden },
+	"peekhidden":        func(k *KeyMap) *key.Binding { return &k.PeekHidden },
+	"toggletreemapweight": func(k *KeyMap) *key.Binding {
+		return &k.ToggleTreemapWeight
+	},
+	"filesonly":           func(k *KeyMap) *key.Binding { return &k.FilesOnly },
+	"staleonly":           func(k *KeyMap) *key.Binding { return &k.StaleOnly },
+	"sizefloor":           func(k *KeyMap) *key.Binding { return &k.SizeFloor },
+	"persistmarks":        func(k *KeyMap) *key.Binding { return &k.PersistMarks },
+	"togglepercentofroot": func(k *KeyMap) *key.Binding { return &k.TogglePercentOfRoot },
+}
+
+// ApplyRemap overrides the bindings in k named by overrides, a map from
+// action name (as read from a key remap config file) to replacement key.
+// Action names not in keyRemapActions are reported back as warnings and
+// otherwise ignored; an empty replacement key for a recognized action is
+// returned as an error, since applying it would leave that action
+// unreachable.
+func (k *KeyMap) ApplyRemap(overrides map[string]string) (warnings []string, err error) {
+	for action, newKey := range overrides {
+		field, ok := keyRemapActions[strings.ToLower(action)]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown key remap action %q, ignoring", action))
+			continue
+		}
+		if newKey == "" {
+			return warnings, fmt.Errorf("key remap for action %q has an empty key", action)
+		}
+		binding := field(k)
+		*binding = key.NewBinding(key.WithKeys(newKey), key.WithHelp(newKey, binding.Help().Desc))
+	}
+	return warnings, nil
+}
@@ -0,0 +1,35 @@
+package ui
+
+import "testing"
+
+func TestApplyRemap_OverridesKnownAction(t *testing.T) {
+	keys := DefaultKeyMap()
+	warnings, err := keys.ApplyRemap(map[string]string{"delete": "x"})
+	if err != nil {
+		t.Fatalf("ApplyRemap: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+	if got := keys.Delete.Keys(); len(got) != 1 || got[0] != "x" {
+		t.Fatalf("expected Delete bound to [x], got %v", got)
+	}
+}
+
+func TestApplyRemap_UnknownActionWarnsAndIsIgnored(t *testing.T) {
+	keys := DefaultKeyMap()
+	warnings, err := keys.ApplyRemap(map[string]string{"teleport": "t"})
+	if err != nil {
+		t.Fatalf("ApplyRemap: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+}
+
+func TestApplyRemap_EmptyKeyIsFatal(t *testing.T) {
+	keys := DefaultKeyMap()
+	if _, err := keys.ApplyRemap(map[string]string{"delete": ""}); err == nil {
+		t.Fatal("expected error for empty key")
+	}
+}
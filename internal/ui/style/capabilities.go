@@ -0,0 +1,41 @@
+package style
+
+import (
+	"os"
+	"strings"
+)
+
+// Capabilities describes what the terminal is expected to render correctly.
+// It is detected once at startup and consulted wherever the UI would
+// otherwise emit Unicode box-drawing or bar characters, so minimal
+// environments (dumb terminals, non-UTF-8 locales) get readable ASCII
+// instead of garbled output.
+type Capabilities struct {
+	// ASCII, when true, means Unicode box-drawing/bar characters should not
+	// be used; plain ASCII equivalents are substituted instead.
+	ASCII bool
+}
+
+// DetectCapabilities inspects TERM and the locale environment variables to
+// guess whether the terminal can render Unicode box-drawing characters. It
+// favors Unicode unless there is clear evidence against it, since that is
+// the common case and a wrong ASCII fallback only costs some visual polish.
+func DetectCapabilities() Capabilities {
+	term := os.Getenv("TERM")
+	if term == "" || term == "dumb" {
+		return Capabilities{ASCII: true}
+	}
+
+	locale := os.Getenv("LC_ALL")
+	if locale == "" {
+		locale = os.Getenv("LC_CTYPE")
+	}
+	if locale == "" {
+		locale = os.Getenv("LANG")
+	}
+	if locale != "" && !strings.Contains(strings.ToUpper(locale), "UTF-8") && !strings.Contains(strings.ToUpper(locale), "UTF8") {
+		return Capabilities{ASCII: true}
+	}
+
+	return Capabilities{ASCII: false}
+}
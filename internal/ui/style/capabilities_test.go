@@ -0,0 +1,36 @@
+package style
+
+import "testing"
+
+func TestDetectCapabilities_DumbTermIsASCII(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	t.Setenv("LANG", "en_US.UTF-8")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+
+	if caps := DetectCapabilities(); !caps.ASCII {
+		t.Fatal("expected TERM=dumb to force ASCII")
+	}
+}
+
+func TestDetectCapabilities_NonUTF8LocaleIsASCII(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "C")
+
+	if caps := DetectCapabilities(); !caps.ASCII {
+		t.Fatal("expected non-UTF-8 LANG to force ASCII")
+	}
+}
+
+func TestDetectCapabilities_UTF8LocaleIsUnicode(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if caps := DetectCapabilities(); caps.ASCII {
+		t.Fatal("expected UTF-8 LANG with a real TERM to allow Unicode")
+	}
+}
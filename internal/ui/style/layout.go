@@ -11,6 +11,9 @@ import (
 type Layout struct {
 	Width  int
 	Height int
+	// ShowCounts reserves room in the tree view row for a per-directory
+	// item count column, toggled independently of Width/Height.
+	ShowCounts bool
 }
 
 // NewLayout creates a layout for the given terminal dimensions.
@@ -56,13 +59,22 @@ func (l Layout) NameWidth() int {
 	return w
 }
 
+// CountWidth returns the width reserved for the item count column,
+// 0 when ShowCounts is false.
+func (l Layout) CountWidth() int {
+	if !l.ShowCounts {
+		return 0
+	}
+	return 7 // " " + "9.9K"(up to 4) right-padded to 6, plus leading space
+}
+
 // rowOverhead returns the fixed-width portion of each tree view row
 // (everything except the bar and name).
 //
-// Layout: "  " mark + "99.9%" pct(6) + " [" + bar + "] " + name + " " + "  9.9 GiB" size(10)
-// Fixed:    2         + 6             + 2    +     + 2    +      + 1  + 10 = 23
+// Layout: "  " mark + "99.9%" pct(6) + " [" + bar + "] " + name + " " + "  9.9 GiB" size(10) + count
+// Fixed:    2         + 6             + 2    +     + 2    +      + 1  + 10 + CountWidth() = 23 + CountWidth()
 func (l Layout) rowOverhead() int {
-	return 23 // mark(2) + pct(6) + " ["(2) + "] "(2) + " "(1) + size(10)
+	return 23 + l.CountWidth() // mark(2) + pct(6) + " ["(2) + "] "(2) + " "(1) + size(10) + count
 }
 
 // Center centers content in the available width.
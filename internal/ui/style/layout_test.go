@@ -11,8 +11,8 @@ func TestContentHeight(t *testing.T) {
 	}{
 		{80, 24, 20},
 		{10, 5, 1},
-		{10, 4, 1},  // 4-4=0, clamped to 1
-		{10, 0, 1},  // negative, clamped to 1
+		{10, 4, 1}, // 4-4=0, clamped to 1
+		{10, 0, 1}, // negative, clamped to 1
 		{80, 50, 46},
 	}
 
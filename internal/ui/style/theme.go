@@ -10,13 +10,13 @@ import (
 // Theme holds all the styled components for the UI.
 type Theme struct {
 	// Base colors
-	Primary    lipgloss.Color
-	Secondary  lipgloss.Color
-	Accent     lipgloss.Color
-	Muted      lipgloss.Color
-	Error      lipgloss.Color
-	Warning    lipgloss.Color
-	Success    lipgloss.Color
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Accent    lipgloss.Color
+	Muted     lipgloss.Color
+	Error     lipgloss.Color
+	Warning   lipgloss.Color
+	Success   lipgloss.Color
 
 	// Backgrounds
 	BgDark     lipgloss.Color
@@ -53,9 +53,17 @@ type Theme struct {
 	ModalStyle       lipgloss.Style
 	ModalTitle       lipgloss.Style
 	BorderStyle      lipgloss.Style
+
+	// Caps governs whether bars and borders fall back to plain ASCII
+	// instead of Unicode box-drawing characters. Zero value (Capabilities{})
+	// keeps the existing Unicode rendering, so callers that never touch it
+	// see no behavior change.
+	Caps Capabilities
 }
 
-// DefaultTheme returns the default dark theme.
+// DefaultTheme returns the default dark theme, with Caps auto-detected from
+// the environment via DetectCapabilities. Callers that want to force ASCII
+// or Unicode regardless of the environment can overwrite t.Caps afterward.
 func DefaultTheme() Theme {
 	t := Theme{
 		Primary:   lipgloss.Color("#7B2FBE"),
@@ -77,6 +85,8 @@ func DefaultTheme() Theme {
 
 		GradientStart: lipgloss.Color("#7B2FBE"),
 		GradientEnd:   lipgloss.Color("#00D4AA"),
+
+		Caps: DetectCapabilities(),
 	}
 
 	// Header: no padding — we handle spacing manually inside
@@ -162,6 +172,116 @@ func DefaultTheme() Theme {
 	return t
 }
 
+// LightTheme returns a light color scheme for terminals with a light
+// background, where DefaultTheme's dark backgrounds and pale text read as
+// nearly invisible. Caps is auto-detected the same way as DefaultTheme.
+func LightTheme() Theme {
+	t := Theme{
+		Primary:   lipgloss.Color("#6236AB"),
+		Secondary: lipgloss.Color("#00897B"),
+		Accent:    lipgloss.Color("#1A73E8"),
+		Muted:     lipgloss.Color("#9AA0A6"),
+		Error:     lipgloss.Color("#C53030"),
+		Warning:   lipgloss.Color("#B7791F"),
+		Success:   lipgloss.Color("#2F855A"),
+
+		BgDark:     lipgloss.Color("#FFFFFF"),
+		BgMedium:   lipgloss.Color("#F1F3F4"),
+		BgLight:    lipgloss.Color("#E8EAED"),
+		BgSelected: lipgloss.Color("#D2E3FC"),
+
+		TextPrimary:   lipgloss.Color("#202124"),
+		TextSecondary: lipgloss.Color("#3C4043"),
+		TextMuted:     lipgloss.Color("#5F6368"),
+
+		GradientStart: lipgloss.Color("#6236AB"),
+		GradientEnd:   lipgloss.Color("#00897B"),
+
+		Caps: DetectCapabilities(),
+	}
+
+	t.HeaderStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.TextPrimary).
+		Background(t.BgMedium)
+
+	t.BreadcrumbStyle = lipgloss.NewStyle().
+		Foreground(t.TextMuted)
+
+	t.TabActiveStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(t.Primary).
+		Padding(0, 1)
+
+	t.TabInactiveStyle = lipgloss.NewStyle().
+		Foreground(t.TextMuted).
+		Padding(0, 1)
+
+	t.StatusBarStyle = lipgloss.NewStyle().
+		Foreground(t.TextSecondary).
+		Background(t.BgMedium)
+
+	t.SelectedRow = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#000000")).
+		Background(t.BgSelected)
+
+	t.NormalRow = lipgloss.NewStyle().
+		Foreground(t.TextSecondary)
+
+	t.MarkedIndicator = lipgloss.NewStyle().
+		Foreground(t.Error).
+		Bold(true)
+
+	t.CursorIndicator = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	t.DirName = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	t.FileName = lipgloss.NewStyle().
+		Foreground(t.TextSecondary)
+
+	t.SizeText = lipgloss.NewStyle().
+		Foreground(t.TextMuted).
+		Align(lipgloss.Right)
+
+	t.PercentText = lipgloss.NewStyle().
+		Foreground(t.TextMuted).
+		Width(6).
+		Align(lipgloss.Right)
+
+	t.ErrorText = lipgloss.NewStyle().
+		Foreground(t.Error)
+
+	t.HelpKey = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	t.HelpDesc = lipgloss.NewStyle().
+		Foreground(t.TextMuted)
+
+	t.ModalStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Background(t.BgMedium)
+
+	t.ModalTitle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.TextPrimary).
+		Padding(0, 0, 1, 0)
+
+	t.BorderStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Muted)
+
+	return t
+}
+
 // GradientColor returns a color interpolated between gradient start and end.
 func (t Theme) GradientColor(ratio float64) lipgloss.Color {
 	if ratio <= 0 {
@@ -188,6 +308,11 @@ func (t Theme) BarGradient(width int, ratio float64) string {
 		filled = width
 	}
 
+	fillChar, emptyChar := "━", "─"
+	if t.Caps.ASCII {
+		fillChar, emptyChar = "=", "-"
+	}
+
 	var buf strings.Builder
 	buf.Grow(width * 20) // rough estimate with ANSI codes
 
@@ -199,12 +324,43 @@ func (t Theme) BarGradient(width int, ratio float64) string {
 		charRatio := float64(i) / float64(max(width-1, 1))
 		blended := c1.BlendLab(c2, charRatio)
 		color := lipgloss.Color(blended.Hex())
-		buf.WriteString(lipgloss.NewStyle().Foreground(color).Render("━"))
+		buf.WriteString(lipgloss.NewStyle().Foreground(color).Render(fillChar))
+	}
+
+	if filled < width {
+		dimStyle := lipgloss.NewStyle().Foreground(t.TextMuted)
+		buf.WriteString(dimStyle.Render(strings.Repeat(emptyChar, width-filled)))
+	}
+
+	return buf.String()
+}
+
+// BarDanger renders a progress bar filled with a single solid color
+// (t.Error) instead of the gradient, used to draw the eye to an item that
+// dominates its parent's size.
+func (t Theme) BarDanger(width int, ratio float64) string {
+	if width <= 0 {
+		return ""
 	}
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+
+	fillChar, emptyChar := "━", "─"
+	if t.Caps.ASCII {
+		fillChar, emptyChar = "=", "-"
+	}
+
+	var buf strings.Builder
+	buf.Grow(width * 20)
+
+	fillStyle := lipgloss.NewStyle().Foreground(t.Error)
+	buf.WriteString(fillStyle.Render(strings.Repeat(fillChar, filled)))
 
 	if filled < width {
 		dimStyle := lipgloss.NewStyle().Foreground(t.TextMuted)
-		buf.WriteString(dimStyle.Render(strings.Repeat("─", width-filled)))
+		buf.WriteString(dimStyle.Render(strings.Repeat(emptyChar, width-filled)))
 	}
 
 	return buf.String()
@@ -0,0 +1,36 @@
+package style
+
+import "testing"
+
+func TestBarGradient_ASCIIFallbackAvoidsBoxDrawingChars(t *testing.T) {
+	theme := DefaultTheme()
+	theme.Caps = Capabilities{ASCII: true}
+
+	bar := theme.BarGradient(10, 0.5)
+	for _, r := range bar {
+		if r == '━' || r == '─' {
+			t.Fatalf("expected no Unicode bar characters in ASCII mode, got %q", bar)
+		}
+	}
+}
+
+func TestLightTheme_HasDistinctBackgroundFromDefault(t *testing.T) {
+	dark := DefaultTheme()
+	light := LightTheme()
+
+	if dark.BgDark == light.BgDark {
+		t.Fatalf("expected LightTheme to use a different BgDark than DefaultTheme, both got %q", dark.BgDark)
+	}
+}
+
+func TestBarDanger_ASCIIFallbackAvoidsBoxDrawingChars(t *testing.T) {
+	theme := DefaultTheme()
+	theme.Caps = Capabilities{ASCII: true}
+
+	bar := theme.BarDanger(10, 0.5)
+	for _, r := range bar {
+		if r == '━' || r == '─' {
+			t.Fatalf("expected no Unicode bar characters in ASCII mode, got %q", bar)
+		}
+	}
+}
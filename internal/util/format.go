@@ -1,9 +1,55 @@
 package util
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
-// FormatSize returns a human-readable size string.
+	"github.com/charmbracelet/lipgloss"
+)
+
+// siUnits selects whether FormatSize prints SI (decimal) units instead of
+// its default binary (IEC) units. Set once at startup from the -si flag so
+// the whole UI - TUI and headless output alike - agrees on one unit system.
+var siUnits atomic.Bool
+
+// SetSIUnits sets whether FormatSize formats using SI (decimal,
+// powers-of-1000) units instead of its default binary (powers-of-1024)
+// ones.
+func SetSIUnits(si bool) {
+	siUnits.Store(si)
+}
+
+// sizePrecision is the number of decimal places FormatSize, FormatSizeSI,
+// and FormatCount render. Set once at startup from the -precision flag so
+// the whole UI agrees on one precision; defaults to 1 to match the prior
+// hardcoded "%.1f" formatting.
+var sizePrecision atomic.Int32
+
+func init() {
+	sizePrecision.Store(1)
+}
+
+// SetSizePrecision sets the number of decimal places FormatSize,
+// FormatSizeSI, and FormatCount render, clamped to [0, 2].
+func SetSizePrecision(n int) {
+	switch {
+	case n < 0:
+		n = 0
+	case n > 2:
+		n = 2
+	}
+	sizePrecision.Store(int32(n))
+}
+
+// FormatSize returns a human-readable size string, in binary (IEC) units by
+// default or SI (decimal) units if SetSIUnits(true) was called.
 func FormatSize(bytes int64) string {
+	if siUnits.Load() {
+		return FormatSizeSI(bytes)
+	}
 	if bytes < 0 {
 		return "0 B"
 	}
@@ -17,35 +63,83 @@ func FormatSize(bytes int64) string {
 		pB
 	)
 
+	p := int(sizePrecision.Load())
 	b := float64(bytes)
 	switch {
 	case b >= pB:
-		return fmt.Sprintf("%.1f PiB", b/pB)
+		return fmt.Sprintf("%.*f PiB", p, b/pB)
 	case b >= tB:
-		return fmt.Sprintf("%.1f TiB", b/tB)
+		return fmt.Sprintf("%.*f TiB", p, b/tB)
 	case b >= gB:
-		return fmt.Sprintf("%.1f GiB", b/gB)
+		return fmt.Sprintf("%.*f GiB", p, b/gB)
 	case b >= mB:
-		return fmt.Sprintf("%.1f MiB", b/mB)
+		return fmt.Sprintf("%.*f MiB", p, b/mB)
 	case b >= kB:
-		return fmt.Sprintf("%.1f KiB", b/kB)
+		return fmt.Sprintf("%.*f KiB", p, b/kB)
 	default:
 		return fmt.Sprintf("%d B", bytes)
 	}
 }
 
-// FormatCount returns a human-readable count string.
+// FormatSizeSI returns a human-readable size string using SI (decimal,
+// powers-of-1000) units instead of FormatSize's default binary
+// (powers-of-1024) ones, for callers that want to match tools like
+// `du --si` regardless of the global SetSIUnits setting.
+func FormatSizeSI(bytes int64) string {
+	if bytes < 0 {
+		return "0 B"
+	}
+
+	const (
+		kB = 1000.0
+		mB = kB * 1000
+		gB = mB * 1000
+		tB = gB * 1000
+		pB = tB * 1000
+	)
+
+	p := int(sizePrecision.Load())
+	b := float64(bytes)
+	switch {
+	case b >= pB:
+		return fmt.Sprintf("%.*f PB", p, b/pB)
+	case b >= tB:
+		return fmt.Sprintf("%.*f TB", p, b/tB)
+	case b >= gB:
+		return fmt.Sprintf("%.*f GB", p, b/gB)
+	case b >= mB:
+		return fmt.Sprintf("%.*f MB", p, b/mB)
+	case b >= kB:
+		return fmt.Sprintf("%.*f kB", p, b/kB)
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
+
+// FormatSizeAligned returns a fixed-width, right-aligned human-readable size
+// string, padded to width chars so size columns line up across rows.
+func FormatSizeAligned(bytes int64, width int) string {
+	s := FormatSize(bytes)
+	if len(s) >= width {
+		return s
+	}
+	return strings.Repeat(" ", width-len(s)) + s
+}
+
+// FormatCount returns a human-readable count string, rendered at the same
+// decimal precision as FormatSize (see SetSizePrecision).
 func FormatCount(n int64) string {
 	if n < 1000 {
 		return fmt.Sprintf("%d", n)
 	}
+	p := int(sizePrecision.Load())
 	if n < 1_000_000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1000)
+		return fmt.Sprintf("%.*fK", p, float64(n)/1000)
 	}
 	if n < 1_000_000_000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+		return fmt.Sprintf("%.*fM", p, float64(n)/1_000_000)
 	}
-	return fmt.Sprintf("%.1fB", float64(n)/1_000_000_000)
+	return fmt.Sprintf("%.*fB", p, float64(n)/1_000_000_000)
 }
 
 // Percent returns the percentage of part relative to total.
@@ -56,17 +150,138 @@ func Percent(part, total int64) float64 {
 	return float64(part) / float64(total) * 100
 }
 
-// TruncateString truncates a string to maxLen runes, adding "..." if needed.
+// sizeUnits maps the suffixes accepted by ParseSize to their byte multiplier,
+// mirroring the binary units FormatSize prints (KiB/MiB/...).
+var sizeUnits = map[string]int64{
+	"":   1,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+	"t":  1 << 40,
+	"tb": 1 << 40,
+	"p":  1 << 50,
+	"pb": 1 << 50,
+}
+
+// ParseSize parses a human-entered size like "1G", "512M", or "2048" (plain
+// bytes) into a byte count, for flags such as -min-dir-size. Units are
+// case-insensitive and the optional trailing "iB"/"b" is ignored, so "1g",
+// "1G", "1GB", and "1GiB" are all accepted and mean the same 2^30 bytes as
+// FormatSize would print.
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	i := len(s)
+	for i > 0 && (s[i-1] < '0' || s[i-1] > '9') && s[i-1] != '.' {
+		i--
+	}
+	numPart, unitPart := s[:i], s[i:]
+
+	unitPart = strings.ToLower(strings.TrimSpace(unitPart))
+	unitPart = strings.TrimSuffix(unitPart, "ib")
+	unitPart = strings.TrimSuffix(unitPart, "b")
+
+	mult, ok := sizeUnits[unitPart]
+	if !ok {
+		return 0, fmt.Errorf("unknown size unit %q", unitPart)
+	}
+
+	n, err := strconv.ParseFloat(numPart, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("size must not be negative: %q", s)
+	}
+
+	return int64(n * float64(mult)), nil
+}
+
+// ageUnits maps the suffixes accepted by ParseAge to their day multiplier.
+// Months and years are approximated as fixed-length days (30 and 365) since
+// -older-than describes a rough staleness cutoff, not a calendar date.
+var ageUnits = map[string]float64{
+	"d":  1,
+	"w":  7,
+	"mo": 30,
+	"y":  365,
+}
+
+// ParseAge parses a human-entered age like "30d", "2w", "6mo", or "1y" into
+// a time.Duration, for flags such as -older-than. Units are
+// case-insensitive and plural/long forms ("days", "months", "years") are
+// accepted by matching on their first letters, with "mo"/"month(s)"
+// disambiguated from minutes since this parser has no minute unit.
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty age")
+	}
+
+	i := len(s)
+	for i > 0 && (s[i-1] < '0' || s[i-1] > '9') && s[i-1] != '.' {
+		i--
+	}
+	numPart, unitPart := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+
+	var mult float64
+	switch {
+	case strings.HasPrefix(unitPart, "mo"):
+		mult = ageUnits["mo"]
+	case strings.HasPrefix(unitPart, "y"):
+		mult = ageUnits["y"]
+	case strings.HasPrefix(unitPart, "w"):
+		mult = ageUnits["w"]
+	case strings.HasPrefix(unitPart, "d"):
+		mult = ageUnits["d"]
+	default:
+		return 0, fmt.Errorf("unknown age unit %q", unitPart)
+	}
+
+	n, err := strconv.ParseFloat(numPart, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q: %w", s, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("age must not be negative: %q", s)
+	}
+
+	return time.Duration(n * mult * 24 * float64(time.Hour)), nil
+}
+
+// TruncateString truncates s to at most maxLen display columns, adding "..."
+// if needed. It measures display width rather than rune count, so wide
+// glyphs (CJK, many emoji) are accounted for correctly and the cut always
+// falls on a rune boundary.
 func TruncateString(s string, maxLen int) string {
 	if maxLen <= 0 {
 		return ""
 	}
-	runes := []rune(s)
-	if len(runes) <= maxLen {
+	if lipgloss.Width(s) <= maxLen {
 		return s
 	}
-	if maxLen <= 3 {
-		return string(runes[:maxLen])
+	budget := maxLen
+	suffix := ""
+	if maxLen > 3 {
+		budget = maxLen - 3
+		suffix = "..."
+	}
+	var b strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := lipgloss.Width(string(r))
+		if w+rw > budget {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
 	}
-	return string(runes[:maxLen-3]) + "..."
+	return b.String() + suffix
 }
@@ -2,6 +2,8 @@ package util
 
 import (
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 func TestFormatSize(t *testing.T) {
@@ -30,6 +32,117 @@ func TestFormatSize(t *testing.T) {
 	}
 }
 
+func TestFormatSizeSI(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0 B"},
+		{500, "500 B"},
+		{999, "999 B"},
+		{1000, "1.0 kB"},
+		{1500, "1.5 kB"},
+		{1000000, "1.0 MB"},
+		{1000000000, "1.0 GB"},
+		{1000000000000, "1.0 TB"},
+		{1000000000000000, "1.0 PB"},
+		{-1, "0 B"},
+	}
+
+	for _, tt := range tests {
+		got := FormatSizeSI(tt.bytes)
+		if got != tt.want {
+			t.Errorf("FormatSizeSI(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestSetSIUnits_SwitchesFormatSizeUnitSystem(t *testing.T) {
+	t.Cleanup(func() { SetSIUnits(false) })
+
+	SetSIUnits(true)
+	if got := FormatSize(1000); got != "1.0 kB" {
+		t.Errorf("FormatSize(1000) with SI units = %q, want %q", got, "1.0 kB")
+	}
+
+	SetSIUnits(false)
+	if got := FormatSize(1024); got != "1.0 KiB" {
+		t.Errorf("FormatSize(1024) with binary units = %q, want %q", got, "1.0 KiB")
+	}
+}
+
+func TestSetSizePrecision_ControlsDecimalPlaces(t *testing.T) {
+	t.Cleanup(func() { SetSizePrecision(1) })
+
+	const bytes = 1288490188 // 1.2000000417232513 GiB
+
+	SetSizePrecision(0)
+	if got := FormatSize(bytes); got != "1 GiB" {
+		t.Errorf("FormatSize(%d) at precision 0 = %q, want %q", bytes, got, "1 GiB")
+	}
+
+	SetSizePrecision(1)
+	if got := FormatSize(bytes); got != "1.2 GiB" {
+		t.Errorf("FormatSize(%d) at precision 1 = %q, want %q", bytes, got, "1.2 GiB")
+	}
+
+	SetSizePrecision(2)
+	if got := FormatSize(bytes); got != "1.20 GiB" {
+		t.Errorf("FormatSize(%d) at precision 2 = %q, want %q", bytes, got, "1.20 GiB")
+	}
+}
+
+func TestSetSizePrecision_ClampsOutOfRangeValues(t *testing.T) {
+	t.Cleanup(func() { SetSizePrecision(1) })
+
+	SetSizePrecision(-1)
+	if got := FormatSize(1536); got != "2 KiB" {
+		t.Errorf("FormatSize(1536) clamped to precision 0 = %q, want %q", got, "2 KiB")
+	}
+
+	SetSizePrecision(5)
+	if got := FormatSize(1536); got != "1.50 KiB" {
+		t.Errorf("FormatSize(1536) clamped to precision 2 = %q, want %q", got, "1.50 KiB")
+	}
+}
+
+func TestSetSizePrecision_AppliesToFormatCount(t *testing.T) {
+	t.Cleanup(func() { SetSizePrecision(1) })
+
+	SetSizePrecision(0)
+	if got := FormatCount(1500); got != "2K" {
+		t.Errorf("FormatCount(1500) at precision 0 = %q, want %q", got, "2K")
+	}
+
+	SetSizePrecision(2)
+	if got := FormatCount(1500); got != "1.50K" {
+		t.Errorf("FormatCount(1500) at precision 2 = %q, want %q", got, "1.50K")
+	}
+}
+
+func TestFormatSizeAligned(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		width int
+		want  string
+	}{
+		{0, 9, "      0 B"},
+		{1536, 9, "  1.5 KiB"},
+		{1073741824, 9, "  1.0 GiB"},
+		{1125899906842624, 5, "1.0 PiB"},
+	}
+
+	for _, tt := range tests {
+		got := FormatSizeAligned(tt.bytes, tt.width)
+		if got != tt.want {
+			t.Errorf("FormatSizeAligned(%d, %d) = %q, want %q", tt.bytes, tt.width, got, tt.want)
+		}
+		if len(got) < tt.width && len(got) != len(tt.want) {
+			t.Errorf("FormatSizeAligned(%d, %d) = %q, shorter than width", tt.bytes, tt.width, got)
+		}
+	}
+}
+
 func TestFormatCount(t *testing.T) {
 	tests := []struct {
 		n    int64
@@ -77,6 +190,79 @@ func TestPercent(t *testing.T) {
 	}
 }
 
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0", 0},
+		{"500", 500},
+		{"1024", 1024},
+		{"1K", 1024},
+		{"1k", 1024},
+		{"1KB", 1024},
+		{"1KiB", 1024},
+		{"1M", 1 << 20},
+		{"1G", 1 << 30},
+		{"1.5G", int64(1.5 * (1 << 30))},
+		{"1T", 1 << 40},
+		{"1P", 1 << 50},
+		{" 1G ", 1 << 30},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if err != nil {
+			t.Errorf("ParseSize(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseSize_RejectsInvalidInput(t *testing.T) {
+	for _, in := range []string{"", "G", "-1G", "1X", "abc"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30d", 30 * 24 * time.Hour},
+		{"2w", 2 * 7 * 24 * time.Hour},
+		{"6mo", 6 * 30 * 24 * time.Hour},
+		{"6months", 6 * 30 * 24 * time.Hour},
+		{"1y", 365 * 24 * time.Hour},
+		{"1.5d", time.Duration(1.5 * 24 * float64(time.Hour))},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if err != nil {
+			t.Errorf("ParseAge(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAge_RejectsInvalidInput(t *testing.T) {
+	for _, in := range []string{"", "30", "-1d", "1x"} {
+		if _, err := ParseAge(in); err == nil {
+			t.Errorf("ParseAge(%q) expected error, got nil", in)
+		}
+	}
+}
+
 func TestTruncateString(t *testing.T) {
 	tests := []struct {
 		s      string
@@ -90,9 +276,12 @@ func TestTruncateString(t *testing.T) {
 		{"hello", 2, "he"},
 		{"hello", 1, "h"},
 		{"hello", 0, ""},
-		{"こんにちは", 3, "こんに"},
-		{"こんにちは", 5, "こんにちは"},
+		{"こんにちは", 3, "こ"},
+		{"こんにちは", 10, "こんにちは"},
 		{"abcdefgh", 6, "abc..."},
+		{"こんにちは", 5, "こ..."},
+		{"😀😀😀😀", 3, "😀"},
+		{"😀😀😀😀", 7, "😀😀..."},
 	}
 
 	for _, tt := range tests {
@@ -102,3 +291,14 @@ func TestTruncateString(t *testing.T) {
 		}
 	}
 }
+
+func TestTruncateString_NeverSplitsAMultibyteRune(t *testing.T) {
+	for _, s := range []string{"こんにちは", "😀🎉🚀", "héllo wörld"} {
+		for maxLen := 0; maxLen <= 8; maxLen++ {
+			got := TruncateString(s, maxLen)
+			if !utf8.ValidString(got) {
+				t.Fatalf("TruncateString(%q, %d) produced invalid UTF-8: %q", s, maxLen, got)
+			}
+		}
+	}
+}
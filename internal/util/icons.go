@@ -1,6 +1,9 @@
 package util
 
-import "strings"
+import (
+	"strings"
+	"sync"
+)
 
 // Icon returns a Unicode icon for the given filename or directory.
 func Icon(name string, isDir bool) string {
@@ -10,9 +13,50 @@ func Icon(name string, isDir bool) string {
 	return FileIcon(name)
 }
 
+// dirIconOverrides and dirColorOverrides hold user-registered directory name
+// -> icon/color mappings, layered on top of the built-in dirIcons table.
+// Populated at startup by internal/config from the user's icon config file.
+var (
+	overrideMu        sync.RWMutex
+	dirIconOverrides  = map[string]string{}
+	dirColorOverrides = map[string]string{}
+)
+
+// RegisterDirIcon adds or replaces the icon shown for directories named
+// name (case-insensitive), overriding the built-in default if any.
+func RegisterDirIcon(name, icon string) {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+	dirIconOverrides[strings.ToLower(name)] = icon
+}
+
+// RegisterDirColor adds or replaces the highlight color used for directories
+// named name (case-insensitive). The value is whatever lipgloss.Color
+// accepts (a hex string or an ANSI color number as a string).
+func RegisterDirColor(name, color string) {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+	dirColorOverrides[strings.ToLower(name)] = color
+}
+
+// DirColor returns the registered highlight color for a directory name, if
+// any was configured.
+func DirColor(name string) (string, bool) {
+	overrideMu.RLock()
+	defer overrideMu.RUnlock()
+	color, ok := dirColorOverrides[strings.ToLower(name)]
+	return color, ok
+}
+
 // DirIcon returns an icon for a directory name.
 func DirIcon(name string) string {
 	lower := strings.ToLower(name)
+	overrideMu.RLock()
+	icon, ok := dirIconOverrides[lower]
+	overrideMu.RUnlock()
+	if ok {
+		return icon
+	}
 	if icon, ok := dirIcons[lower]; ok {
 		return icon
 	}
@@ -0,0 +1,51 @@
+package util
+
+import "testing"
+
+func TestDirIcon_UsesRegisteredOverride(t *testing.T) {
+	t.Cleanup(func() {
+		overrideMu.Lock()
+		delete(dirIconOverrides, "myproject")
+		overrideMu.Unlock()
+	})
+
+	if got := DirIcon("myproject"); got != "📁" {
+		t.Fatalf("expected default icon before registration, got %q", got)
+	}
+
+	RegisterDirIcon("MyProject", "🚀")
+	if got := DirIcon("myproject"); got != "🚀" {
+		t.Fatalf("expected overridden icon, got %q", got)
+	}
+}
+
+func TestDirIcon_OverrideTakesPrecedenceOverBuiltin(t *testing.T) {
+	t.Cleanup(func() {
+		overrideMu.Lock()
+		delete(dirIconOverrides, "src")
+		overrideMu.Unlock()
+	})
+
+	RegisterDirIcon("src", "⭐")
+	if got := DirIcon("src"); got != "⭐" {
+		t.Fatalf("expected override to win over built-in, got %q", got)
+	}
+}
+
+func TestDirColor_ReturnsRegisteredColor(t *testing.T) {
+	t.Cleanup(func() {
+		overrideMu.Lock()
+		delete(dirColorOverrides, "important")
+		overrideMu.Unlock()
+	})
+
+	if _, ok := DirColor("important"); ok {
+		t.Fatal("expected no color registered initially")
+	}
+
+	RegisterDirColor("Important", "#ff0000")
+	color, ok := DirColor("important")
+	if !ok || color != "#ff0000" {
+		t.Fatalf("expected registered color #ff0000, got %q (ok=%v)", color, ok)
+	}
+}